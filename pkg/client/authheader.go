@@ -0,0 +1,41 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// WithDuplicateAuthHeader additionally sends the token under the given header name, e.g. "X-Anexia-Token".
+//
+// This is meant for environments with proxies stripping or rewriting the Authorization header.
+// The additional header carries the bare token without scheme. Sending the token in a second header
+// exposes it to every intermediary logging or forwarding that header, which is usually not as carefully
+// treated as the Authorization header. Only use this if the Authorization header is known not to reach the API.
+// The header is redacted in the requests written to the LogWriter.
+func WithDuplicateAuthHeader(name string) Option {
+	return func(o *optionSet) error {
+		if name == "" || http.CanonicalHeaderKey(name) == "Authorization" {
+			return fmt.Errorf("%w: invalid duplicate auth header name '%s'", ErrConfiguration, name)
+		}
+		o.duplicateAuthHeader = name
+
+		return nil
+	}
+}
+
+// authorize sets the Authorization header of the request and its duplicate, if configured.
+func (t *transport) authorize(req *http.Request, scheme, token string) {
+	req.Header.Set("Authorization", fmt.Sprintf("%s %v", scheme, token))
+	if t.duplicateAuthHeader != "" {
+		req.Header.Set(t.duplicateAuthHeader, token)
+	}
+}
+
+// redactedHeaders returns the names of the headers carrying credentials besides the Authorization header.
+func (t *transport) redactedHeaders() []string {
+	if t.duplicateAuthHeader == "" {
+		return nil
+	}
+
+	return []string{t.duplicateAuthHeader}
+}
@@ -0,0 +1,43 @@
+package client_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/anexia-it/go-anxcloud/pkg/client"
+	"github.com/anexia-it/go-anxcloud/pkg/test/echo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDuplicateAuthHeader(t *testing.T) {
+	dummyToken := "ie7dois8Ooquoo1ieB9kae8Od9ooshee3nejuach4inae3gai0Re0Shaipeihail" //nolint:gosec // Not a real token.
+	logs := bytes.Buffer{}
+	c, err := client.New(client.TokenFromString(dummyToken), client.WithDuplicateAuthHeader("X-Anexia-Token"),
+		client.LogWriter(&logs))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	echoHandler := echo.TestMock(t)
+	cw, server := client.NewTestClient(c, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.EqualValues(t, "Token "+dummyToken, r.Header.Get("Authorization"))
+		assert.EqualValues(t, dummyToken, r.Header.Get("X-Anexia-Token"))
+		echoHandler.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), client.DefaultRequestTimeout)
+	defer cancel()
+	assert.NoError(t, echo.NewAPI(cw).Echo(ctx))
+
+	assert.Contains(t, logs.String(), "X-Anexia-Token: REDACTED")
+	assert.NotContains(t, logs.String(), dummyToken)
+}
+
+func TestDuplicateAuthHeaderInvalid(t *testing.T) {
+	_, err := client.New(client.TokenFromString("token"), client.WithDuplicateAuthHeader("authorization"))
+	assert.True(t, errors.Is(err, client.ErrConfiguration), "expected ErrConfiguration but got %v", err)
+}
@@ -0,0 +1,95 @@
+package client
+
+import (
+	"fmt"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// BackoffStrategy determines the delay between attempts of a retried request.
+type BackoffStrategy interface {
+	// NextDelay returns the delay before the attempt following the given, failed one, starting at 1.
+	// The response is the one of the failed attempt, nil if it failed without a response.
+	NextDelay(attempt int, response *http.Response) time.Duration
+}
+
+// BackoffFunc adapts a function to a BackoffStrategy.
+type BackoffFunc func(attempt int, response *http.Response) time.Duration
+
+// NextDelay calls the function.
+func (f BackoffFunc) NextDelay(attempt int, response *http.Response) time.Duration {
+	return f(attempt, response)
+}
+
+// WithBackoff lets the given strategy determine the delay between retry attempts instead of
+// the exponential backoff configured via WithRetry. If the API asks for a longer delay via the
+// Retry-After header, that one is used instead. This only has an effect in combination with WithRetry.
+func WithBackoff(strategy BackoffStrategy) Option {
+	return func(o *optionSet) error {
+		if strategy == nil {
+			return fmt.Errorf("%w: backoff strategy must not be nil", ErrConfiguration)
+		}
+		o.retry.backoff = strategy
+
+		return nil
+	}
+}
+
+// ConstantBackoff waits the same delay between all attempts.
+func ConstantBackoff(delay time.Duration) BackoffStrategy {
+	return BackoffFunc(func(int, *http.Response) time.Duration {
+		return delay
+	})
+}
+
+// ExponentialBackoff starts with the base delay and doubles it with every attempt, up to maxDelay.
+// A maxDelay of 0 does not limit the delay.
+func ExponentialBackoff(base, maxDelay time.Duration) BackoffStrategy {
+	return BackoffFunc(func(attempt int, _ *http.Response) time.Duration {
+		return exponentialDelay(base, maxDelay, attempt)
+	})
+}
+
+// FullJitterBackoff waits a random delay between zero and the delay of ExponentialBackoff, which spreads
+// the retries of many clients failing at the same time.
+func FullJitterBackoff(base, maxDelay time.Duration) BackoffStrategy {
+	return BackoffFunc(func(attempt int, _ *http.Response) time.Duration {
+		delay := exponentialDelay(base, maxDelay, attempt)
+		if delay <= 0 {
+			return 0
+		}
+
+		return time.Duration(rand.Int63n(int64(delay) + 1)) //nolint:gosec // No cryptographic randomness needed.
+	})
+}
+
+// EqualJitterBackoff waits at least half of the delay of ExponentialBackoff plus a random share of
+// the other half. This spreads retries like FullJitterBackoff while keeping a minimum delay.
+// It is the strategy used by WithRetry if no other is set.
+func EqualJitterBackoff(base, maxDelay time.Duration) BackoffStrategy {
+	return BackoffFunc(func(attempt int, _ *http.Response) time.Duration {
+		delay := exponentialDelay(base, maxDelay, attempt)
+		if delay <= 1 {
+			return delay
+		}
+
+		half := delay / 2
+		return half + time.Duration(rand.Int63n(int64(delay-half)+1)) //nolint:gosec // No cryptographic randomness needed.
+	})
+}
+
+func exponentialDelay(base, maxDelay time.Duration, attempt int) time.Duration {
+	delay := base
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if delay <= 0 || (maxDelay > 0 && delay >= maxDelay) {
+			return maxDelay
+		}
+	}
+	if maxDelay > 0 && delay > maxDelay {
+		return maxDelay
+	}
+
+	return delay
+}
@@ -0,0 +1,52 @@
+package client_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/anexia-it/go-anxcloud/pkg/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExponentialBackoff(t *testing.T) {
+	backoff := client.ExponentialBackoff(100*time.Millisecond, time.Second)
+	for attempt, expected := range []time.Duration{100 * time.Millisecond, 200 * time.Millisecond,
+		400 * time.Millisecond, 800 * time.Millisecond, time.Second, time.Second} {
+		assert.EqualValues(t, expected, backoff.NextDelay(attempt+1, nil), "attempt %d", attempt+1)
+	}
+
+	jitter := client.FullJitterBackoff(100*time.Millisecond, time.Second)
+	for attempt := 1; attempt < 10; attempt++ {
+		delay := jitter.NextDelay(attempt, nil)
+		assert.GreaterOrEqual(t, delay, time.Duration(0))
+		assert.LessOrEqual(t, delay, backoff.NextDelay(attempt, nil))
+	}
+}
+
+func TestWithBackoff(t *testing.T) {
+	server, requests := newFlakyServer(2, http.StatusBadGateway, `{"error":{"code":502}}`)
+	defer server.Close()
+
+	var attempts []int
+	var statuses []int
+	strategy := client.BackoffFunc(func(attempt int, response *http.Response) time.Duration {
+		attempts = append(attempts, attempt)
+		statuses = append(statuses, response.StatusCode)
+		return time.Millisecond
+	})
+
+	c, err := client.New(client.TokenFromString("token"), client.WithRetry(3, time.Hour), client.WithBackoff(strategy))
+	assert.NoError(t, err)
+
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	assert.NoError(t, err)
+	response, err := c.Do(req)
+	assert.NoError(t, err)
+	if assert.NotNil(t, response) {
+		assert.EqualValues(t, http.StatusOK, response.StatusCode)
+	}
+	assert.EqualValues(t, 3, *requests)
+	assert.EqualValues(t, []int{1, 2}, attempts)
+	assert.EqualValues(t, []int{http.StatusBadGateway, http.StatusBadGateway}, statuses)
+}
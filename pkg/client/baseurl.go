@@ -0,0 +1,27 @@
+package client
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// BaseURL lets the client send requests to the engine at the given URL instead of DefaultBaseURL,
+// e.g. a staging environment or a mock.
+//
+// The URL has to be absolute with http or https scheme. Trailing slashes are removed, as API paths are
+// appended to it.
+func BaseURL(baseURL string) Option {
+	return func(o *optionSet) error {
+		parsed, err := url.Parse(baseURL)
+		if err != nil {
+			return fmt.Errorf("%w: invalid base URL: %v", ErrConfiguration, err)
+		}
+		if (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return fmt.Errorf("%w: base URL '%s' is not an absolute http or https URL", ErrConfiguration, baseURL)
+		}
+		o.baseURL = strings.TrimRight(baseURL, "/")
+
+		return nil
+	}
+}
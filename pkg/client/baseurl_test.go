@@ -0,0 +1,27 @@
+package client_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/anexia-it/go-anxcloud/pkg/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBaseURL(t *testing.T) {
+	c, err := client.New(client.TokenFromString("token"))
+	if assert.NoError(t, err) {
+		assert.EqualValues(t, client.DefaultBaseURL, c.BaseURL())
+	}
+
+	c, err = client.New(client.TokenFromString("token"), client.BaseURL("https://staging.example.com/engine//"))
+	if assert.NoError(t, err) {
+		assert.EqualValues(t, "https://staging.example.com/engine", c.BaseURL())
+		assert.EqualValues(t, "https://staging.example.com/engine", client.BindLocation(c, "location").BaseURL())
+	}
+
+	for _, invalid := range []string{"", "staging.example.com", "ftp://staging.example.com", "https://", "http://[::1"} {
+		_, err = client.New(client.TokenFromString("token"), client.BaseURL(invalid))
+		assert.True(t, errors.Is(err, client.ErrConfiguration), "expected ErrConfiguration for %q but got %v", invalid, err)
+	}
+}
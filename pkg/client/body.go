@@ -0,0 +1,93 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// NewJSONRequest creates a request with v encoded as JSON body. The body is encoded while the request is
+// sent instead of being buffered first, so large payloads like certificate bundles are not held in memory
+// twice. The request is sent without Content-Length, using chunked transfer encoding.
+//
+// Errors encoding v fail the request when it is sent. The body is encoded again for every retry. Dumping
+// requests to a LogWriter, Logger or CurlWriter still reads their body into memory.
+func NewJSONRequest(ctx context.Context, method, url string, v interface{}) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, &jsonBody{v: v})
+	if err != nil {
+		return nil, err
+	}
+	req.GetBody = func() (io.ReadCloser, error) {
+		return &jsonBody{v: v}, nil
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	return req, nil
+}
+
+// NewReaderRequest creates a request sending body as is, e.g. a zone file read from disk.
+//
+// size is the length of body in bytes and sent as Content-Length, a negative size sends the request using
+// chunked transfer encoding and a size of 0 sends no body at all. The caller keeps ownership of body, which is not closed by the client. If body
+// implements io.Seeker, it is rewound for retries, otherwise requests are not retried.
+func NewReaderRequest(ctx context.Context, method, url string, body io.Reader, size int64) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, io.NopCloser(body))
+	if err != nil {
+		return nil, err
+	}
+
+	if size == 0 {
+		req.Body = http.NoBody
+	} else {
+		req.ContentLength = size
+	}
+
+	if seeker, ok := body.(io.Seeker); ok && size != 0 {
+		start, err := seeker.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return nil, fmt.Errorf("could not determine position of request body: %w", err)
+		}
+		req.GetBody = func() (io.ReadCloser, error) {
+			if _, err := seeker.Seek(start, io.SeekStart); err != nil {
+				return nil, fmt.Errorf("could not rewind request body: %w", err)
+			}
+
+			return io.NopCloser(body), nil
+		}
+	}
+
+	return req, nil
+}
+
+// jsonBody encodes its value into a pipe, starting once it is read first.
+type jsonBody struct {
+	v      interface{}
+	once   sync.Once
+	reader *io.PipeReader
+}
+
+func (b *jsonBody) start() {
+	b.once.Do(func() {
+		reader, writer := io.Pipe()
+		b.reader = reader
+		go func() {
+			_ = writer.CloseWithError(json.NewEncoder(writer).Encode(b.v))
+		}()
+	})
+}
+
+func (b *jsonBody) Read(p []byte) (int, error) {
+	b.start()
+
+	return b.reader.Read(p)
+}
+
+// Close stops the encoding, unblocking it if the body was not read completely.
+func (b *jsonBody) Close() error {
+	b.start()
+
+	return b.reader.Close()
+}
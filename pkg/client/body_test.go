@@ -0,0 +1,75 @@
+package client_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/anexia-it/go-anxcloud/pkg/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStreamingBodies(t *testing.T) {
+	var bodies []string
+	var lengths []int64
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		body, err := ioutil.ReadAll(r.Body)
+		assert.NoError(t, err)
+		bodies = append(bodies, string(body))
+		lengths = append(lengths, r.ContentLength)
+		if requests%2 == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(`{"error":{"code":503,"message":"unavailable"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`"ok"`))
+	}))
+	defer server.Close()
+
+	c, err := client.New(client.TokenFromString("token"), client.WithRetry(2, time.Millisecond))
+	assert.NoError(t, err)
+
+	t.Run("JSON", func(t *testing.T) {
+		bodies, lengths = nil, nil
+		payload := map[string]string{"certificate": strings.Repeat("a", 1<<16)}
+		req, err := client.NewJSONRequest(context.Background(), http.MethodPut, server.URL, payload)
+		assert.NoError(t, err)
+		assert.EqualValues(t, "application/json", req.Header.Get("Content-Type"))
+
+		response, err := c.Do(req)
+		if assert.NoError(t, err) {
+			_ = response.Body.Close()
+		}
+		if assert.Len(t, bodies, 2) {
+			for i, body := range bodies {
+				var received map[string]string
+				assert.NoError(t, json.Unmarshal([]byte(body), &received))
+				assert.EqualValues(t, payload, received)
+				assert.EqualValues(t, -1, lengths[i])
+			}
+		}
+	})
+
+	t.Run("Reader", func(t *testing.T) {
+		bodies, lengths = nil, nil
+		reader := bytes.NewReader([]byte("skip:zone data"))
+		_, _ = reader.Seek(5, 0)
+		req, err := client.NewReaderRequest(context.Background(), http.MethodPut, server.URL, reader, 9)
+		assert.NoError(t, err)
+
+		response, err := c.Do(req)
+		if assert.NoError(t, err) {
+			_ = response.Body.Close()
+		}
+		assert.EqualValues(t, []string{"zone data", "zone data"}, bodies)
+		assert.EqualValues(t, []int64{9, 9}, lengths)
+	})
+}
@@ -2,20 +2,33 @@
 package client
 
 import (
+	"bytes"
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"net/http/httputil"
+	"net/url"
 	"os"
+	"sort"
+	"strings"
 	"time"
 )
 
 const (
 	// TokenEnvName is the name of the environment variable that should contain the API token.
 	TokenEnvName = "ANEXIA_TOKEN" //nolint:gosec // This is a name, not a secret.
+	// ClientIDEnvName is the name of the environment variable that should contain the OAuth2 client ID.
+	ClientIDEnvName = "ANEXIA_CLIENT_ID"
+	// ClientSecretEnvName is the name of the environment variable that should contain the OAuth2 client secret.
+	ClientSecretEnvName = "ANEXIA_CLIENT_SECRET" //nolint:gosec // This is a name, not a secret.
+	// TokenURLEnvName is the name of the environment variable that should contain the OAuth2 token endpoint.
+	TokenURLEnvName = "ANEXIA_TOKEN_URL" //nolint:gosec // This is a name, not a secret.
 	// VsphereLocationEnvName is the name of the environment variable that should contain a test location for paths that need a provisioning location.
 	VsphereLocationEnvName = "ANEXIA_VSPHERE_LOCATION_ID"
 	// CoreLocationEnvName is the name of the environment variable that should contain a test location for paths that need a core location.
@@ -26,7 +39,7 @@ const (
 	IntegrationTestEnvName = "ANEXIA_INTEGRATION_TESTS_ON"
 	// DefaultBaseURL is the default base URL used for requests.
 	DefaultBaseURL = "https://engine.anexia-it.com"
-	// DefaultRequestTimeout is a suggested timeout for API calls.
+	// DefaultRequestTimeout is the timeout applied to API calls without a deadline, see RequestTimeout.
 	DefaultRequestTimeout = 10 * time.Second
 )
 
@@ -34,6 +47,9 @@ const (
 var ErrEnvMissing = errors.New("environment variable missing")
 
 // Client interacts with the anxcloud API.
+//
+// Clients created with New are safe for concurrent use by multiple goroutines, including
+// rotating their token with SetToken and reading ClockSkew and ConnectionStats while requests are in flight.
 type Client interface {
 	// Do fires a given http.Request against the API.
 	// This method behaves as http.Client.Do, but signs the request prior to sending it out
@@ -56,41 +72,139 @@ type ResponseError struct {
 	} `json:"debug"`
 }
 
+// Error formats the code, the message and the failed validations of the error, like
+// "received error from api: 400 invalid request (name: must not be empty; ttl: must be positive)".
+// If the API returned no code, the status code of the response is used.
 func (r ResponseError) Error() string {
-	return fmt.Sprintf("received error from api: %+v", r.ErrorData)
+	code := r.ErrorData.Code
+	if code == 0 && r.Response != nil {
+		code = r.Response.StatusCode
+	}
+
+	message := fmt.Sprintf("received error from api: %d", code)
+	if r.ErrorData.Message != "" {
+		message += " " + r.ErrorData.Message
+	}
+	if len(r.ErrorData.Validation) == 0 {
+		return message
+	}
+
+	fields := make([]string, 0, len(r.ErrorData.Validation))
+	for field := range r.ErrorData.Validation {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	for i, field := range fields {
+		fields[i] = fmt.Sprintf("%s: %s", field, r.ErrorData.Validation[field])
+	}
+
+	return fmt.Sprintf("%s (%s)", message, strings.Join(fields, "; "))
+}
+
+// ValidationErrors returns a copy of the validation errors by field name, nil if there are none.
+func (r ResponseError) ValidationErrors() map[string]string {
+	if len(r.ErrorData.Validation) == 0 {
+		return nil
+	}
+
+	validation := make(map[string]string, len(r.ErrorData.Validation))
+	for field, message := range r.ErrorData.Validation {
+		validation[field] = message
+	}
+
+	return validation
+}
+
+// FieldError returns the validation error of the given field, if it failed validation.
+func (r ResponseError) FieldError(field string) (string, bool) {
+	message, ok := r.ErrorData.Validation[field]
+
+	return message, ok
 }
 
-func handleRequest(c *http.Client, req *http.Request, logWriter io.Writer) (*http.Response, error) {
-	if logWriter != nil {
-		reqBytes, dumpErr := dumpRequest(req)
-		if dumpErr == nil {
-			fmt.Fprintf(logWriter, "request: %s\n", string(reqBytes))
+// handleRequest sends the request, passing it and its response to logger if set.
+// The Authorization header and the given redactHeaders are redacted in the dump and can not be set via WithExtraHeaders.
+func handleRequest(c *http.Client, req *http.Request, logger Logger, redactHeaders ...string) (*http.Response, error) {
+	setExtraHeaders(req, redactHeaders...)
+	correlationID := setCorrelationID(req, false)
+
+	if logger != nil {
+		entry := RequestLogEntry{Method: req.Method, URL: req.URL.String(), CorrelationID: correlationID}
+		if reqBytes, dumpErr := dumpRequest(req, redactHeaders...); dumpErr == nil {
+			entry.Dump = reqBytes
 		}
+		logger.LogRequest(entry)
 	}
+	start := time.Now()
 	response, err := c.Do(req)
+	duration := time.Since(start)
+	if err == nil {
+		err = decompress(response)
+	}
 	if err == nil && (response.StatusCode < http.StatusOK || response.StatusCode >= http.StatusMultipleChoices) {
+		// The body is buffered and replaced, so callers can still read it after it was decoded here.
+		body, readErr := ioutil.ReadAll(response.Body)
+		_ = response.Body.Close()
+		response.Body = bufferedBody{bytes.NewReader(body)}
+		if readErr != nil {
+			return response, fmt.Errorf("could not read error response: %w", readErr)
+		}
+
 		errResponse := ResponseError{Request: req, Response: response}
-		if decodeErr := json.NewDecoder(response.Body).Decode(&errResponse); decodeErr != nil {
-			return response, fmt.Errorf("could not decode error response: %w", decodeErr)
+		if decodeErr := json.Unmarshal(body, &errResponse); decodeErr != nil {
+			err = fmt.Errorf("could not decode error response: %w", decodeErr)
+		} else {
+			err = &errResponse
 		}
 
-		err = &errResponse
+		retryAfter, hasRetryAfter := retryAfterOf(response)
+		if hasRetryAfter {
+			err = &RetryAfterError{RetryAfter: retryAfter, Err: err}
+		}
+		if response.StatusCode == http.StatusTooManyRequests {
+			err = &RateLimitError{RetryAfter: retryAfter, Err: err}
+		}
 	}
 
-	if logWriter != nil && response != nil {
-		respBytes, dumpErr := httputil.DumpResponse(response, err == nil)
-		if dumpErr == nil {
-			fmt.Fprintf(logWriter, "response: %s\n", string(respBytes))
+	if logger != nil {
+		entry := ResponseLogEntry{
+			Method:        req.Method,
+			URL:           req.URL.String(),
+			CorrelationID: correlationID,
+			Duration:      duration,
+			Err:           err,
+		}
+		if response != nil {
+			entry.StatusCode = response.StatusCode
+			_, redactBody := req.Context().Value(redactedBodyKey{}).(bool)
+			if respBytes, dumpErr := httputil.DumpResponse(response, err == nil && !redactBody); dumpErr == nil {
+				entry.Dump = respBytes
+			}
 		}
+		logger.LogResponse(entry)
 	}
 
 	return response, err
 }
 
-func dumpRequest(req *http.Request) ([]byte, error) {
+type redactedBodyKey struct{}
+
+// WithRedactedBody marks requests using the returned context as carrying secrets in their request or response body.
+// The bodies of such requests and their responses are omitted when they are dumped to the LogWriter.
+func WithRedactedBody(ctx context.Context) context.Context {
+	return context.WithValue(ctx, redactedBodyKey{}, true)
+}
+
+func dumpRequest(req *http.Request, redactHeaders ...string) ([]byte, error) {
+	_, redactBody := req.Context().Value(redactedBodyKey{}).(bool)
 	clonedRequest := req.Clone(context.Background())
 	clonedRequest.Header.Set("Authorization", "REDACTED")
-	dumpedRequest, err := httputil.DumpRequestOut(clonedRequest, true)
+	for _, name := range redactHeaders {
+		if clonedRequest.Header.Get(name) != "" {
+			clonedRequest.Header.Set(name, "REDACTED")
+		}
+	}
+	dumpedRequest, err := httputil.DumpRequestOut(clonedRequest, !redactBody)
 	if err != nil {
 		return nil, err
 	}
@@ -103,9 +217,37 @@ func dumpRequest(req *http.Request) ([]byte, error) {
 }
 
 type optionSet struct {
-	httpClient *http.Client
-	token      string
-	logWriter  io.Writer
+	httpClient   *http.Client
+	token        string
+	clientID     string
+	clientSecret string
+	tokenURL     string
+	logWriter    io.Writer
+	logger       Logger
+	maxClockSkew time.Duration
+	retry        retryOptions
+
+	generateCorrelationIDs bool
+	defaultLocation        string
+	duplicateAuthHeader    string
+	connectionStats        bool
+	curlWriter             io.Writer
+	methodOverride         bool
+	maxConcurrency         int
+	userAgent              string
+	tokenFile              string
+	tokenFileReload        time.Duration
+	requestTimeout         time.Duration
+	metrics                func(RequestMetric)
+	compression            bool
+	baseURL                string
+	proxy                  *url.URL
+	headers                http.Header
+	tlsConfig              *tls.Config
+	rootCAs                *x509.CertPool
+	insecureSkipVerify     bool
+	// ownsHTTPClient is true if httpClient was created for this client and is not shared with other code.
+	ownsHTTPClient bool
 }
 
 // Option is a optional parameter for the New method.
@@ -143,7 +285,68 @@ func TokenFromEnv(unset bool) Option {
 	}
 }
 
-// LogWriter configures the debug writer for logging requests and responses
+// ClientCredentials authenticates using the OAuth2 client credentials grant.
+//
+// An access token is obtained from tokenURL on the first request and cached until shortly before it expires.
+// Clients used by multiple goroutines share the cached token and refresh it only once. ErrConfiguration is
+// returned if the client ID or secret is empty or tokenURL is no absolute http or https URL.
+func ClientCredentials(clientID, clientSecret, tokenURL string) Option {
+	return func(o *optionSet) error {
+		if clientID == "" || clientSecret == "" {
+			return fmt.Errorf("%w: client ID and client secret must not be empty", ErrConfiguration)
+		}
+		if parsed, err := url.Parse(tokenURL); err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return fmt.Errorf("%w: token URL '%s' is not an absolute http or https URL", ErrConfiguration, tokenURL)
+		}
+
+		o.clientID = clientID
+		o.clientSecret = clientSecret
+		o.tokenURL = tokenURL
+
+		return nil
+	}
+}
+
+// ClientCredentialsFromEnv fetches the OAuth2 client credentials and token endpoint from environment variables.
+func ClientCredentialsFromEnv() Option {
+	return func(o *optionSet) error {
+		values := make(map[string]string, 3)
+		for _, name := range []string{ClientIDEnvName, ClientSecretEnvName, TokenURLEnvName} {
+			value, present := os.LookupEnv(name)
+			if !present {
+				return fmt.Errorf("%w: %s", ErrEnvMissing, name)
+			}
+			values[name] = value
+		}
+
+		return ClientCredentials(values[ClientIDEnvName], values[ClientSecretEnvName], values[TokenURLEnvName])(o)
+	}
+}
+
+// AuthAuto picks the first available method of authentication from the environment.
+//
+// A token from TokenEnvName is preferred, otherwise OAuth2 client credentials from ClientIDEnvName,
+// ClientSecretEnvName and TokenURLEnvName are used. If neither is available ErrConfiguration is
+// returned, listing the variables that were looked for.
+func AuthAuto() Option {
+	return func(o *optionSet) error {
+		tokenErr := TokenFromEnv(false)(o)
+		if tokenErr == nil {
+			return nil
+		}
+
+		credentialsErr := ClientCredentialsFromEnv()(o)
+		if credentialsErr == nil {
+			return nil
+		}
+
+		return fmt.Errorf("%w: no authentication found, tried token (%v) and client credentials (%v)",
+			ErrConfiguration, tokenErr, credentialsErr)
+	}
+}
+
+// LogWriter configures the debug writer for logging requests and responses.
+// Use WithLogger to pass them to a structured logger instead.
 func LogWriter(w io.Writer) Option {
 	return func(o *optionSet) error {
 		o.logWriter = w
@@ -152,7 +355,8 @@ func LogWriter(w io.Writer) Option {
 	}
 }
 
-// HTTPClient lets the client use the given http.Client.
+// HTTPClient lets the client use the given http.Client. It takes precedence over WithProxy, WithTLSConfig,
+// WithRootCAs and WithInsecureSkipVerify.
 func HTTPClient(c *http.Client) Option {
 	return func(o *optionSet) error {
 		o.httpClient = c
@@ -161,6 +365,24 @@ func HTTPClient(c *http.Client) Option {
 	}
 }
 
+// defaultHTTPClient returns http.DefaultClient, or a client with a clone of http.DefaultTransport if a proxy
+// or TLS settings are configured.
+func defaultHTTPClient(o optionSet) *http.Client {
+	if o.proxy == nil && o.tlsConfig == nil && o.rootCAs == nil && !o.insecureSkipVerify {
+		return http.DefaultClient
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if o.proxy != nil {
+		transport.Proxy = http.ProxyURL(o.proxy)
+	}
+	if o.tlsConfig != nil || o.rootCAs != nil || o.insecureSkipVerify {
+		transport.TLSClientConfig = o.tlsClientConfig()
+	}
+
+	return &http.Client{Transport: transport}
+}
+
 // ErrConfiguration is raised when the given configuration is insufficient or erroneous.
 var ErrConfiguration = errors.New("could not configure client")
 
@@ -169,23 +391,40 @@ var ErrConfiguration = errors.New("could not configure client")
 // The options need to contain a method of authentication with the API. If you are
 // unsure what to use pass AuthFromEnv.
 func New(options ...Option) (Client, error) {
-	optionSet := optionSet{}
+	optionSet := optionSet{requestTimeout: DefaultRequestTimeout}
 	for _, option := range options {
 		if err := option(&optionSet); err != nil {
 			return nil, err
 		}
 	}
 	if optionSet.httpClient == nil {
-		optionSet.httpClient = http.DefaultClient
+		optionSet.httpClient = defaultHTTPClient(optionSet)
+		optionSet.ownsHTTPClient = optionSet.httpClient != http.DefaultClient
 	}
 
 	if optionSet.token != "" {
-		return &tokenClient{
-			token:      optionSet.token,
-			httpClient: optionSet.httpClient,
-			logWriter:  optionSet.logWriter,
+		c := &tokenClient{
+			transport: newTransport(optionSet),
+			token:     optionSet.token,
+		}
+		if optionSet.tokenFile != "" && optionSet.tokenFileReload > 0 {
+			c.file = newTokenFile(optionSet.tokenFile, optionSet.tokenFileReload)
+		}
+
+		return c, nil
+	}
+
+	if optionSet.clientID != "" {
+		return &oauthClient{
+			transport: newTransport(optionSet),
+			credentials: &clientCredentials{
+				clientID:     optionSet.clientID,
+				clientSecret: optionSet.clientSecret,
+				tokenURL:     optionSet.tokenURL,
+				httpClient:   optionSet.httpClient,
+			},
 		}, nil
 	}
 
-	return nil, fmt.Errorf("%w: token not set", ErrConfiguration)
+	return nil, fmt.Errorf("%w: neither token nor client credentials set", ErrConfiguration)
 }
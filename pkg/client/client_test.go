@@ -42,7 +42,7 @@ func TestClient_handleRequest(t *testing.T) {
 		buffer := make([]byte, 0)
 		writeBuffer := bytes.NewBuffer(buffer)
 
-		response, err := handleRequest(http.DefaultClient, req, writeBuffer)
+		response, err := handleRequest(http.DefaultClient, req, writerLogger{writeBuffer})
 		assert.NoError(t, err)
 		if assert.NotNil(t, response) {
 			body, err := ioutil.ReadAll(response.Body)
@@ -83,7 +83,7 @@ func TestClient_handleRequest(t *testing.T) {
 		buffer := make([]byte, 0)
 		writeBuffer := bytes.NewBuffer(buffer)
 
-		response, err := handleRequest(http.DefaultClient, req, writeBuffer)
+		response, err := handleRequest(http.DefaultClient, req, writerLogger{writeBuffer})
 		assert.Error(t, err)
 		if assert.NotNil(t, response) {
 			assert.EqualValues(t, response.StatusCode, http.StatusBadRequest)
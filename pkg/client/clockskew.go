@@ -0,0 +1,97 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+// ErrClockSkew indicates that the local clock differs from the one of the API by more than the configured threshold.
+var ErrClockSkew = errors.New("local clock is skewed")
+
+// ClockSkewError is returned instead of the original error if a request was rejected as unauthorized
+// while the local clock was skewed by more than the threshold configured via WithClockSkewDetection.
+type ClockSkewError struct {
+	// Skew is the measured difference between the local clock and the one of the API.
+	// A positive value means the local clock is ahead.
+	Skew time.Duration
+	// Err is the error the request failed with.
+	Err error
+}
+
+func (e *ClockSkewError) Error() string {
+	return fmt.Sprintf("%v by %v, which may have caused: %v", ErrClockSkew, e.Skew, e.Err)
+}
+
+// Unwrap returns the error the request failed with.
+func (e *ClockSkewError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is ErrClockSkew.
+func (e *ClockSkewError) Is(target error) bool {
+	return target == ErrClockSkew
+}
+
+// WithClockSkewDetection compares the local clock with the Date header of every API response.
+//
+// If the difference exceeds maxSkew, a warning is passed to the LogWriter and to a Logger implementing
+// WarningLogger, and requests rejected as unauthorized return a ClockSkewError. The last measured skew is available via ClockSkew.
+func WithClockSkewDetection(maxSkew time.Duration) Option {
+	return func(o *optionSet) error {
+		if maxSkew <= 0 {
+			return fmt.Errorf("%w: maximum clock skew must be positive", ErrConfiguration)
+		}
+		o.maxClockSkew = maxSkew
+
+		return nil
+	}
+}
+
+// ClockSkew returns the difference between the local clock and the one of the API, measured
+// on the last response. The result is false if the client does not detect clock skew
+// or no response with a Date header was received yet.
+func ClockSkew(c Client) (time.Duration, bool) {
+	detector, ok := c.(interface{ clockSkew() (time.Duration, bool) })
+	if !ok {
+		return 0, false
+	}
+
+	return detector.clockSkew()
+}
+
+func (t *transport) clockSkew() (time.Duration, bool) {
+	if t.maxClockSkew == 0 || atomic.LoadInt32(&t.clockSkewMeasured) == 0 {
+		return 0, false
+	}
+
+	return time.Duration(atomic.LoadInt64(&t.lastClockSkew)), true
+}
+
+// checkClockSkew measures the clock skew on the given response and returns the error the request should fail with.
+func (t *transport) checkClockSkew(response *http.Response, err error) error {
+	serverTime, parseErr := http.ParseTime(response.Header.Get("Date"))
+	if parseErr != nil {
+		return err
+	}
+
+	// The Date header has a resolution of one second, so skew below that can not be measured.
+	skew := time.Since(serverTime).Truncate(time.Second)
+	atomic.StoreInt64(&t.lastClockSkew, int64(skew))
+	atomic.StoreInt32(&t.clockSkewMeasured, 1)
+
+	if skew <= t.maxClockSkew && skew >= -t.maxClockSkew {
+		return err
+	}
+
+	logWarning(t.logger, fmt.Sprintf("local clock differs from API clock by %v", skew))
+
+	var responseError *ResponseError
+	if errors.As(err, &responseError) && response.StatusCode == http.StatusUnauthorized {
+		return &ClockSkewError{Skew: skew, Err: err}
+	}
+
+	return err
+}
@@ -0,0 +1,52 @@
+package client_test
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/anexia-it/go-anxcloud/pkg/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClockSkewDetection(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Date", time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat))
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"error":{"code":401,"message":"invalid token"}}`))
+	}))
+	defer server.Close()
+
+	logs := bytes.Buffer{}
+	logger := &recordingLogger{}
+	c, err := client.New(client.TokenFromString("token"), client.LogWriter(&logs), client.WithLogger(logger),
+		client.WithClockSkewDetection(time.Minute))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	_, measured := client.ClockSkew(c)
+	assert.False(t, measured)
+
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	assert.NoError(t, err)
+	_, err = c.Do(req)
+
+	var skewError *client.ClockSkewError
+	if assert.True(t, errors.As(err, &skewError), "expected ClockSkewError but got %v", err) {
+		assert.True(t, errors.Is(err, client.ErrClockSkew))
+		var responseError *client.ResponseError
+		assert.True(t, errors.As(err, &responseError))
+	}
+
+	skew, measured := client.ClockSkew(c)
+	assert.True(t, measured)
+	assert.InDelta(t, time.Hour.Seconds(), skew.Seconds(), 2)
+	assert.Contains(t, logs.String(), "warning: local clock differs")
+	if assert.Len(t, logger.warnings, 1) {
+		assert.Contains(t, logger.warnings[0], "local clock differs from API clock by")
+	}
+}
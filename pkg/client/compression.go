@@ -0,0 +1,68 @@
+package client
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// WithCompression lets the client request gzip compressed responses, cutting the transfer time of large listings.
+//
+// Compressed responses are decompressed transparently, their body reads as uncompressed data, also when
+// dumped to the LogWriter. An Accept-Encoding header set on a request by the caller is never overwritten.
+func WithCompression() Option {
+	return func(o *optionSet) error {
+		o.compression = true
+
+		return nil
+	}
+}
+
+// setAcceptEncoding requests a gzip compressed response if compression is enabled and the caller did not set
+// an Accept-Encoding.
+func (t *transport) setAcceptEncoding(req *http.Request) {
+	if t.compression && req.Header.Get("Accept-Encoding") == "" {
+		req.Header.Set("Accept-Encoding", "gzip")
+	}
+}
+
+// gzipBody reads a gzip compressed response body.
+type gzipBody struct {
+	*gzip.Reader
+	body io.ReadCloser
+}
+
+func (g gzipBody) Close() error {
+	_ = g.Reader.Close()
+	return g.body.Close()
+}
+
+// decompress replaces the body of a gzip encoded response by its decompressed content, like http.Transport
+// does for requests it added the Accept-Encoding header to itself.
+func decompress(response *http.Response) error {
+	if !strings.EqualFold(response.Header.Get("Content-Encoding"), "gzip") {
+		return nil
+	}
+
+	reader, err := gzip.NewReader(response.Body)
+	if errors.Is(err, io.EOF) {
+		// Responses without body, e.g. to HEAD requests, carry the header as well.
+		reader = nil
+	} else if err != nil {
+		_ = response.Body.Close()
+		return fmt.Errorf("could not decompress response: %w", err)
+	}
+
+	if reader != nil {
+		response.Body = gzipBody{reader, response.Body}
+	}
+	response.Header.Del("Content-Encoding")
+	response.Header.Del("Content-Length")
+	response.ContentLength = -1
+	response.Uncompressed = true
+
+	return nil
+}
@@ -0,0 +1,58 @@
+package client_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/anexia-it/go-anxcloud/pkg/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithCompression(t *testing.T) {
+	logs := bytes.Buffer{}
+	c, err := client.New(client.TokenFromString("token"), client.WithCompression(), client.LogWriter(&logs))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	_, server := client.NewTestClient(nil, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.EqualValues(t, "gzip", r.Header.Get("Accept-Encoding"))
+		w.Header().Set("Content-Encoding", "gzip")
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+		gz := gzip.NewWriter(w)
+		if r.URL.Path == "/missing" {
+			_, _ = gz.Write([]byte(`{"error":{"code":404,"message":"not found"}}`))
+		} else {
+			_, _ = gz.Write([]byte(`{"results":[]}`))
+		}
+		_ = gz.Close()
+	}))
+	defer server.Close()
+
+	req, err := http.NewRequest(http.MethodGet, server.URL+"/list", nil)
+	assert.NoError(t, err)
+	response, err := c.Do(req)
+	if assert.NoError(t, err) {
+		body, err := ioutil.ReadAll(response.Body)
+		assert.NoError(t, err)
+		assert.EqualValues(t, `{"results":[]}`, string(body))
+		assert.Empty(t, response.Header.Get("Content-Encoding"))
+		assert.NoError(t, response.Body.Close())
+	}
+
+	req, err = http.NewRequest(http.MethodGet, server.URL+"/missing", nil)
+	assert.NoError(t, err)
+	_, err = c.Do(req)
+	var responseErr *client.ResponseError
+	if assert.True(t, errors.As(err, &responseErr), "expected ResponseError but got %v", err) {
+		assert.EqualValues(t, "not found", responseErr.ErrorData.Message)
+	}
+
+	assert.Contains(t, logs.String(), `{"results":[]}`)
+}
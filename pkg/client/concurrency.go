@@ -0,0 +1,38 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+)
+
+// WithMaxConcurrency limits the number of requests the client has in flight at the same time to n.
+//
+// Further requests block until a slot is free or their context is done. Unlike retries or rate limits,
+// this bounds the number of connections used towards the API. A request holds its slot until its
+// response body is closed, or until it returned an error.
+func WithMaxConcurrency(n int) Option {
+	return func(o *optionSet) error {
+		if n < 1 {
+			return fmt.Errorf("%w: maximum concurrency has to be at least 1, got %d", ErrConfiguration, n)
+		}
+		o.maxConcurrency = n
+
+		return nil
+	}
+}
+
+// semaphore limits the number of concurrently held slots.
+type semaphore chan struct{}
+
+// acquire blocks until a slot is free or the context of the request is done.
+// The returned function releases the slot, calling it more than once has no effect.
+func (s semaphore) acquire(req *http.Request) (func(), error) {
+	select {
+	case s <- struct{}{}:
+		once := sync.Once{}
+		return func() { once.Do(func() { <-s }) }, nil
+	case <-req.Context().Done():
+		return nil, fmt.Errorf("waiting for a free request slot: %w", req.Context().Err())
+	}
+}
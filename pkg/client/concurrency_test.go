@@ -0,0 +1,66 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/anexia-it/go-anxcloud/pkg/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaxConcurrency(t *testing.T) {
+	c, err := client.New(client.TokenFromString("token"), client.WithMaxConcurrency(2))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	arrived := make(chan struct{}, 3)
+	unblock := make(chan struct{})
+	cw, server := client.NewTestClient(c, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		arrived <- struct{}{}
+		<-unblock
+		fmt.Fprint(w, "ok")
+	}))
+	defer server.Close()
+
+	do := func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, cw.BaseURL(), nil)
+		assert.NoError(t, err)
+		response, err := cw.Do(req)
+		if err != nil {
+			return err
+		}
+		_, _ = ioutil.ReadAll(response.Body)
+		return response.Body.Close()
+	}
+
+	results := make(chan error, 3)
+	for i := 0; i < 3; i++ {
+		go func() { results <- do(context.Background()) }()
+	}
+
+	<-arrived
+	<-arrived
+	select {
+	case <-arrived:
+		t.Fatal("third request was sent while two were in flight")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err = do(ctx)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded), "expected context.DeadlineExceeded but got %v", err)
+
+	unblock <- struct{}{}
+	<-arrived
+	close(unblock)
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, <-results)
+	}
+}
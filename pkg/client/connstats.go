@@ -0,0 +1,80 @@
+package client
+
+import (
+	"net/http"
+	"net/http/httptrace"
+	"sync/atomic"
+)
+
+// ConnStats counts the connections used for requests of a client.
+type ConnStats struct {
+	// New is the number of requests sent on a freshly dialed connection.
+	New int64
+	// Reused is the number of requests sent on a connection kept alive from a previous request.
+	Reused int64
+}
+
+// ReuseRate returns the share of requests sent on a reused connection, between 0 and 1.
+func (s ConnStats) ReuseRate() float64 {
+	total := s.New + s.Reused
+	if total == 0 {
+		return 0
+	}
+
+	return float64(s.Reused) / float64(total)
+}
+
+// WithConnectionStats counts whether requests are sent on new or reused connections.
+// The counts are available via ConnectionStats.
+//
+// This is opt-in as it attaches an httptrace.ClientTrace to every request.
+func WithConnectionStats() Option {
+	return func(o *optionSet) error {
+		o.connectionStats = true
+
+		return nil
+	}
+}
+
+// ConnectionStats returns the connection counts of the given client. The result is false
+// if the client does not count connections.
+func ConnectionStats(c Client) (ConnStats, bool) {
+	counter, ok := c.(interface{ connectionStats() (ConnStats, bool) })
+	if !ok {
+		return ConnStats{}, false
+	}
+
+	return counter.connectionStats()
+}
+
+// connCounter is allocated separately from the transport, so its counters are 64-bit aligned for atomic access.
+type connCounter struct {
+	new    int64
+	reused int64
+}
+
+func (t *transport) connectionStats() (ConnStats, bool) {
+	if t.connCounter == nil {
+		return ConnStats{}, false
+	}
+
+	return ConnStats{
+		New:    atomic.LoadInt64(&t.connCounter.new),
+		Reused: atomic.LoadInt64(&t.connCounter.reused),
+	}, true
+}
+
+// traceConnections returns the request with a trace counting the connection it gets.
+func (t *transport) traceConnections(req *http.Request) *http.Request {
+	trace := &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) {
+			if info.Reused {
+				atomic.AddInt64(&t.connCounter.reused, 1)
+			} else {
+				atomic.AddInt64(&t.connCounter.new, 1)
+			}
+		},
+	}
+
+	return req.WithContext(httptrace.WithClientTrace(req.Context(), trace))
+}
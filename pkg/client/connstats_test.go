@@ -0,0 +1,48 @@
+package client_test
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/anexia-it/go-anxcloud/pkg/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConnectionStats(t *testing.T) {
+	c, err := client.New(client.TokenFromString("token"), client.WithConnectionStats(),
+		client.HTTPClient(&http.Client{Transport: &http.Transport{}}))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	cw, server := client.NewTestClient(c, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "ok")
+	}))
+	defer server.Close()
+
+	for i := 0; i < 3; i++ {
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, cw.BaseURL(), nil)
+		assert.NoError(t, err)
+		response, err := cw.Do(req)
+		if assert.NoError(t, err) {
+			_, _ = ioutil.ReadAll(response.Body)
+			_ = response.Body.Close()
+		}
+	}
+
+	stats, ok := client.ConnectionStats(c)
+	assert.True(t, ok)
+	assert.EqualValues(t, client.ConnStats{New: 1, Reused: 2}, stats)
+	assert.InDelta(t, 2.0/3.0, stats.ReuseRate(), 0.001)
+}
+
+func TestConnectionStatsDisabled(t *testing.T) {
+	c, err := client.New(client.TokenFromString("token"))
+	if assert.NoError(t, err) {
+		_, ok := client.ConnectionStats(c)
+		assert.False(t, ok)
+	}
+}
@@ -0,0 +1,56 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// CorrelationIDHeader is the header carrying the correlation ID of a request.
+const CorrelationIDHeader = "X-Correlation-ID"
+
+type correlationIDKey struct{}
+
+// WithCorrelationID returns a context that makes all requests using it carry the given correlation ID.
+//
+// This ties together all requests of a logical operation spanning multiple calls in the API logs.
+// The ID is also included in the client log output.
+func WithCorrelationID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, correlationIDKey{}, id)
+}
+
+// CorrelationID returns the correlation ID set on the context by WithCorrelationID.
+func CorrelationID(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(correlationIDKey{}).(string)
+	return id, ok && id != ""
+}
+
+// GenerateCorrelationIDs lets the client generate a random correlation ID for every request
+// that has none set via WithCorrelationID or the CorrelationIDHeader.
+func GenerateCorrelationIDs() Option {
+	return func(o *optionSet) error {
+		o.generateCorrelationIDs = true
+
+		return nil
+	}
+}
+
+// setCorrelationID sets the correlation ID header from the request context, if the header is not set already.
+// It returns the correlation ID of the request, which is empty if it has none.
+func setCorrelationID(req *http.Request, generate bool) string {
+	if id := req.Header.Get(CorrelationIDHeader); id != "" {
+		return id
+	}
+
+	id, ok := CorrelationID(req.Context())
+	if !ok && !generate {
+		return ""
+	}
+	if !ok {
+		id = uuid.NewV4().String()
+	}
+	req.Header.Set(CorrelationIDHeader, id)
+
+	return id
+}
@@ -0,0 +1,92 @@
+package client_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/anexia-it/go-anxcloud/pkg/client"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// correlationIDsOf sends a request with the given context and header and returns the correlation IDs received
+// by the server for every attempt.
+func correlationIDsOf(t *testing.T, ctx context.Context, header string, failures int, options ...client.Option) []string {
+	var received []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = append(received, r.Header.Get(client.CorrelationIDHeader))
+		if len(received) <= failures {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(`{"error":{"code":503,"message":"unavailable"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`"ok"`))
+	}))
+	defer server.Close()
+
+	c, err := client.New(append([]client.Option{client.TokenFromString("token")}, options...)...)
+	if !assert.NoError(t, err) {
+		return nil
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	assert.NoError(t, err)
+	if header != "" {
+		req.Header.Set(client.CorrelationIDHeader, header)
+	}
+	response, err := c.Do(req)
+	if assert.NoError(t, err) {
+		_ = response.Body.Close()
+	}
+
+	return received
+}
+
+func TestCorrelationID(t *testing.T) {
+	ctx := client.WithCorrelationID(context.Background(), "deploy-42")
+	id, ok := client.CorrelationID(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, "deploy-42", id)
+	_, ok = client.CorrelationID(client.WithCorrelationID(context.Background(), ""))
+	assert.False(t, ok, "empty IDs are not set")
+
+	t.Run("FromContext", func(t *testing.T) {
+		logs := bytes.Buffer{}
+		assert.Equal(t, []string{"deploy-42"}, correlationIDsOf(t, ctx, "", 0, client.LogWriter(&logs)))
+		assert.Contains(t, logs.String(), "request [deploy-42]: ")
+		assert.Contains(t, logs.String(), "response [deploy-42]: ")
+	})
+
+	t.Run("None", func(t *testing.T) {
+		logs := bytes.Buffer{}
+		assert.Equal(t, []string{""}, correlationIDsOf(t, context.Background(), "", 0, client.LogWriter(&logs)))
+		assert.Contains(t, logs.String(), "request: ")
+	})
+
+	t.Run("HeaderTakesPrecedence", func(t *testing.T) {
+		assert.Equal(t, []string{"explicit"}, correlationIDsOf(t, ctx, "explicit", 0, client.GenerateCorrelationIDs()))
+	})
+
+	t.Run("Generated", func(t *testing.T) {
+		first := correlationIDsOf(t, context.Background(), "", 0, client.GenerateCorrelationIDs())
+		second := correlationIDsOf(t, context.Background(), "", 0, client.GenerateCorrelationIDs())
+		if assert.Len(t, first, 1) && assert.Len(t, second, 1) {
+			_, err := uuid.FromString(first[0])
+			assert.NoError(t, err)
+			assert.NotEqual(t, first[0], second[0], "every request gets its own ID")
+		}
+		assert.Equal(t, []string{"deploy-42"}, correlationIDsOf(t, ctx, "", 0, client.GenerateCorrelationIDs()))
+	})
+
+	t.Run("SharedByRetries", func(t *testing.T) {
+		ids := correlationIDsOf(t, context.Background(), "", 2, client.GenerateCorrelationIDs(), client.WithRetry(3, time.Millisecond))
+		if assert.Len(t, ids, 3) {
+			assert.NotEmpty(t, ids[0])
+			assert.Equal(t, ids[0], ids[1])
+			assert.Equal(t, ids[0], ids[2])
+		}
+	})
+}
@@ -0,0 +1,69 @@
+package client
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// WithCurlLogging writes an equivalent curl command line to w for every request sent.
+//
+// Like the requests written to the LogWriter, the Authorization header and other headers carrying credentials
+// are redacted, as are bodies of requests marked with WithRedactedBody.
+func WithCurlLogging(w io.Writer) Option {
+	return func(o *optionSet) error {
+		o.curlWriter = w
+
+		return nil
+	}
+}
+
+// curlCommand returns a curl command line sending the given request. The body of the request is read
+// and replaced by an in-memory copy.
+func curlCommand(req *http.Request, redactHeaders ...string) (string, error) {
+	redacted := map[string]struct{}{"Authorization": {}}
+	for _, name := range redactHeaders {
+		redacted[http.CanonicalHeaderKey(name)] = struct{}{}
+	}
+
+	names := make([]string, 0, len(req.Header))
+	for name := range req.Header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	parts := []string{"curl", "-X", req.Method, shellQuote(req.URL.String())}
+	for _, name := range names {
+		for _, value := range req.Header[name] {
+			if _, ok := redacted[http.CanonicalHeaderKey(name)]; ok {
+				value = "REDACTED"
+			}
+			parts = append(parts, "-H", shellQuote(fmt.Sprintf("%s: %s", name, value)))
+		}
+	}
+
+	if req.Body != nil && req.Body != http.NoBody {
+		body, err := ioutil.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return "", fmt.Errorf("could not read request body: %w", err)
+		}
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+		if _, redactBody := req.Context().Value(redactedBodyKey{}).(bool); redactBody {
+			body = []byte("REDACTED")
+		}
+		parts = append(parts, "--data-raw", shellQuote(string(body)))
+	}
+
+	return strings.Join(parts, " "), nil
+}
+
+// shellQuote quotes s for POSIX shells using single quotes.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,40 @@
+package client_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/anexia-it/go-anxcloud/pkg/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCurlLogging(t *testing.T) {
+	curlLog := bytes.Buffer{}
+	c, err := client.New(client.TokenFromString("secret-token"), client.WithCurlLogging(&curlLog))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	cw, server := client.NewTestClient(c, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.EqualValues(t, `{"name":"it's me"}`, body)
+		_, _ = w.Write([]byte(`"ok"`))
+	}))
+	defer server.Close()
+
+	req, err := http.NewRequest(http.MethodPost, cw.BaseURL()+"/api/test", strings.NewReader(`{"name":"it's me"}`))
+	assert.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "test")
+	response, err := cw.Do(req)
+	if assert.NoError(t, err) {
+		_ = response.Body.Close()
+	}
+
+	assert.EqualValues(t, "curl -X POST '"+cw.BaseURL()+"/api/test' -H 'Authorization: REDACTED' "+
+		`-H 'Content-Type: application/json' -H 'User-Agent: test' --data-raw '{"name":"it'\''s me"}'`+"\n", curlLog.String())
+}
@@ -0,0 +1,87 @@
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+type extraHeadersKey struct{}
+
+// WithExtraHeaders returns a context adding the given headers to all requests using it,
+// e.g. to opt into beta behavior of the API for single operations.
+//
+// Headers set on the request are replaced by the extra headers of the same name, except for the Authorization
+// header and others carrying credentials, which are never changed. Extra headers already set on ctx are kept,
+// unless replaced by the given ones.
+func WithExtraHeaders(ctx context.Context, headers http.Header) context.Context {
+	merged := http.Header{}
+	if existing, ok := ctx.Value(extraHeadersKey{}).(http.Header); ok {
+		for name, values := range existing {
+			merged[name] = values
+		}
+	}
+	for name, values := range headers {
+		merged[http.CanonicalHeaderKey(name)] = append([]string(nil), values...)
+	}
+
+	return context.WithValue(ctx, extraHeadersKey{}, merged)
+}
+
+// setExtraHeaders sets the extra headers of the request context on the request, skipping the protected ones.
+func setExtraHeaders(req *http.Request, protected ...string) {
+	headers, ok := req.Context().Value(extraHeadersKey{}).(http.Header)
+	if !ok {
+		return
+	}
+
+	skip := make(map[string]struct{}, len(protected)+1)
+	skip["Authorization"] = struct{}{}
+	for _, name := range protected {
+		skip[http.CanonicalHeaderKey(name)] = struct{}{}
+	}
+
+	for name, values := range headers {
+		if _, ok := skip[name]; ok {
+			continue
+		}
+		req.Header[name] = append([]string(nil), values...)
+	}
+}
+
+// WithHeaders lets the client add the given headers to every request, e.g. a tenant hint.
+//
+// Headers already set on a request are kept and headers of WithExtraHeaders take precedence, the Authorization
+// header and others carrying credentials are never changed. The headers are copied, changing them afterwards
+// has no effect on the client.
+func WithHeaders(headers http.Header) Option {
+	return func(o *optionSet) error {
+		if o.headers == nil {
+			o.headers = http.Header{}
+		}
+		for name, values := range headers {
+			o.headers[http.CanonicalHeaderKey(name)] = append([]string(nil), values...)
+		}
+
+		return nil
+	}
+}
+
+// setDefaultHeaders sets the headers of WithHeaders not set on the request yet, skipping the protected ones.
+func setDefaultHeaders(req *http.Request, headers http.Header, protected ...string) {
+	if len(headers) == 0 {
+		return
+	}
+
+	skip := make(map[string]struct{}, len(protected)+1)
+	skip["Authorization"] = struct{}{}
+	for _, name := range protected {
+		skip[http.CanonicalHeaderKey(name)] = struct{}{}
+	}
+
+	for name, values := range headers {
+		if _, ok := skip[name]; ok || len(req.Header.Values(name)) > 0 {
+			continue
+		}
+		req.Header[name] = append([]string(nil), values...)
+	}
+}
@@ -0,0 +1,70 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/anexia-it/go-anxcloud/pkg/client"
+	"github.com/anexia-it/go-anxcloud/pkg/test/echo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtraHeaders(t *testing.T) {
+	c, err := client.New(client.TokenFromString("token"), client.WithDuplicateAuthHeader("X-Anexia-Token"))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	var received http.Header
+	echoHandler := echo.TestMock(t)
+	cw, server := client.NewTestClient(c, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Clone()
+		echoHandler.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	t.Run("Applied", func(t *testing.T) {
+		ctx := client.WithExtraHeaders(context.Background(), http.Header{"X-Feature-Flag": {"beta"}})
+		ctx = client.WithExtraHeaders(ctx, http.Header{"x-another-flag": {"on"}})
+		assert.NoError(t, echo.NewAPI(cw).Echo(ctx))
+
+		assert.EqualValues(t, "beta", received.Get("X-Feature-Flag"))
+		assert.EqualValues(t, "on", received.Get("X-Another-Flag"))
+	})
+
+	t.Run("CanNotClobberAuth", func(t *testing.T) {
+		ctx := client.WithExtraHeaders(context.Background(), http.Header{
+			"authorization":  {"Token stolen"},
+			"X-Anexia-Token": {"stolen"},
+		})
+		assert.NoError(t, echo.NewAPI(cw).Echo(ctx))
+
+		assert.EqualValues(t, []string{"Token token"}, received.Values("Authorization"))
+		assert.EqualValues(t, []string{"token"}, received.Values("X-Anexia-Token"))
+	})
+}
+
+func TestWithHeaders(t *testing.T) {
+	headers := http.Header{"x-tenant": {"tenant"}, "X-Source": {"default"}, "Authorization": {"Token stolen"}}
+	c, err := client.New(client.TokenFromString("token"), client.WithHeaders(headers))
+	if !assert.NoError(t, err) {
+		return
+	}
+	headers.Set("X-Tenant", "changed")
+
+	var received http.Header
+	echoHandler := echo.TestMock(t)
+	cw, server := client.NewTestClient(c, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Clone()
+		echoHandler.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	ctx := client.WithExtraHeaders(context.Background(), http.Header{"X-Source": {"extra"}})
+	assert.NoError(t, echo.NewAPI(cw).Echo(ctx))
+
+	assert.EqualValues(t, []string{"tenant"}, received.Values("X-Tenant"))
+	assert.EqualValues(t, []string{"extra"}, received.Values("X-Source"))
+	assert.EqualValues(t, []string{"Token token"}, received.Values("Authorization"))
+}
@@ -0,0 +1,86 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+// ErrNoLocation is raised if a call needs a location but neither one was given nor a default one is configured.
+var ErrNoLocation = errors.New("no location given and no default location configured")
+
+// WithDefaultLocation sets the location used by calls that are passed an empty location.
+func WithDefaultLocation(id string) Option {
+	return func(o *optionSet) error {
+		o.defaultLocation = id
+
+		return nil
+	}
+}
+
+// DefaultLocationFromEnv sets the default location from VsphereLocationEnvName.
+func DefaultLocationFromEnv() Option {
+	return func(o *optionSet) error {
+		location, present := os.LookupEnv(VsphereLocationEnvName)
+		if !present {
+			return fmt.Errorf("%w: %s", ErrEnvMissing, VsphereLocationEnvName)
+		}
+		o.defaultLocation = location
+
+		return nil
+	}
+}
+
+// ResolveLocation returns the given location, or the default location of the client if it is empty.
+//
+// If neither is set, ErrNoLocation is returned.
+func ResolveLocation(c Client, location string) (string, error) {
+	if location != "" {
+		return location, nil
+	}
+
+	if locator, ok := c.(interface{ defaultLocationID() string }); ok {
+		if location = locator.defaultLocationID(); location != "" {
+			return location, nil
+		}
+	}
+
+	return "", ErrNoLocation
+}
+
+func (t *transport) defaultLocationID() string {
+	return t.defaultLocation
+}
+
+// BindLocation returns a client using the given location instead of the default location of c.
+// Calls passed an empty location use the bound one.
+func BindLocation(c Client, id string) Client {
+	return locationClient{c, id}
+}
+
+type locationClient struct {
+	Client
+	location string
+}
+
+func (l locationClient) defaultLocationID() string {
+	return l.location
+}
+
+func (l locationClient) clockSkew() (time.Duration, bool) {
+	return ClockSkew(l.Client)
+}
+
+func (l locationClient) connectionStats() (ConnStats, bool) {
+	return ConnectionStats(l.Client)
+}
+
+func (l locationClient) shutdown(ctx context.Context) error {
+	return Shutdown(ctx, l.Client)
+}
+
+func (l locationClient) setToken(token string) error {
+	return SetToken(l.Client, token)
+}
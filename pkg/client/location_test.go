@@ -0,0 +1,88 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/anexia-it/go-anxcloud/pkg/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveLocation(t *testing.T) {
+	plain, err := client.New(client.TokenFromString("token"))
+	if !assert.NoError(t, err) {
+		return
+	}
+	withDefault, err := client.New(client.TokenFromString("token"), client.WithDefaultLocation("default-location"))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	for name, tc := range map[string]struct {
+		client   client.Client
+		given    string
+		expected string
+	}{
+		"Given":          {plain, "given-location", "given-location"},
+		"GivenOverrides": {withDefault, "given-location", "given-location"},
+		"Default":        {withDefault, "", "default-location"},
+		"Bound":          {client.BindLocation(withDefault, "bound-location"), "", "bound-location"},
+		"BoundToPlain":   {client.BindLocation(plain, "bound-location"), "", "bound-location"},
+		"BoundOverrides": {client.BindLocation(plain, "bound-location"), "given-location", "given-location"},
+	} {
+		location, err := client.ResolveLocation(tc.client, tc.given)
+		if assert.NoError(t, err, name) {
+			assert.Equal(t, tc.expected, location, name)
+		}
+	}
+
+	for name, c := range map[string]client.Client{
+		"Plain":      plain,
+		"BoundEmpty": client.BindLocation(plain, ""),
+	} {
+		_, err := client.ResolveLocation(c, "")
+		assert.True(t, errors.Is(err, client.ErrNoLocation), "%s: expected ErrNoLocation but got %v", name, err)
+	}
+}
+
+func TestDefaultLocationFromEnv(t *testing.T) {
+	old, present := os.LookupEnv(client.VsphereLocationEnvName)
+	t.Cleanup(func() {
+		if present {
+			_ = os.Setenv(client.VsphereLocationEnvName, old)
+		} else {
+			_ = os.Unsetenv(client.VsphereLocationEnvName)
+		}
+	})
+
+	assert.NoError(t, os.Unsetenv(client.VsphereLocationEnvName))
+	_, err := client.New(client.TokenFromString("token"), client.DefaultLocationFromEnv())
+	assert.True(t, errors.Is(err, client.ErrEnvMissing), "expected ErrEnvMissing but got %v", err)
+
+	assert.NoError(t, os.Setenv(client.VsphereLocationEnvName, "env-location"))
+	c, err := client.New(client.TokenFromString("token"), client.DefaultLocationFromEnv())
+	if assert.NoError(t, err) {
+		location, err := client.ResolveLocation(c, "")
+		assert.NoError(t, err)
+		assert.Equal(t, "env-location", location)
+	}
+}
+
+func TestBindLocationKeepsClientFeatures(t *testing.T) {
+	c, err := client.New(client.TokenFromString("token"), client.WithConnectionStats(), client.WithDefaultLocation("default-location"))
+	if !assert.NoError(t, err) {
+		return
+	}
+	bound := client.BindLocation(c, "bound-location")
+
+	_, ok := client.ConnectionStats(bound)
+	assert.True(t, ok, "connection stats of the bound client must be available")
+	assert.NoError(t, client.SetToken(bound, "rotated"))
+	assert.NoError(t, client.Shutdown(context.Background(), bound))
+
+	location, err := client.ResolveLocation(c, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "default-location", location, "binding must not change the bound client")
+}
@@ -0,0 +1,136 @@
+package client
+
+import (
+	"fmt"
+	"io"
+	"time"
+)
+
+// Logger receives a log entry for every request sent by the client and its response.
+//
+// Implementations may be called concurrently and should not block, e.g. to bridge into structured loggers.
+type Logger interface {
+	LogRequest(entry RequestLogEntry)
+	LogResponse(entry ResponseLogEntry)
+}
+
+// WarningLogger can be implemented by a Logger to also receive warnings of the client, e.g. about clock skew.
+type WarningLogger interface {
+	LogWarning(message string)
+}
+
+// RequestLogEntry describes a request about to be sent.
+type RequestLogEntry struct {
+	Method string
+	URL    string
+	// CorrelationID of the request, empty if it has none.
+	CorrelationID string
+	// Dump is the request as sent on the wire. The Authorization header and other headers carrying
+	// credentials are redacted, as is the body of requests marked with WithRedactedBody.
+	// It is nil if the request could not be dumped.
+	Dump []byte
+}
+
+// ResponseLogEntry describes the outcome of a request.
+type ResponseLogEntry struct {
+	Method        string
+	URL           string
+	CorrelationID string
+	// StatusCode of the response, 0 if no response was received.
+	StatusCode int
+	// Duration from sending the request until the response headers were received.
+	Duration time.Duration
+	// Err is the error the request failed with, if any.
+	Err error
+	// Dump is the response as received. The body is omitted for successful responses and for
+	// requests marked with WithRedactedBody. It is nil if no response was received.
+	Dump []byte
+}
+
+// WithLogger lets the client pass a log entry for every request and response to l.
+//
+// It can be combined with LogWriter, in which case both receive the requests.
+func WithLogger(l Logger) Option {
+	return func(o *optionSet) error {
+		o.logger = l
+
+		return nil
+	}
+}
+
+// writerLogger writes the dumps of requests and responses to an io.Writer, as LogWriter always did.
+type writerLogger struct {
+	w io.Writer
+}
+
+func (l writerLogger) LogRequest(entry RequestLogEntry) {
+	if entry.Dump != nil {
+		fmt.Fprintf(l.w, "request%s: %s\n", correlationLogPrefix(entry.CorrelationID), string(entry.Dump))
+	}
+}
+
+func (l writerLogger) LogResponse(entry ResponseLogEntry) {
+	if entry.Dump != nil {
+		fmt.Fprintf(l.w, "response%s: %s\n", correlationLogPrefix(entry.CorrelationID), string(entry.Dump))
+	}
+}
+
+func (l writerLogger) LogWarning(message string) {
+	fmt.Fprintf(l.w, "warning: %s\n", message)
+}
+
+func correlationLogPrefix(correlationID string) string {
+	if correlationID == "" {
+		return ""
+	}
+
+	return fmt.Sprintf(" [%s]", correlationID)
+}
+
+// multiLogger passes all entries to each of its loggers.
+type multiLogger []Logger
+
+func (m multiLogger) LogRequest(entry RequestLogEntry) {
+	for _, l := range m {
+		l.LogRequest(entry)
+	}
+}
+
+func (m multiLogger) LogResponse(entry ResponseLogEntry) {
+	for _, l := range m {
+		l.LogResponse(entry)
+	}
+}
+
+func (m multiLogger) LogWarning(message string) {
+	for _, l := range m {
+		logWarning(l, message)
+	}
+}
+
+// logWarning passes message to l if it is a WarningLogger.
+func logWarning(l Logger, message string) {
+	if warner, ok := l.(WarningLogger); ok {
+		warner.LogWarning(message)
+	}
+}
+
+// newLogger combines the Logger and the LogWriter configured, returning nil if neither is set.
+func newLogger(o optionSet) Logger {
+	var loggers multiLogger
+	if o.logWriter != nil {
+		loggers = append(loggers, writerLogger{o.logWriter})
+	}
+	if o.logger != nil {
+		loggers = append(loggers, o.logger)
+	}
+
+	switch len(loggers) {
+	case 0:
+		return nil
+	case 1:
+		return loggers[0]
+	default:
+		return loggers
+	}
+}
@@ -0,0 +1,78 @@
+package client_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/anexia-it/go-anxcloud/pkg/client"
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingLogger struct {
+	mu        sync.Mutex
+	requests  []client.RequestLogEntry
+	responses []client.ResponseLogEntry
+	warnings  []string
+}
+
+func (l *recordingLogger) LogRequest(entry client.RequestLogEntry) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.requests = append(l.requests, entry)
+}
+
+func (l *recordingLogger) LogResponse(entry client.ResponseLogEntry) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.responses = append(l.responses, entry)
+}
+
+func (l *recordingLogger) LogWarning(message string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.warnings = append(l.warnings, message)
+}
+
+func TestWithLogger(t *testing.T) {
+	logger := &recordingLogger{}
+	logs := bytes.Buffer{}
+	c, err := client.New(client.TokenFromString("secret-token"), client.WithLogger(logger), client.LogWriter(&logs))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	_, server := client.NewTestClient(nil, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error":{"code":404,"message":"not found"}}`))
+	}))
+	defer server.Close()
+
+	ctx := client.WithCorrelationID(context.Background(), "correlation")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/api/thing", nil)
+	assert.NoError(t, err)
+	_, err = c.Do(req)
+	assert.Error(t, err)
+
+	if assert.Len(t, logger.requests, 1) {
+		entry := logger.requests[0]
+		assert.EqualValues(t, http.MethodGet, entry.Method)
+		assert.EqualValues(t, server.URL+"/api/thing", entry.URL)
+		assert.EqualValues(t, "correlation", entry.CorrelationID)
+		assert.Contains(t, string(entry.Dump), "Authorization: REDACTED")
+		assert.NotContains(t, string(entry.Dump), "secret-token")
+	}
+	if assert.Len(t, logger.responses, 1) {
+		entry := logger.responses[0]
+		assert.EqualValues(t, http.StatusNotFound, entry.StatusCode)
+		assert.Greater(t, int64(entry.Duration), int64(0))
+		assert.Equal(t, err, entry.Err)
+		assert.NotNil(t, entry.Dump)
+	}
+
+	assert.True(t, strings.HasPrefix(logs.String(), "request [correlation]: GET /api/thing"), "unexpected log output %q", logs.String())
+	assert.Contains(t, logs.String(), "response [correlation]: HTTP/1.1 404 Not Found")
+}
@@ -0,0 +1,34 @@
+package client
+
+import (
+	"net/http"
+)
+
+// MethodOverrideHeader is the header carrying the actual method of requests tunneled via WithMethodOverride.
+const MethodOverrideHeader = "X-HTTP-Method-Override"
+
+// WithMethodOverride sends requests with methods other than GET and POST as POST, carrying the actual
+// method in the MethodOverrideHeader. This is meant for proxies only allowing GET and POST.
+//
+// This changes how requests are sent on the wire, so only enable it if the path to the API requires it.
+func WithMethodOverride() Option {
+	return func(o *optionSet) error {
+		o.methodOverride = true
+
+		return nil
+	}
+}
+
+// overrideMethod returns a copy of the request sent as POST with the actual method in the MethodOverrideHeader,
+// or the request itself if it is a GET or POST request.
+func overrideMethod(req *http.Request) *http.Request {
+	if req.Method == http.MethodGet || req.Method == http.MethodPost {
+		return req
+	}
+
+	overridden := req.Clone(req.Context())
+	overridden.Header.Set(MethodOverrideHeader, req.Method)
+	overridden.Method = http.MethodPost
+
+	return overridden
+}
@@ -0,0 +1,50 @@
+package client_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/anexia-it/go-anxcloud/pkg/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMethodOverride(t *testing.T) {
+	var method, override string
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method, override = r.Method, r.Header.Get(client.MethodOverrideHeader)
+	})
+
+	send := func(c client.Client, requestMethod string) {
+		cw, server := client.NewTestClient(c, handler)
+		defer server.Close()
+
+		req, err := http.NewRequest(requestMethod, cw.BaseURL(), nil)
+		assert.NoError(t, err)
+		response, err := cw.Do(req)
+		if assert.NoError(t, err) {
+			_ = response.Body.Close()
+		}
+	}
+
+	t.Run("Enabled", func(t *testing.T) {
+		c, err := client.New(client.TokenFromString("token"), client.WithMethodOverride())
+		assert.NoError(t, err)
+
+		send(c, http.MethodDelete)
+		assert.EqualValues(t, http.MethodPost, method)
+		assert.EqualValues(t, http.MethodDelete, override)
+
+		send(c, http.MethodGet)
+		assert.EqualValues(t, http.MethodGet, method)
+		assert.Empty(t, override)
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		c, err := client.New(client.TokenFromString("token"))
+		assert.NoError(t, err)
+
+		send(c, http.MethodDelete)
+		assert.EqualValues(t, http.MethodDelete, method)
+		assert.Empty(t, override)
+	})
+}
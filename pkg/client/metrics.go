@@ -0,0 +1,67 @@
+package client
+
+import (
+	"net/http"
+	"time"
+)
+
+// RequestMetric describes a completed call of Client.Do.
+type RequestMetric struct {
+	Method string
+	// Path of the request URL, without query. It may contain identifiers, so it should be mapped to
+	// an endpoint template before being used as metric label.
+	Path string
+	// StatusCode of the final response, 0 if no response was received.
+	StatusCode int
+	// Duration from calling Do until it returned, including retries and waiting for a free request slot.
+	Duration time.Duration
+	// Retries is the number of retries made after the first attempt.
+	Retries int
+	// Err is the error returned by Do, if any.
+	Err error
+	// Trace links the request to a trace, see RequestTraceContext. It is only valid if the request was traced,
+	// and can be attached to metrics as exemplar using TraceContext.ExemplarLabels.
+	Trace TraceContext
+}
+
+// WithMetrics calls f for every completed call of Do, successful or not.
+//
+// This allows to record latencies and status codes per endpoint without the client depending on a
+// metrics library. f is called synchronously and possibly concurrently, so it should be fast and safe for
+// concurrent use.
+func WithMetrics(f func(RequestMetric)) Option {
+	return func(o *optionSet) error {
+		o.metrics = f
+
+		return nil
+	}
+}
+
+// pendingMetric collects the metric of a request from the start of Do.
+type pendingMetric struct {
+	metric RequestMetric
+	start  time.Time
+}
+
+// newRequestMetric starts measuring a request, recording its details before it is modified for sending.
+func newRequestMetric(req *http.Request) pendingMetric {
+	trace, _ := RequestTraceContext(req)
+
+	return pendingMetric{
+		metric: RequestMetric{Method: req.Method, Path: req.URL.Path, Trace: trace},
+		start:  time.Now(),
+	}
+}
+
+// complete returns the metric of the request finished with the given response and error.
+func (p pendingMetric) complete(response *http.Response, retries int, err error) RequestMetric {
+	metric := p.metric
+	metric.Duration = time.Since(p.start)
+	metric.Retries = retries
+	metric.Err = err
+	if response != nil {
+		metric.StatusCode = response.StatusCode
+	}
+
+	return metric
+}
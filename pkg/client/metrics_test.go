@@ -0,0 +1,52 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/anexia-it/go-anxcloud/pkg/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithMetrics(t *testing.T) {
+	var metrics []client.RequestMetric
+	c, err := client.New(client.TokenFromString("token"), client.WithRetry(3, time.Millisecond),
+		client.WithMetrics(func(metric client.RequestMetric) { metrics = append(metrics, metric) }))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	server, _ := newFlakyServer(2, http.StatusServiceUnavailable, `{"error":{"code":503}}`)
+	defer server.Close()
+
+	trace := client.TraceContext{TraceID: "4bf92f3577b34da6a3ce929d0e0e4736", SpanID: "00f067aa0ba902b7"}
+	req, err := http.NewRequestWithContext(client.WithTraceContext(context.Background(), trace), http.MethodGet,
+		server.URL+"/api/thing?page=1", nil)
+	assert.NoError(t, err)
+	response, err := c.Do(req)
+	if assert.NoError(t, err) {
+		_ = response.Body.Close()
+	}
+
+	req, err = http.NewRequest(http.MethodGet, "http://127.0.0.1:0/unreachable", nil)
+	assert.NoError(t, err)
+	_, doErr := c.Do(req)
+	assert.Error(t, doErr)
+
+	if assert.Len(t, metrics, 2) {
+		assert.EqualValues(t, http.MethodGet, metrics[0].Method)
+		assert.EqualValues(t, "/api/thing", metrics[0].Path)
+		assert.EqualValues(t, http.StatusOK, metrics[0].StatusCode)
+		assert.EqualValues(t, 2, metrics[0].Retries)
+		assert.NoError(t, metrics[0].Err)
+		assert.Greater(t, int64(metrics[0].Duration), int64(0))
+		assert.Equal(t, trace, metrics[0].Trace)
+
+		assert.EqualValues(t, "/unreachable", metrics[1].Path)
+		assert.EqualValues(t, 0, metrics[1].StatusCode)
+		assert.Equal(t, doErr, metrics[1].Err)
+		assert.False(t, metrics[1].Trace.Valid())
+	}
+}
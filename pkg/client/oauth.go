@@ -0,0 +1,105 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// tokenExpiryDelta is the time before its expiry at which an OAuth2 access token is refreshed.
+const tokenExpiryDelta = 10 * time.Second
+
+// ErrTokenRequest is raised if an OAuth2 access token could not be obtained.
+var ErrTokenRequest = errors.New("could not obtain access token")
+
+// clientCredentials obtains and caches an OAuth2 access token using the client credentials grant.
+type clientCredentials struct {
+	clientID     string
+	clientSecret string
+	tokenURL     string
+	httpClient   *http.Client
+
+	mu          sync.Mutex
+	accessToken string
+	expiry      time.Time
+}
+
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int    `json:"expires_in"`
+}
+
+// token returns a cached access token or fetches a new one if the cached token is about to expire.
+// Concurrent callers wait for a single refresh instead of each issuing a token request.
+func (c *clientCredentials) token(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.accessToken != "" && (c.expiry.IsZero() || time.Now().Add(tokenExpiryDelta).Before(c.expiry)) {
+		return c.accessToken, nil
+	}
+
+	data := url.Values{}
+	data.Set("grant_type", "client_credentials")
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.tokenURL, strings.NewReader(data.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("%w: could not create token request: %v", ErrTokenRequest, err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(url.QueryEscape(c.clientID), url.QueryEscape(c.clientSecret))
+
+	// The token request is not passed to handleRequest, so the credentials never end up in a request dump.
+	response, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrTokenRequest, err)
+	}
+	defer func() { _ = response.Body.Close() }()
+
+	if response.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(io.LimitReader(response.Body, 1024))
+		return "", fmt.Errorf("%w: token endpoint returned %s: %s", ErrTokenRequest, response.Status, body)
+	}
+
+	var payload tokenResponse
+	if err := json.NewDecoder(response.Body).Decode(&payload); err != nil {
+		return "", fmt.Errorf("%w: could not decode token response: %v", ErrTokenRequest, err)
+	}
+	if payload.AccessToken == "" {
+		return "", fmt.Errorf("%w: token response contains no access token", ErrTokenRequest)
+	}
+
+	c.accessToken = payload.AccessToken
+	c.expiry = time.Time{}
+	if payload.ExpiresIn > 0 {
+		c.expiry = time.Now().Add(time.Duration(payload.ExpiresIn) * time.Second)
+	}
+
+	return c.accessToken, nil
+}
+
+type oauthClient struct {
+	*transport
+	credentials *clientCredentials
+}
+
+func (o oauthClient) Do(req *http.Request) (*http.Response, error) {
+	ctx, cancel := o.withTimeout(req.Context())
+	token, err := o.credentials.token(ctx)
+	cancel()
+	if err != nil {
+		return nil, err
+	}
+	o.setUserAgent(req)
+	o.authorize(req, "Bearer", token)
+
+	return o.do(req)
+}
@@ -0,0 +1,159 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/anexia-it/go-anxcloud/pkg/client"
+	"github.com/anexia-it/go-anxcloud/pkg/test/echo"
+	"github.com/stretchr/testify/assert"
+)
+
+// setEnv sets the given environment variables, unsets all other auth variables and restores them after the test.
+func setEnv(t *testing.T, values map[string]string) {
+	for _, name := range []string{client.TokenEnvName, client.ClientIDEnvName, client.ClientSecretEnvName, client.TokenURLEnvName} {
+		name := name
+		old, present := os.LookupEnv(name)
+		t.Cleanup(func() {
+			if present {
+				_ = os.Setenv(name, old)
+			} else {
+				_ = os.Unsetenv(name)
+			}
+		})
+
+		if value, ok := values[name]; ok {
+			assert.NoError(t, os.Setenv(name, value))
+		} else {
+			assert.NoError(t, os.Unsetenv(name))
+		}
+	}
+}
+
+func newTokenServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		clientID, clientSecret, ok := r.BasicAuth()
+		assert.True(t, ok)
+		assert.EqualValues(t, "client-id", clientID)
+		assert.EqualValues(t, "client-secret", clientSecret)
+		assert.EqualValues(t, "client_credentials", r.FormValue("grant_type"))
+		fmt.Fprint(w, `{"access_token":"access-token","token_type":"Bearer","expires_in":3600}`)
+	}))
+}
+
+func authorizationOf(t *testing.T, c client.Client) string {
+	var authorization string
+	echoHandler := echo.TestMock(t)
+	cw, server := client.NewTestClient(c, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		echoHandler.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), client.DefaultRequestTimeout)
+	defer cancel()
+	assert.NoError(t, echo.NewAPI(cw).Echo(ctx))
+
+	return authorization
+}
+
+func TestAuthAuto(t *testing.T) {
+	tokenServer := newTokenServer(t)
+	defer tokenServer.Close()
+
+	t.Run("PrefersToken", func(t *testing.T) {
+		setEnv(t, map[string]string{
+			client.TokenEnvName:        "static-token",
+			client.ClientIDEnvName:     "client-id",
+			client.ClientSecretEnvName: "client-secret",
+			client.TokenURLEnvName:     tokenServer.URL,
+		})
+
+		c, err := client.New(client.AuthAuto())
+		if assert.NoError(t, err) {
+			assert.EqualValues(t, "Token static-token", authorizationOf(t, c))
+		}
+	})
+
+	t.Run("FallsBackToClientCredentials", func(t *testing.T) {
+		setEnv(t, map[string]string{
+			client.ClientIDEnvName:     "client-id",
+			client.ClientSecretEnvName: "client-secret",
+			client.TokenURLEnvName:     tokenServer.URL,
+		})
+
+		c, err := client.New(client.AuthAuto())
+		if assert.NoError(t, err) {
+			assert.EqualValues(t, "Bearer access-token", authorizationOf(t, c))
+		}
+	})
+
+	t.Run("NothingConfigured", func(t *testing.T) {
+		setEnv(t, map[string]string{client.ClientIDEnvName: "client-id"})
+
+		_, err := client.New(client.AuthAuto())
+		assert.True(t, errors.Is(err, client.ErrConfiguration), "expected ErrConfiguration but got %v", err)
+		assert.Contains(t, err.Error(), client.TokenEnvName)
+		assert.Contains(t, err.Error(), client.ClientSecretEnvName)
+	})
+}
+
+func TestClientCredentials(t *testing.T) {
+	var mu sync.Mutex
+	tokenRequests := 0
+	expiresIn := 3600
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		tokenRequests++
+		fmt.Fprintf(w, `{"access_token":"access-token-%d","token_type":"Bearer","expires_in":%d}`, tokenRequests, expiresIn)
+	}))
+	defer tokenServer.Close()
+
+	t.Run("SharedToken", func(t *testing.T) {
+		c, err := client.New(client.ClientCredentials("client-id", "client-secret", tokenServer.URL))
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				assert.EqualValues(t, "Bearer access-token-1", authorizationOf(t, c))
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, 1, tokenRequests)
+	})
+
+	t.Run("RefreshedBeforeExpiry", func(t *testing.T) {
+		mu.Lock()
+		tokenRequests, expiresIn = 0, 5
+		mu.Unlock()
+
+		c, err := client.New(client.ClientCredentials("client-id", "client-secret", tokenServer.URL))
+		if assert.NoError(t, err) {
+			assert.EqualValues(t, "Bearer access-token-1", authorizationOf(t, c))
+			assert.EqualValues(t, "Bearer access-token-2", authorizationOf(t, c))
+		}
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		for _, option := range []client.Option{
+			client.ClientCredentials("", "client-secret", tokenServer.URL),
+			client.ClientCredentials("client-id", "", tokenServer.URL),
+			client.ClientCredentials("client-id", "client-secret", "/token"),
+		} {
+			_, err := client.New(option)
+			assert.True(t, errors.Is(err, client.ErrConfiguration), "expected ErrConfiguration but got %v", err)
+		}
+	})
+}
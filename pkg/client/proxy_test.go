@@ -0,0 +1,51 @@
+package client_test
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/anexia-it/go-anxcloud/pkg/client"
+	"github.com/anexia-it/go-anxcloud/pkg/test/echo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithProxy(t *testing.T) {
+	var mu sync.Mutex
+	var hosts, authorizations []string
+	echoHandler := echo.TestMock(t)
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		hosts = append(hosts, r.Host)
+		authorizations = append(authorizations, r.Header.Get("Proxy-Authorization"))
+		mu.Unlock()
+		echoHandler.ServeHTTP(w, r)
+	}))
+	defer proxy.Close()
+	proxyURL := strings.Replace(proxy.URL, "http://", "http://user:secret@", 1)
+
+	c, err := client.New(client.TokenFromString("token"), client.BaseURL("http://engine.invalid"), client.WithProxy(proxyURL))
+	if assert.NoError(t, err) {
+		assert.NoError(t, echo.NewAPI(c).Echo(context.Background()))
+		assert.Equal(t, []string{"engine.invalid"}, hosts)
+		assert.Equal(t, []string{"Basic " + base64.StdEncoding.EncodeToString([]byte("user:secret"))}, authorizations)
+	}
+
+	// An explicit http.Client wins and is used without the proxy.
+	c, err = client.New(client.TokenFromString("token"), client.BaseURL("http://engine.invalid"), client.WithProxy(proxyURL),
+		client.HTTPClient(&http.Client{}))
+	if assert.NoError(t, err) {
+		assert.Error(t, echo.NewAPI(c).Echo(context.Background()))
+		assert.Len(t, hosts, 1)
+	}
+
+	for _, invalid := range []string{"", "proxy.example.com:3128", "ftp://proxy.example.com", "http://", "http://[::1"} {
+		_, err = client.New(client.TokenFromString("token"), client.WithProxy(invalid))
+		assert.True(t, errors.Is(err, client.ErrConfiguration), "expected ErrConfiguration for %q but got %v", invalid, err)
+	}
+}
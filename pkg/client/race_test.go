@@ -0,0 +1,73 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/anexia-it/go-anxcloud/pkg/client"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestConcurrentUse issues requests from many goroutines while rotating the token and reading
+// the client state. Run with -race to detect unsynchronized access.
+func TestConcurrentUse(t *testing.T) {
+	c, err := client.New(client.TokenFromString("token-0"), client.WithConnectionStats(),
+		client.WithClockSkewDetection(time.Hour), client.GenerateCorrelationIDs(),
+		client.HTTPClient(&http.Client{Transport: &http.Transport{}}))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	cw, server := client.NewTestClient(c, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.True(t, strings.HasPrefix(r.Header.Get("Authorization"), "Token token-"))
+		w.Header().Set("Date", time.Now().UTC().Format(http.TimeFormat))
+		fmt.Fprint(w, "ok")
+	}))
+	defer server.Close()
+
+	const workers, requests = 16, 20
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < requests; j++ {
+				req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, cw.BaseURL(), nil)
+				assert.NoError(t, err)
+				response, err := cw.Do(req)
+				if assert.NoError(t, err) {
+					_, _ = ioutil.ReadAll(response.Body)
+					_ = response.Body.Close()
+				}
+			}
+		}()
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 1; i <= requests; i++ {
+			assert.NoError(t, client.SetToken(c, fmt.Sprintf("token-%d", i)))
+			_, _ = client.ConnectionStats(c)
+			_, _ = client.ClockSkew(c)
+		}
+	}()
+	wg.Wait()
+
+	stats, ok := client.ConnectionStats(c)
+	assert.True(t, ok)
+	assert.EqualValues(t, workers*requests, stats.New+stats.Reused)
+}
+
+func TestSetTokenUnsupported(t *testing.T) {
+	c, _ := client.NewTestClient(nil, http.NotFoundHandler())
+	err := client.SetToken(c, "token")
+	assert.True(t, errors.Is(err, client.ErrTokenRotationUnsupported), "expected ErrTokenRotationUnsupported but got %v", err)
+}
@@ -0,0 +1,39 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrRateLimited is raised if the API rejected a request with 429 Too Many Requests.
+var ErrRateLimited = errors.New("rate limited by api")
+
+// RateLimitError is returned if the API rejected a request with 429 Too Many Requests.
+//
+// It wraps the error of the response, so the ResponseError and RetryAfterError remain accessible via errors.As.
+type RateLimitError struct {
+	// RetryAfter is the time to wait before retrying the request, as suggested by the Retry-After header.
+	// It is 0 if the API did not send the header.
+	RetryAfter time.Duration
+	// Err is the error the request failed with.
+	Err error
+}
+
+func (e *RateLimitError) Error() string {
+	if e.RetryAfter > 0 {
+		return fmt.Sprintf("%v, retry after %v: %v", ErrRateLimited, e.RetryAfter, e.Err)
+	}
+
+	return fmt.Sprintf("%v: %v", ErrRateLimited, e.Err)
+}
+
+// Is reports whether target is ErrRateLimited.
+func (e *RateLimitError) Is(target error) bool {
+	return target == ErrRateLimited
+}
+
+// Unwrap returns the error the request failed with.
+func (e *RateLimitError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,49 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/anexia-it/go-anxcloud/pkg/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimitError(t *testing.T) {
+	c, server := client.NewTestClient(nil, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("retry") != "" {
+			w.Header().Set("Retry-After", "7")
+		}
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte(`{"error":{"code":429,"message":"slow down"}}`))
+	}))
+	defer server.Close()
+
+	do := func(query string) error {
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, c.BaseURL()+query, nil)
+		assert.NoError(t, err)
+		response, err := c.Do(req)
+		if response != nil {
+			_ = response.Body.Close()
+		}
+		return err
+	}
+
+	err := do("?retry=1")
+	assert.True(t, errors.Is(err, client.ErrRateLimited), "expected ErrRateLimited but got %v", err)
+	var rateLimitErr *client.RateLimitError
+	if assert.True(t, errors.As(err, &rateLimitErr)) {
+		assert.EqualValues(t, 7*time.Second, rateLimitErr.RetryAfter)
+	}
+	var responseErr *client.ResponseError
+	if assert.True(t, errors.As(err, &responseErr)) {
+		assert.EqualValues(t, "slow down", responseErr.ErrorData.Message)
+	}
+
+	err = do("")
+	if assert.True(t, errors.As(err, &rateLimitErr), "expected RateLimitError but got %v", err) {
+		assert.Zero(t, rateLimitErr.RetryAfter)
+	}
+}
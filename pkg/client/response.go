@@ -0,0 +1,30 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+)
+
+// CheckResponse returns an error wrapping a *ResponseError if the status of response is not 2xx.
+//
+// Clients created with New already fail such requests. API packages call this for other Client implementations,
+// so error responses are never decoded as payload. The body of such responses is closed.
+// request describes the failed call for the error message, like "get load balancer backends".
+func CheckResponse(response *http.Response, request string) error {
+	if response.StatusCode >= http.StatusOK && response.StatusCode < http.StatusMultipleChoices {
+		return nil
+	}
+
+	body, _ := ioutil.ReadAll(response.Body)
+	_ = response.Body.Close()
+
+	responseError := &ResponseError{Request: response.Request, Response: response}
+	if err := json.Unmarshal(body, responseError); err != nil {
+		responseError.ErrorData.Code = response.StatusCode
+		responseError.ErrorData.Message = string(body)
+	}
+
+	return fmt.Errorf("could not %s, got response %s: %w", request, response.Status, responseError)
+}
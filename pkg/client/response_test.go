@@ -0,0 +1,40 @@
+package client_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/anexia-it/go-anxcloud/pkg/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckResponse(t *testing.T) {
+	respond := func(status int, body string) *http.Response {
+		return &http.Response{StatusCode: status, Status: http.StatusText(status), Body: ioutil.NopCloser(strings.NewReader(body))}
+	}
+
+	for _, status := range []int{http.StatusOK, http.StatusCreated, http.StatusNoContent} {
+		assert.NoError(t, client.CheckResponse(respond(status, ""), "get things"), status)
+	}
+
+	for status, body := range map[int]string{
+		http.StatusNotModified:         "",
+		http.StatusNotFound:            `{"error":{"code":404,"message":"not found"}}`,
+		http.StatusUnprocessableEntity: `{"error":{"code":422,"message":"invalid","validation":{"name":"must not be empty"}}}`,
+		http.StatusBadGateway:          "<html>bad gateway</html>",
+	} {
+		err := client.CheckResponse(respond(status, body), "get things")
+		var responseErr *client.ResponseError
+		if assert.True(t, errors.As(err, &responseErr), "%d: expected ResponseError but got %v", status, err) {
+			assert.Contains(t, err.Error(), "could not get things", status)
+			assert.Equal(t, status, responseErr.Response.StatusCode, status)
+			if !strings.HasPrefix(body, "{") {
+				assert.Equal(t, status, responseErr.ErrorData.Code, "%d: the status must be used without error body", status)
+				assert.Equal(t, body, responseErr.ErrorData.Message, status)
+			}
+		}
+	}
+}
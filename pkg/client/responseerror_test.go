@@ -0,0 +1,44 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/anexia-it/go-anxcloud/pkg/client"
+	"github.com/anexia-it/go-anxcloud/pkg/test/echo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResponseErrorValidation(t *testing.T) {
+	cw, server := client.NewTestClient(nil, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":{"code":400,"message":"invalid request","validation":{"ttl":"must be positive","name":"must not be empty"}}}`))
+	}))
+	defer server.Close()
+
+	err := echo.NewAPI(cw).Echo(context.Background())
+	var responseErr *client.ResponseError
+	if !assert.True(t, errors.As(err, &responseErr), "expected ResponseError but got %v", err) {
+		return
+	}
+
+	assert.EqualValues(t, "received error from api: 400 invalid request (name: must not be empty; ttl: must be positive)",
+		responseErr.Error())
+	assert.Equal(t, map[string]string{"name": "must not be empty", "ttl": "must be positive"}, responseErr.ValidationErrors())
+	message, ok := responseErr.FieldError("ttl")
+	assert.True(t, ok)
+	assert.EqualValues(t, "must be positive", message)
+	_, ok = responseErr.FieldError("rdata")
+	assert.False(t, ok)
+
+	responseErr.ValidationErrors()["ttl"] = "changed"
+	assert.EqualValues(t, "must be positive", responseErr.ErrorData.Validation["ttl"])
+}
+
+func TestResponseErrorWithoutValidation(t *testing.T) {
+	responseErr := client.ResponseError{Response: &http.Response{StatusCode: http.StatusNotFound}}
+	assert.EqualValues(t, "received error from api: 404", responseErr.Error())
+	assert.Nil(t, responseErr.ValidationErrors())
+}
@@ -0,0 +1,203 @@
+package client
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+// RetryDecision is the result of classifying a response for retrying.
+type RetryDecision int
+
+const (
+	// DefaultRetryDecision lets the default classification decide, which retries all requests on
+	// 429 Too Many Requests, and idempotent ones also on transport errors and 5xx responses.
+	DefaultRetryDecision RetryDecision = iota
+	// RetryRequest retries the request if attempts are left.
+	RetryRequest
+	// FailRequest returns the response or error without retrying.
+	FailRequest
+)
+
+// RetryClassifier decides whether a request is retried based on its response or error.
+// The response is nil if the request failed without a response.
+type RetryClassifier func(response *http.Response, err error) RetryDecision
+
+type retryOptions struct {
+	maxAttempts int
+	baseDelay   time.Duration
+	classifier  RetryClassifier
+	backoff     BackoffStrategy
+}
+
+// WithRetry retries failed requests up to maxAttempts attempts in total.
+//
+// The delay between attempts starts at baseDelay and doubles with every attempt, with jitter as described
+// for EqualJitterBackoff, unless another strategy is set via WithBackoff. If the API asks for a longer delay
+// via the Retry-After header, that one is used instead.
+//
+// Idempotent requests (GET, HEAD, OPTIONS, PUT and DELETE) are retried on transport errors, 429 and 5xx responses.
+// Other requests like POST are only retried on 429, as the API did not process them then. Other 4xx
+// responses are never retried. Request bodies are rewound for every attempt, which requires
+// http.Request.GetBody, as set by http.NewRequest for buffered bodies.
+// Retries stop if the context of the request is done, also while waiting for the next attempt.
+func WithRetry(maxAttempts int, baseDelay time.Duration) Option {
+	return func(o *optionSet) error {
+		if maxAttempts < 1 {
+			return fmt.Errorf("%w: at least one attempt is required", ErrConfiguration)
+		}
+		o.retry.maxAttempts = maxAttempts
+		o.retry.baseDelay = baseDelay
+
+		return nil
+	}
+}
+
+// WithRetryClassifier lets the given classifier decide whether a failed request is retried.
+//
+// The classifier is consulted before the default classification, which is used if it returns DefaultRetryDecision.
+// The response body is buffered before the classifier is called, so it can be read by the classifier
+// and is still readable afterwards. This only has an effect in combination with WithRetry.
+func WithRetryClassifier(classifier RetryClassifier) Option {
+	return func(o *optionSet) error {
+		o.retry.classifier = classifier
+
+		return nil
+	}
+}
+
+func defaultRetryDecision(req *http.Request, response *http.Response, err error) RetryDecision {
+	switch {
+	case response != nil && response.StatusCode == http.StatusTooManyRequests:
+		return RetryRequest
+	case !isIdempotent(req.Method):
+		return FailRequest
+	case response == nil && err != nil:
+		return RetryRequest
+	case response == nil:
+		return FailRequest
+	case response.StatusCode >= 500 && response.StatusCode < 600:
+		return RetryRequest
+	default:
+		return FailRequest
+	}
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case "", http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+func (r retryOptions) shouldRetry(req *http.Request, response *http.Response, err error) (bool, error) {
+	if err == nil {
+		return false, nil
+	}
+
+	decision := DefaultRetryDecision
+	if r.classifier != nil {
+		if response != nil {
+			if bufferErr := bufferBody(response); bufferErr != nil {
+				return false, bufferErr
+			}
+		}
+		decision = r.classifier(response, err)
+		if response != nil {
+			if bufferErr := bufferBody(response); bufferErr != nil {
+				return false, bufferErr
+			}
+		}
+	}
+	if decision == DefaultRetryDecision {
+		decision = defaultRetryDecision(req, response, err)
+	}
+
+	return decision == RetryRequest, nil
+}
+
+// bufferBody replaces the body of the response with an in-memory copy, or rewinds it if it already is one.
+func bufferBody(response *http.Response) error {
+	if body, ok := response.Body.(bufferedBody); ok {
+		_, err := body.Seek(0, io.SeekStart)
+		return err
+	}
+
+	body, err := ioutil.ReadAll(response.Body)
+	_ = response.Body.Close()
+	if err != nil {
+		return fmt.Errorf("could not buffer response body: %w", err)
+	}
+	response.Body = bufferedBody{bytes.NewReader(body)}
+
+	return nil
+}
+
+// bufferedBody is a response body held in memory that can be rewound.
+type bufferedBody struct {
+	*bytes.Reader
+}
+
+func (bufferedBody) Close() error {
+	return nil
+}
+
+// doWithRetry sends the request until it succeeds, the retry attempts are exhausted or its context is done.
+// It also returns the number of retries made.
+func (t *transport) doWithRetry(req *http.Request) (*http.Response, int, error) {
+	for attempt := 1; ; attempt++ {
+		response, err := t.send(req)
+		if attempt >= t.retry.maxAttempts {
+			return response, attempt - 1, err
+		}
+
+		retry, bufferErr := t.retry.shouldRetry(req, response, err)
+		if bufferErr != nil {
+			return response, attempt - 1, bufferErr
+		}
+		if !retry {
+			return response, attempt - 1, err
+		}
+
+		if req.Body != nil && req.Body != http.NoBody {
+			if req.GetBody == nil {
+				return response, attempt - 1, err
+			}
+			body, bodyErr := req.GetBody()
+			if bodyErr != nil {
+				return response, attempt - 1, err
+			}
+			req.Body = body
+		}
+
+		backoff := t.retry.backoff
+		if backoff == nil {
+			backoff = EqualJitterBackoff(t.retry.baseDelay, 0)
+		}
+		delay := backoff.NextDelay(attempt, response)
+		var retryAfterErr *RetryAfterError
+		if errors.As(err, &retryAfterErr) && retryAfterErr.RetryAfter > delay {
+			delay = retryAfterErr.RetryAfter
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-req.Context().Done():
+			timer.Stop()
+			if response != nil {
+				_ = response.Body.Close()
+			}
+			return nil, attempt - 1, fmt.Errorf("retrying request aborted after attempt %d: %w", attempt, req.Context().Err())
+		}
+		if response != nil {
+			_ = response.Body.Close()
+		}
+	}
+}
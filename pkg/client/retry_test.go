@@ -0,0 +1,170 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/anexia-it/go-anxcloud/pkg/client"
+	"github.com/stretchr/testify/assert"
+)
+
+// newFlakyServer responds with the given status and body to the first failures requests and with 200 afterwards.
+func newFlakyServer(failures, status int, body string) (*httptest.Server, *int) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests <= failures {
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(body))
+			return
+		}
+		_, _ = w.Write([]byte(`"ok"`))
+	}))
+
+	return server, &requests
+}
+
+func TestRetryClassifier(t *testing.T) {
+	classifier := func(response *http.Response, _ error) client.RetryDecision {
+		if response == nil {
+			return client.DefaultRetryDecision
+		}
+		body, readErr := ioutil.ReadAll(response.Body)
+		assert.NoError(t, readErr)
+		if strings.Contains(string(body), "temporarily locked") {
+			return client.RetryRequest
+		}
+
+		return client.DefaultRetryDecision
+	}
+
+	t.Run("RetriesClassifiedResponse", func(t *testing.T) {
+		server, requests := newFlakyServer(2, http.StatusConflict, `{"error":{"code":409,"message":"temporarily locked"}}`)
+		defer server.Close()
+
+		c, err := client.New(client.TokenFromString("token"), client.WithRetry(3, time.Millisecond),
+			client.WithRetryClassifier(classifier))
+		assert.NoError(t, err)
+
+		req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+		assert.NoError(t, err)
+		response, err := c.Do(req)
+		assert.NoError(t, err)
+		if assert.NotNil(t, response) {
+			assert.EqualValues(t, http.StatusOK, response.StatusCode)
+		}
+		assert.EqualValues(t, 3, *requests)
+	})
+
+	t.Run("FallsBackToDefault", func(t *testing.T) {
+		server, requests := newFlakyServer(1, http.StatusConflict, `{"error":{"code":409,"message":"already exists"}}`)
+		defer server.Close()
+
+		c, err := client.New(client.TokenFromString("token"), client.WithRetry(3, time.Millisecond),
+			client.WithRetryClassifier(classifier))
+		assert.NoError(t, err)
+
+		req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+		assert.NoError(t, err)
+		response, err := c.Do(req)
+		assert.Error(t, err)
+		if assert.NotNil(t, response) {
+			body, readErr := ioutil.ReadAll(response.Body)
+			assert.NoError(t, readErr)
+			assert.Contains(t, string(body), "already exists")
+		}
+		assert.EqualValues(t, 1, *requests)
+	})
+}
+
+func TestRetryHonorsRetryAfter(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(`{"error":{"code":503,"message":"maintenance"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`"ok"`))
+	}))
+	defer server.Close()
+
+	c, err := client.New(client.TokenFromString("token"), client.WithRetry(2, time.Millisecond))
+	assert.NoError(t, err)
+
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	assert.NoError(t, err)
+	start := time.Now()
+	response, err := c.Do(req)
+	assert.NoError(t, err)
+	if assert.NotNil(t, response) {
+		assert.EqualValues(t, http.StatusOK, response.StatusCode)
+	}
+	assert.GreaterOrEqual(t, time.Since(start), time.Second)
+	assert.EqualValues(t, 2, requests)
+}
+
+func TestRetryIdempotency(t *testing.T) {
+	for _, testCase := range []struct {
+		name     string
+		method   string
+		status   int
+		requests int
+	}{
+		{"GetOn5xx", http.MethodGet, http.StatusBadGateway, 2},
+		{"PostOn5xx", http.MethodPost, http.StatusBadGateway, 1},
+		{"PostOn429", http.MethodPost, http.StatusTooManyRequests, 2},
+		{"GetOn404", http.MethodGet, http.StatusNotFound, 1},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			var bodies []string
+			server, requests := newFlakyServer(1, testCase.status, `{"error":{"code":1,"message":"failed"}}`)
+			server.Config.Handler = func(next http.Handler) http.Handler {
+				return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					body, _ := ioutil.ReadAll(r.Body)
+					bodies = append(bodies, string(body))
+					next.ServeHTTP(w, r)
+				})
+			}(server.Config.Handler)
+			defer server.Close()
+
+			c, err := client.New(client.TokenFromString("token"), client.WithRetry(3, time.Millisecond))
+			assert.NoError(t, err)
+
+			req, err := http.NewRequest(testCase.method, server.URL, strings.NewReader("payload"))
+			assert.NoError(t, err)
+			response, _ := c.Do(req)
+			if response != nil {
+				_ = response.Body.Close()
+			}
+			assert.EqualValues(t, testCase.requests, *requests)
+			for _, body := range bodies {
+				assert.EqualValues(t, "payload", body)
+			}
+		})
+	}
+}
+
+func TestRetryAbortsOnCancel(t *testing.T) {
+	server, requests := newFlakyServer(5, http.StatusServiceUnavailable, `{"error":{"code":503,"message":"maintenance"}}`)
+	defer server.Close()
+
+	c, err := client.New(client.TokenFromString("token"), client.WithRetry(5, time.Hour))
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	assert.NoError(t, err)
+	_, err = c.Do(req)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded), "expected context.DeadlineExceeded but got %v", err)
+	assert.EqualValues(t, 1, *requests)
+}
@@ -0,0 +1,54 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryAfterError is returned if the API rejected a request with 429 Too Many Requests or 503 Service Unavailable
+// and told when to retry it via the Retry-After header.
+//
+// If retrying is enabled via WithRetry, the client waits at least RetryAfter before the next attempt.
+// Otherwise callers can use RetryAfter to implement their own backoff.
+type RetryAfterError struct {
+	// RetryAfter is the time to wait before retrying the request.
+	RetryAfter time.Duration
+	// Err is the error the request failed with.
+	Err error
+}
+
+func (e *RetryAfterError) Error() string {
+	return fmt.Sprintf("%v, retry after %v", e.Err, e.RetryAfter)
+}
+
+// Unwrap returns the error the request failed with.
+func (e *RetryAfterError) Unwrap() error {
+	return e.Err
+}
+
+// retryAfterOf parses the Retry-After header of 429 and 503 responses, given in seconds or as HTTP date.
+func retryAfterOf(response *http.Response) (time.Duration, bool) {
+	if response.StatusCode != http.StatusTooManyRequests && response.StatusCode != http.StatusServiceUnavailable {
+		return 0, false
+	}
+
+	value := response.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		if delay := time.Until(date); delay > 0 {
+			return delay, true
+		}
+		return 0, true
+	}
+
+	return 0, false
+}
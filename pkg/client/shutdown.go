@@ -0,0 +1,108 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// ErrShutdown is raised for requests issued on a client after Shutdown was called.
+var ErrShutdown = errors.New("client was shut down")
+
+// Shutdown cancels all in-flight requests of the given client and waits for them to return,
+// or for ctx to be done. Requests issued afterwards fail with ErrShutdown.
+//
+// A request counts as in-flight until its response body is closed, or until it returned an error.
+// Once all requests returned, idle connections are closed if the client created its own http.Client, e.g. for
+// WithProxy or WithTLSConfig. http.DefaultClient and clients passed via HTTPClient are shared with other code
+// and left untouched, as are clients not created with New.
+func Shutdown(ctx context.Context, c Client) error {
+	s, ok := c.(interface {
+		shutdown(ctx context.Context) error
+	})
+	if !ok {
+		return nil
+	}
+
+	return s.shutdown(ctx)
+}
+
+// lifecycle tracks the in-flight requests of a transport.
+type lifecycle struct {
+	mu       sync.Mutex
+	closed   bool
+	inFlight sync.WaitGroup
+	done     chan struct{}
+}
+
+func newLifecycle() *lifecycle {
+	return &lifecycle{done: make(chan struct{})}
+}
+
+// begin registers a request as in-flight and returns it with a context canceled on shutdown,
+// together with the function to call once the request is finished.
+func (l *lifecycle) begin(req *http.Request) (*http.Request, func(), error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.closed {
+		return nil, nil, ErrShutdown
+	}
+	l.inFlight.Add(1)
+
+	ctx, cancel := context.WithCancel(req.Context())
+	go func() {
+		select {
+		case <-l.done:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	once := sync.Once{}
+	return req.WithContext(ctx), func() {
+		once.Do(func() {
+			cancel()
+			l.inFlight.Done()
+		})
+	}, nil
+}
+
+func (t *transport) shutdown(ctx context.Context) error {
+	t.lifecycle.mu.Lock()
+	if !t.lifecycle.closed {
+		t.lifecycle.closed = true
+		close(t.lifecycle.done)
+	}
+	t.lifecycle.mu.Unlock()
+
+	finished := make(chan struct{})
+	go func() {
+		t.lifecycle.inFlight.Wait()
+		close(finished)
+	}()
+
+	select {
+	case <-finished:
+		if t.ownsHTTPClient {
+			t.httpClient.CloseIdleConnections()
+		}
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// trackedBody finishes the tracking of a request once its body is closed.
+type trackedBody struct {
+	io.ReadCloser
+	finish func()
+}
+
+func (b trackedBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.finish()
+
+	return err
+}
@@ -0,0 +1,72 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/anexia-it/go-anxcloud/pkg/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShutdown(t *testing.T) {
+	c, err := client.New(client.TokenFromString("token"))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	received := make(chan struct{})
+	cw, server := client.NewTestClient(c, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(received)
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	failed := make(chan error)
+	go func() {
+		req, err := http.NewRequest(http.MethodGet, cw.BaseURL(), nil)
+		assert.NoError(t, err)
+		_, err = cw.Do(req)
+		failed <- err
+	}()
+	<-received
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	assert.NoError(t, client.Shutdown(ctx, c))
+
+	select {
+	case err := <-failed:
+		assert.True(t, errors.Is(err, context.Canceled), "expected context.Canceled but got %v", err)
+	case <-time.After(time.Second):
+		t.Fatal("in-flight request was not canceled")
+	}
+
+	req, err := http.NewRequest(http.MethodGet, cw.BaseURL(), nil)
+	assert.NoError(t, err)
+	_, err = cw.Do(req)
+	assert.True(t, errors.Is(err, client.ErrShutdown), "expected ErrShutdown but got %v", err)
+}
+
+// idleClosingTransport records whether its idle connections were closed.
+type idleClosingTransport struct {
+	http.RoundTripper
+	closed bool
+}
+
+func (t *idleClosingTransport) CloseIdleConnections() {
+	t.closed = true
+}
+
+func TestShutdownKeepsSharedHTTPClient(t *testing.T) {
+	shared := &idleClosingTransport{RoundTripper: http.DefaultTransport}
+	c, err := client.New(client.TokenFromString("token"), client.HTTPClient(&http.Client{Transport: shared}))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.NoError(t, client.Shutdown(context.Background(), c))
+	assert.False(t, shared.closed, "idle connections of a client passed via HTTPClient must not be closed")
+}
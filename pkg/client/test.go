@@ -1,7 +1,6 @@
 package client
 
 import (
-	"io"
 	"net/http"
 	"net/http/httptest"
 )
@@ -10,7 +9,7 @@ type testClient struct {
 	baseClient Client
 	baseURL    string
 	httpClient *http.Client
-	logWriter  io.Writer
+	logger     Logger
 }
 
 func (t testClient) BaseURL() string {
@@ -22,7 +21,15 @@ func (t testClient) Do(req *http.Request) (*http.Response, error) {
 		return t.baseClient.Do(req)
 	}
 
-	return handleRequest(t.httpClient, req, t.logWriter)
+	return handleRequest(t.httpClient, req, t.logger)
+}
+
+func (t testClient) defaultLocationID() string {
+	if locator, ok := t.baseClient.(interface{ defaultLocationID() string }); ok {
+		return locator.defaultLocationID()
+	}
+
+	return ""
 }
 
 // NewTestClient creates a new client for testing.
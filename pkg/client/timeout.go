@@ -0,0 +1,44 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// RequestTimeout applies a timeout of d to requests whose context has no deadline.
+//
+// The timeout covers sending the request and reading the response body. Requests already carrying a
+// deadline are left untouched. When this option is omitted DefaultRequestTimeout is used, passing 0
+// disables the timeout.
+func RequestTimeout(d time.Duration) Option {
+	return func(o *optionSet) error {
+		if d < 0 {
+			return fmt.Errorf("%w: request timeout must not be negative, got %v", ErrConfiguration, d)
+		}
+		o.requestTimeout = d
+
+		return nil
+	}
+}
+
+// withTimeout returns ctx with the configured request timeout applied if it has no deadline yet.
+// The returned function has to be called once the request is finished.
+func (t *transport) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if _, hasDeadline := ctx.Deadline(); hasDeadline || t.requestTimeout <= 0 {
+		return ctx, func() {}
+	}
+
+	return context.WithTimeout(ctx, t.requestTimeout)
+}
+
+// withRequestTimeout is withTimeout for the context of req.
+func (t *transport) withRequestTimeout(req *http.Request) (*http.Request, context.CancelFunc) {
+	ctx, cancel := t.withTimeout(req.Context())
+	if ctx == req.Context() {
+		return req, cancel
+	}
+
+	return req.WithContext(ctx), cancel
+}
@@ -0,0 +1,56 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/anexia-it/go-anxcloud/pkg/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestTimeout(t *testing.T) {
+	_, server := client.NewTestClient(nil, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(200 * time.Millisecond):
+		}
+	}))
+	defer server.Close()
+
+	do := func(ctx context.Context, options ...client.Option) error {
+		c, err := client.New(append([]client.Option{client.TokenFromString("token")}, options...)...)
+		if err != nil {
+			return err
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+		assert.NoError(t, err)
+		response, err := c.Do(req)
+		if err != nil {
+			return err
+		}
+		return response.Body.Close()
+	}
+
+	t.Run("Applied", func(t *testing.T) {
+		err := do(context.Background(), client.RequestTimeout(20*time.Millisecond))
+		assert.True(t, errors.Is(err, context.DeadlineExceeded), "expected context.DeadlineExceeded but got %v", err)
+	})
+
+	t.Run("KeepsDeadline", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		assert.NoError(t, do(ctx, client.RequestTimeout(20*time.Millisecond)))
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		assert.NoError(t, do(context.Background(), client.RequestTimeout(0)))
+	})
+
+	t.Run("Negative", func(t *testing.T) {
+		err := do(context.Background(), client.RequestTimeout(-time.Second))
+		assert.True(t, errors.Is(err, client.ErrConfiguration), "expected ErrConfiguration but got %v", err)
+	})
+}
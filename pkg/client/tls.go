@@ -0,0 +1,64 @@
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+)
+
+// WithTLSConfig lets the client use a copy of config for TLS connections, e.g. to present a client certificate.
+//
+// Configurations with InsecureSkipVerify set are rejected with ErrConfiguration, use WithInsecureSkipVerify
+// to disable verification explicitly.
+func WithTLSConfig(config *tls.Config) Option {
+	return func(o *optionSet) error {
+		if config == nil {
+			return fmt.Errorf("%w: TLS config must not be nil", ErrConfiguration)
+		}
+		if config.InsecureSkipVerify {
+			return fmt.Errorf("%w: TLS config skips certificate verification, use WithInsecureSkipVerify instead",
+				ErrConfiguration)
+		}
+		o.tlsConfig = config.Clone()
+
+		return nil
+	}
+}
+
+// WithRootCAs lets the client trust the certificate authorities in pool instead of the ones of the system,
+// e.g. to talk to an engine instance using a private CA. It overrides the RootCAs of WithTLSConfig.
+func WithRootCAs(pool *x509.CertPool) Option {
+	return func(o *optionSet) error {
+		if pool == nil {
+			return fmt.Errorf("%w: root CA pool must not be nil", ErrConfiguration)
+		}
+		o.rootCAs = pool
+
+		return nil
+	}
+}
+
+// WithInsecureSkipVerify disables the verification of server certificates.
+//
+// This makes the connection vulnerable to man-in-the-middle attacks and is only meant for tests.
+func WithInsecureSkipVerify() Option {
+	return func(o *optionSet) error {
+		o.insecureSkipVerify = true
+
+		return nil
+	}
+}
+
+// tlsClientConfig returns the TLS configuration combining WithTLSConfig, WithRootCAs and WithInsecureSkipVerify.
+func (o optionSet) tlsClientConfig() *tls.Config {
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
+	if o.tlsConfig != nil {
+		config = o.tlsConfig.Clone()
+	}
+	if o.rootCAs != nil {
+		config.RootCAs = o.rootCAs
+	}
+	config.InsecureSkipVerify = o.insecureSkipVerify //nolint:gosec // Only set by WithInsecureSkipVerify.
+
+	return config
+}
@@ -0,0 +1,43 @@
+package client_test
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/anexia-it/go-anxcloud/pkg/client"
+	"github.com/anexia-it/go-anxcloud/pkg/test/echo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTLSOptions(t *testing.T) {
+	server := httptest.NewTLSServer(echo.TestMock(t))
+	defer server.Close()
+	pool := x509.NewCertPool()
+	pool.AddCert(server.Certificate())
+
+	echoWith := func(options ...client.Option) error {
+		c, err := client.New(append([]client.Option{client.TokenFromString("token"), client.BaseURL(server.URL)}, options...)...)
+		if err != nil {
+			return err
+		}
+		return echo.NewAPI(c).Echo(context.Background())
+	}
+
+	assert.Error(t, echoWith(), "expected the certificate of the test server to be untrusted")
+	assert.NoError(t, echoWith(client.WithRootCAs(pool)))
+	assert.NoError(t, echoWith(client.WithTLSConfig(&tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12})))
+	assert.NoError(t, echoWith(client.WithInsecureSkipVerify()))
+
+	for _, option := range []client.Option{
+		client.WithTLSConfig(&tls.Config{InsecureSkipVerify: true}), //nolint:gosec // Testing it is rejected.
+		client.WithTLSConfig(nil),
+		client.WithRootCAs(nil),
+	} {
+		err := echoWith(option)
+		assert.True(t, errors.Is(err, client.ErrConfiguration), "expected ErrConfiguration but got %v", err)
+	}
+}
@@ -1,23 +1,55 @@
 package client
 
 import (
-	"fmt"
-	"io"
+	"errors"
 	"net/http"
+	"sync"
 )
 
+// ErrTokenRotationUnsupported is raised by SetToken for clients not authenticating with a static token.
+var ErrTokenRotationUnsupported = errors.New("client does not support token rotation")
+
 type tokenClient struct {
-	token      string
-	httpClient *http.Client
-	logWriter  io.Writer
+	*transport
+
+	mu    sync.RWMutex
+	token string
+	file  *tokenFile
 }
 
-func (t tokenClient) BaseURL() string {
-	return DefaultBaseURL
+func (t *tokenClient) Do(req *http.Request) (*http.Response, error) {
+	if t.file != nil {
+		if token, changed := t.file.reload(); changed {
+			_ = t.setToken(token)
+		}
+	}
+
+	t.mu.RLock()
+	token := t.token
+	t.mu.RUnlock()
+	t.setUserAgent(req)
+	t.authorize(req, "Token", token)
+
+	return t.do(req)
+}
+
+func (t *tokenClient) setToken(token string) error { //nolint:unparam // Shared with locationClient, which can fail.
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.token = token
+
+	return nil
 }
 
-func (t tokenClient) Do(req *http.Request) (*http.Response, error) {
-	req.Header.Set("Authorization", fmt.Sprintf("Token %v", t.token))
+// SetToken replaces the token of a client created with TokenFromString or TokenFromEnv.
+//
+// It is safe to call while requests are issued concurrently; requests already sent keep the old token.
+// ErrTokenRotationUnsupported is returned for clients authenticating otherwise.
+func SetToken(c Client, token string) error {
+	rotator, ok := c.(interface{ setToken(token string) error })
+	if !ok {
+		return ErrTokenRotationUnsupported
+	}
 
-	return handleRequest(t.httpClient, req, t.logWriter)
+	return rotator.setToken(token)
 }
@@ -0,0 +1,101 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrTokenFile is raised if the token can not be read from the file given to TokenFromFile.
+var ErrTokenFile = errors.New("could not read token file")
+
+// TokenFromFile reads the API auth token from the file at path, e.g. a mounted Kubernetes secret.
+// Surrounding whitespace and newlines are trimmed. An error wrapping ErrTokenFile is returned if the file
+// can not be read or is empty.
+func TokenFromFile(path string) Option {
+	return func(o *optionSet) error {
+		token, err := readTokenFile(path)
+		if err != nil {
+			return err
+		}
+		o.token = token
+		o.tokenFile = path
+
+		return nil
+	}
+}
+
+// ReloadTokenFile lets the client check the file given to TokenFromFile for changes at most once per interval,
+// using the new token for subsequent requests. This way a rotated secret is picked up without recreating
+// the client. If the file can not be read or is empty when reloading, the previous token is kept.
+func ReloadTokenFile(interval time.Duration) Option {
+	return func(o *optionSet) error {
+		if interval <= 0 {
+			return fmt.Errorf("%w: token file reload interval has to be positive", ErrConfiguration)
+		}
+		o.tokenFileReload = interval
+
+		return nil
+	}
+}
+
+func readTokenFile(path string) (string, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrTokenFile, err)
+	}
+
+	token := strings.TrimSpace(string(content))
+	if token == "" {
+		return "", fmt.Errorf("%w: '%s' is empty", ErrTokenFile, path)
+	}
+
+	return token, nil
+}
+
+// tokenFile reloads the token of a tokenClient from a file once it changed.
+type tokenFile struct {
+	path     string
+	interval time.Duration
+
+	mu        sync.Mutex
+	lastCheck time.Time
+	modTime   time.Time
+}
+
+func newTokenFile(path string, interval time.Duration) *tokenFile {
+	f := &tokenFile{path: path, interval: interval, lastCheck: time.Now()}
+	if info, err := os.Stat(path); err == nil {
+		f.modTime = info.ModTime()
+	}
+
+	return f
+}
+
+// reload returns the token of the file if it changed since the last check and the interval passed.
+func (f *tokenFile) reload() (string, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if time.Since(f.lastCheck) < f.interval {
+		return "", false
+	}
+	f.lastCheck = time.Now()
+
+	info, err := os.Stat(f.path)
+	if err != nil || info.ModTime().Equal(f.modTime) {
+		return "", false
+	}
+
+	token, err := readTokenFile(f.path)
+	if err != nil {
+		return "", false
+	}
+	f.modTime = info.ModTime()
+
+	return token, true
+}
@@ -0,0 +1,43 @@
+package client_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/anexia-it/go-anxcloud/pkg/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenFromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+
+	t.Run("Missing", func(t *testing.T) {
+		_, err := client.New(client.TokenFromFile(path))
+		assert.True(t, errors.Is(err, client.ErrTokenFile), "expected ErrTokenFile but got %v", err)
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		assert.NoError(t, ioutil.WriteFile(path, []byte(" \n"), 0o600))
+		_, err := client.New(client.TokenFromFile(path))
+		assert.True(t, errors.Is(err, client.ErrTokenFile), "expected ErrTokenFile but got %v", err)
+	})
+
+	t.Run("Reload", func(t *testing.T) {
+		assert.NoError(t, ioutil.WriteFile(path, []byte("first-token\n"), 0o600))
+		c, err := client.New(client.TokenFromFile(path), client.ReloadTokenFile(time.Millisecond))
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.EqualValues(t, "Token first-token", authorizationOf(t, c))
+
+		assert.NoError(t, ioutil.WriteFile(path, []byte("second-token\n"), 0o600))
+		future := time.Now().Add(time.Minute)
+		assert.NoError(t, os.Chtimes(path, future, future))
+		time.Sleep(2 * time.Millisecond)
+		assert.EqualValues(t, "Token second-token", authorizationOf(t, c))
+	})
+}
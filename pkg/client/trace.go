@@ -0,0 +1,77 @@
+package client
+
+import (
+	"context"
+	"encoding/hex"
+	"net/http"
+	"strings"
+)
+
+// TraceparentHeader is the W3C trace context header carrying the trace and span ID of a request.
+const TraceparentHeader = "traceparent"
+
+// TraceContext identifies the trace and span a request belongs to.
+type TraceContext struct {
+	// TraceID is the hex encoded 16 byte trace ID.
+	TraceID string
+	// SpanID is the hex encoded 8 byte span ID.
+	SpanID string
+}
+
+// Valid returns true if the trace context has a well-formed, non-zero trace and span ID.
+func (t TraceContext) Valid() bool {
+	return isTraceID(t.TraceID, 32) && isTraceID(t.SpanID, 16)
+}
+
+// ExemplarLabels returns the labels of an OpenMetrics exemplar linking a metric to the trace,
+// or nil if the trace context is not valid.
+func (t TraceContext) ExemplarLabels() map[string]string {
+	if !t.Valid() {
+		return nil
+	}
+
+	return map[string]string{"trace_id": t.TraceID, "span_id": t.SpanID}
+}
+
+type traceContextKey struct{}
+
+// WithTraceContext returns a context that links all requests using it to the given trace.
+//
+// Tracing libraries keep the current span in the context in their own way, so it has to be
+// copied over explicitly, e.g. from an OpenTelemetry span context.
+func WithTraceContext(ctx context.Context, trace TraceContext) context.Context {
+	return context.WithValue(ctx, traceContextKey{}, trace)
+}
+
+// RequestTraceContext returns the trace context of the request, taken from its context if set by
+// WithTraceContext, otherwise from its TraceparentHeader. It returns false if the request is not traced.
+func RequestTraceContext(req *http.Request) (TraceContext, bool) {
+	if trace, ok := req.Context().Value(traceContextKey{}).(TraceContext); ok && trace.Valid() {
+		return trace, true
+	}
+
+	return parseTraceparent(req.Header.Get(TraceparentHeader))
+}
+
+// parseTraceparent parses a W3C traceparent header of the form version-traceid-spanid-flags.
+func parseTraceparent(header string) (TraceContext, bool) {
+	parts := strings.Split(strings.TrimSpace(header), "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" {
+		return TraceContext{}, false
+	}
+
+	trace := TraceContext{TraceID: parts[1], SpanID: parts[2]}
+	return trace, trace.Valid()
+}
+
+// isTraceID returns true if id consists of length lowercase hex characters, not all of them zero.
+func isTraceID(id string, length int) bool {
+	if len(id) != length || strings.ToLower(id) != id {
+		return false
+	}
+	if _, err := hex.DecodeString(id); err != nil {
+		return false
+	}
+
+	return strings.Trim(id, "0") != ""
+}
@@ -0,0 +1,47 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/anexia-it/go-anxcloud/pkg/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestTraceContext(t *testing.T) {
+	const (
+		traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+		spanID  = "00f067aa0ba902b7"
+	)
+
+	t.Run("NotTraced", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "http://localhost", nil)
+		_, ok := client.RequestTraceContext(req)
+		assert.False(t, ok)
+		assert.Nil(t, client.TraceContext{}.ExemplarLabels())
+	})
+
+	t.Run("FromContext", func(t *testing.T) {
+		ctx := client.WithTraceContext(context.Background(), client.TraceContext{TraceID: traceID, SpanID: spanID})
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "http://localhost", nil)
+		trace, ok := client.RequestTraceContext(req)
+		assert.True(t, ok)
+		assert.Equal(t, map[string]string{"trace_id": traceID, "span_id": spanID}, trace.ExemplarLabels())
+	})
+
+	t.Run("FromHeader", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "http://localhost", nil)
+		req.Header.Set(client.TraceparentHeader, "00-"+traceID+"-"+spanID+"-01")
+		trace, ok := client.RequestTraceContext(req)
+		assert.True(t, ok)
+		assert.Equal(t, client.TraceContext{TraceID: traceID, SpanID: spanID}, trace)
+	})
+
+	t.Run("InvalidHeader", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "http://localhost", nil)
+		req.Header.Set(client.TraceparentHeader, "00-00000000000000000000000000000000-"+spanID+"-01")
+		_, ok := client.RequestTraceContext(req)
+		assert.False(t, ok)
+	})
+}
@@ -0,0 +1,164 @@
+package client
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// transport sends requests to the API and holds the configuration and state
+// shared by all client implementations, independent of how requests are signed.
+type transport struct {
+	// lastClockSkew is accessed atomically and only valid if clockSkewMeasured is 1.
+	// It is kept first to be 64-bit aligned on 32-bit platforms.
+	lastClockSkew     int64
+	clockSkewMeasured int32
+
+	httpClient   *http.Client
+	logger       Logger
+	maxClockSkew time.Duration
+	retry        retryOptions
+
+	generateCorrelationIDs bool
+	defaultLocation        string
+	duplicateAuthHeader    string
+	connCounter            *connCounter
+	lifecycle              *lifecycle
+	curlWriter             io.Writer
+	methodOverride         bool
+	slots                  semaphore
+	userAgent              string
+	requestTimeout         time.Duration
+	metrics                func(RequestMetric)
+	compression            bool
+	baseURL                string
+	headers                http.Header
+	// ownsHTTPClient is true if the idle connections of httpClient may be closed on shutdown.
+	ownsHTTPClient bool
+}
+
+func newTransport(o optionSet) *transport {
+	var slots semaphore
+	if o.maxConcurrency > 0 {
+		slots = make(semaphore, o.maxConcurrency)
+	}
+
+	baseURL := o.baseURL
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+
+	userAgent := o.userAgent
+	if userAgent == "" {
+		userAgent = defaultUserAgent()
+	}
+
+	var counter *connCounter
+	if o.connectionStats {
+		counter = &connCounter{}
+	}
+
+	return &transport{
+		httpClient:   o.httpClient,
+		logger:       newLogger(o),
+		maxClockSkew: o.maxClockSkew,
+		retry:        o.retry,
+
+		generateCorrelationIDs: o.generateCorrelationIDs,
+		defaultLocation:        o.defaultLocation,
+		duplicateAuthHeader:    o.duplicateAuthHeader,
+		connCounter:            counter,
+		lifecycle:              newLifecycle(),
+		curlWriter:             o.curlWriter,
+		methodOverride:         o.methodOverride,
+		slots:                  slots,
+		userAgent:              userAgent,
+		requestTimeout:         o.requestTimeout,
+		metrics:                o.metrics,
+		compression:            o.compression,
+		baseURL:                baseURL,
+		headers:                o.headers,
+		ownsHTTPClient:         o.ownsHTTPClient,
+	}
+}
+
+func (t *transport) BaseURL() string {
+	return t.baseURL
+}
+
+// do sends the given, already signed request.
+func (t *transport) do(req *http.Request) (response *http.Response, err error) {
+	retries := 0
+	if t.metrics != nil {
+		metric := newRequestMetric(req)
+		defer func() { t.metrics(metric.complete(response, retries, err)) }()
+	}
+
+	// The ID is set before retrying, so all attempts share the same ID.
+	setCorrelationID(req, t.generateCorrelationIDs)
+	if t.methodOverride {
+		req = overrideMethod(req)
+	}
+
+	req, cancelTimeout := t.withRequestTimeout(req)
+	req, finish, err := t.lifecycle.begin(req)
+	if err != nil {
+		cancelTimeout()
+		return nil, err
+	}
+	finishLifecycle := finish
+	finish = func() {
+		finishLifecycle()
+		cancelTimeout()
+	}
+	if t.slots != nil {
+		release, err := t.slots.acquire(req)
+		if err != nil {
+			finish()
+			return nil, err
+		}
+		finishRequest := finish
+		finish = func() {
+			finishRequest()
+			release()
+		}
+	}
+
+	if t.retry.maxAttempts > 1 {
+		response, retries, err = t.doWithRetry(req)
+	} else {
+		response, err = t.send(req)
+	}
+
+	// Error responses are buffered, so only successful ones need the request context while their body is read.
+	if err != nil || response == nil {
+		finish()
+	} else {
+		response.Body = trackedBody{response.Body, finish}
+	}
+
+	return response, err
+}
+
+// send sends the request once.
+func (t *transport) send(req *http.Request) (*http.Response, error) {
+	if t.connCounter != nil {
+		req = t.traceConnections(req)
+	}
+	setDefaultHeaders(req, t.headers, t.redactedHeaders()...)
+	t.setAcceptEncoding(req)
+	if t.curlWriter != nil {
+		setExtraHeaders(req, t.redactedHeaders()...)
+		if command, err := curlCommand(req, t.redactedHeaders()...); err == nil {
+			fmt.Fprintln(t.curlWriter, command)
+		}
+	}
+
+	response, err := handleRequest(t.httpClient, req, t.logger, t.redactedHeaders()...)
+	if t.maxClockSkew > 0 && response != nil {
+		err = t.checkClockSkew(response, err)
+	}
+
+	return response, err
+}
@@ -0,0 +1,58 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"runtime/debug"
+	"sync"
+)
+
+const modulePath = "github.com/anexia-it/go-anxcloud"
+
+var (
+	moduleVersionOnce sync.Once
+	moduleVersion     = "unknown"
+)
+
+// UserAgent adds the given product and its version to the User-Agent header sent with every request,
+// e.g. "go-anxcloud/v0.4.0 (terraform-provider-anxcloud/1.2.3)". Without this option, the header only
+// names go-anxcloud and its version. A User-Agent set on a request by the caller is never overwritten.
+func UserAgent(product, version string) Option {
+	return func(o *optionSet) error {
+		if product == "" {
+			return fmt.Errorf("%w: user agent product must not be empty", ErrConfiguration)
+		}
+		o.userAgent = fmt.Sprintf("%s (%s/%s)", defaultUserAgent(), product, version)
+
+		return nil
+	}
+}
+
+// defaultUserAgent returns "go-anxcloud/<version>", with the version of this module taken from the build info.
+func defaultUserAgent() string {
+	moduleVersionOnce.Do(func() {
+		info, ok := debug.ReadBuildInfo()
+		if !ok {
+			return
+		}
+		if info.Main.Path == modulePath && info.Main.Version != "" {
+			moduleVersion = info.Main.Version
+			return
+		}
+		for _, dependency := range info.Deps {
+			if dependency.Path == modulePath {
+				moduleVersion = dependency.Version
+				return
+			}
+		}
+	})
+
+	return "go-anxcloud/" + moduleVersion
+}
+
+// setUserAgent sets the User-Agent header of the request, if the caller did not set one.
+func (t *transport) setUserAgent(req *http.Request) {
+	if req.Header.Get("User-Agent") == "" {
+		req.Header.Set("User-Agent", t.userAgent)
+	}
+}
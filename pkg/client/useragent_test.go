@@ -0,0 +1,45 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/anexia-it/go-anxcloud/pkg/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func userAgentOf(t *testing.T, c client.Client, set string) string {
+	var userAgent string
+	cw, server := client.NewTestClient(c, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgent = r.Header.Get("User-Agent")
+	}))
+	defer server.Close()
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, cw.BaseURL(), nil)
+	assert.NoError(t, err)
+	if set != "" {
+		req.Header.Set("User-Agent", set)
+	}
+	response, err := cw.Do(req)
+	if assert.NoError(t, err) {
+		_ = response.Body.Close()
+	}
+
+	return userAgent
+}
+
+func TestUserAgent(t *testing.T) {
+	c, err := client.New(client.TokenFromString("token"))
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(userAgentOf(t, c, ""), "go-anxcloud/"))
+
+	c, err = client.New(client.TokenFromString("token"), client.UserAgent("terraform-provider-anxcloud", "1.2.3"))
+	assert.NoError(t, err)
+	userAgent := userAgentOf(t, c, "")
+	assert.True(t, strings.HasPrefix(userAgent, "go-anxcloud/"), userAgent)
+	assert.True(t, strings.HasSuffix(userAgent, " (terraform-provider-anxcloud/1.2.3)"), userAgent)
+
+	assert.EqualValues(t, "custom/1.0", userAgentOf(t, c, "custom/1.0"))
+}
@@ -0,0 +1,115 @@
+// Package acme provides a CloudDNS backed DNS-01 challenge provider for ACME clients.
+//
+// Provider satisfies the challenge.Provider and challenge.ProviderTimeout interfaces of
+// github.com/go-acme/lego without depending on it, so it can be passed to lego and
+// tools built upon it directly.
+package acme
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/anexia-it/go-anxcloud/pkg/client"
+	"github.com/anexia-it/go-anxcloud/pkg/clouddns/zone"
+	uuid "github.com/satori/go.uuid"
+)
+
+const (
+	// DefaultPropagationTimeout is the default time an ACME client waits for a challenge record to propagate.
+	DefaultPropagationTimeout = 5 * time.Minute
+	// DefaultPollingInterval is the default interval in which an ACME client checks for a challenge record.
+	DefaultPollingInterval = 10 * time.Second
+)
+
+// ErrNoZone is raised if no managed zone contains a domain. It is the same error as zone.ErrNoZone.
+var ErrNoZone = zone.ErrNoZone
+
+// Provider creates and removes ACME DNS-01 challenge records in CloudDNS.
+type Provider struct {
+	// RequestTimeout limits the time of all API requests for presenting or cleaning up a single challenge.
+	RequestTimeout time.Duration
+	// PropagationTimeout is returned as the time an ACME client should wait for a challenge record to propagate.
+	PropagationTimeout time.Duration
+	// PollingInterval is returned as the interval in which an ACME client should check for a challenge record.
+	PollingInterval time.Duration
+
+	api      zone.API
+	resolver *zone.ZoneResolver
+
+	mu      sync.Mutex
+	records map[string]challengeRecord
+}
+
+type challengeRecord struct {
+	zone       string
+	identifier uuid.UUID
+}
+
+// NewProvider creates a new Provider using the given client.
+func NewProvider(c client.Client) *Provider {
+	api := zone.NewAPI(c)
+	return &Provider{
+		RequestTimeout:     client.DefaultRequestTimeout,
+		PropagationTimeout: DefaultPropagationTimeout,
+		PollingInterval:    DefaultPollingInterval,
+		api:                api,
+		resolver:           zone.NewZoneResolver(api, 0),
+		records:            make(map[string]challengeRecord),
+	}
+}
+
+// Present creates the challenge record for domain in the managed zone containing it.
+func (p *Provider) Present(domain, token, keyAuth string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), p.RequestTimeout)
+	defer cancel()
+
+	zoneName, err := p.resolver.ZoneForFQDN(ctx, domain)
+	if err != nil {
+		return err
+	}
+
+	identifier, err := zone.PresentChallenge(ctx, p.api, zoneName, domain, keyAuth)
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.records[challengeKey(domain, token)] = challengeRecord{zoneName, identifier}
+
+	return nil
+}
+
+// CleanUp removes the challenge record created by Present for the same domain and token.
+func (p *Provider) CleanUp(domain, token, keyAuth string) error {
+	key := challengeKey(domain, token)
+	p.mu.Lock()
+	record, ok := p.records[key]
+	p.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("no challenge record was presented for '%s'", domain)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), p.RequestTimeout)
+	defer cancel()
+	if err := zone.CleanupChallenge(ctx, p.api, record.zone, record.identifier); err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.records, key)
+
+	return nil
+}
+
+// Timeout returns the time an ACME client should wait for a challenge record to propagate and the polling interval.
+func (p *Provider) Timeout() (timeout, interval time.Duration) {
+	return p.PropagationTimeout, p.PollingInterval
+}
+
+func challengeKey(domain, token string) string {
+	return domain + "|" + token
+}
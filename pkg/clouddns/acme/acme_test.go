@@ -0,0 +1,143 @@
+package acme_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/anexia-it/go-anxcloud/pkg/client"
+	"github.com/anexia-it/go-anxcloud/pkg/clouddns/acme"
+	"github.com/anexia-it/go-anxcloud/pkg/clouddns/zone"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// cloudDNSStore serves the zone listing and the record endpoints used by the provider. Unknown zones and records
+// are answered with 404.
+type cloudDNSStore struct {
+	t       *testing.T
+	mu      sync.Mutex
+	records map[string][]zone.Record
+}
+
+func newCloudDNSStore(t *testing.T, zoneNames ...string) *cloudDNSStore {
+	s := &cloudDNSStore{t: t, records: map[string][]zone.Record{}}
+	for _, name := range zoneNames {
+		s.records[name] = []zone.Record{}
+	}
+
+	return s
+}
+
+func (s *cloudDNSStore) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/clouddns/v1/zone.json"), "/")
+	if r.Method == http.MethodGet && len(parts) == 1 {
+		zones := make([]zone.Zone, 0, len(s.records))
+		for name := range s.records {
+			zones = append(zones, zone.Zone{Definition: &zone.Definition{ZoneName: name}})
+		}
+		assert.NoError(s.t, json.NewEncoder(w).Encode(map[string][]zone.Zone{"results": zones}))
+		return
+	}
+
+	var records []zone.Record
+	ok := len(parts) >= 3 && parts[2] == "records"
+	if ok {
+		records, ok = s.records[parts[1]]
+	}
+	switch {
+	case !ok:
+	case r.Method == http.MethodGet && len(parts) == 3:
+		assert.NoError(s.t, json.NewEncoder(w).Encode(records))
+		return
+	case r.Method == http.MethodPost && len(parts) == 3:
+		var request zone.RecordRequest
+		assert.NoError(s.t, json.NewDecoder(r.Body).Decode(&request))
+		s.records[parts[1]] = append(records, zone.Record{Identifier: uuid.NewV4(), Name: request.Name, Type: request.Type,
+			RData: request.RData, Region: request.Region, TTL: &request.TTL})
+		assert.NoError(s.t, json.NewEncoder(w).Encode(zone.Zone{Definition: &zone.Definition{ZoneName: parts[1]}}))
+		return
+	case r.Method == http.MethodDelete && len(parts) == 4:
+		for i, record := range records {
+			if record.Identifier.String() == parts[3] {
+				s.records[parts[1]] = append(records[:i], records[i+1:]...)
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+		}
+	}
+
+	w.WriteHeader(http.StatusNotFound)
+	_, _ = w.Write([]byte(`{"error":{"code":404,"message":"not found"}}`))
+}
+
+func (s *cloudDNSStore) challengeRecords(zoneName string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	names := make([]string, 0, len(s.records[zoneName]))
+	for _, record := range s.records[zoneName] {
+		names = append(names, record.Name)
+	}
+
+	return names
+}
+
+func TestProvider(t *testing.T) {
+	store := newCloudDNSStore(t, "example.com", "sub.example.com")
+	c, server := client.NewTestClient(nil, store)
+	defer server.Close()
+
+	provider := acme.NewProvider(c)
+	assert.NoError(t, provider.Present("www.example.com", "token-1", "auth-1"))
+	assert.NoError(t, provider.Present("*.shop.sub.example.com.", "token-2", "auth-2"))
+	assert.NoError(t, provider.Present("www.example.com", "token-3", "auth-3"))
+
+	assert.ElementsMatch(t, []string{"_acme-challenge.www", "_acme-challenge.www"}, store.challengeRecords("example.com"))
+	assert.Equal(t, []string{"_acme-challenge.shop"}, store.challengeRecords("sub.example.com"))
+
+	assert.NoError(t, provider.CleanUp("www.example.com", "token-1", "auth-1"))
+	assert.NoError(t, provider.CleanUp("*.shop.sub.example.com.", "token-2", "auth-2"))
+	assert.Equal(t, []string{"_acme-challenge.www"}, store.challengeRecords("example.com"), "other challenges must be kept")
+	assert.Empty(t, store.challengeRecords("sub.example.com"))
+
+	assert.Error(t, provider.CleanUp("www.example.com", "token-1", "auth-1"), "challenges can only be cleaned up once")
+
+	err := provider.Present("www.example.org", "token-4", "auth-4")
+	assert.True(t, errors.Is(err, acme.ErrNoZone), "expected ErrNoZone but got %v", err)
+}
+
+func TestProviderTimeout(t *testing.T) {
+	c, server := client.NewTestClient(nil, http.NotFoundHandler())
+	defer server.Close()
+
+	provider := acme.NewProvider(c)
+	timeout, interval := provider.Timeout()
+	assert.Equal(t, acme.DefaultPropagationTimeout, timeout)
+	assert.Equal(t, acme.DefaultPollingInterval, interval)
+
+	provider.PropagationTimeout, provider.PollingInterval = time.Minute, time.Second
+	timeout, interval = provider.Timeout()
+	assert.Equal(t, time.Minute, timeout)
+	assert.Equal(t, time.Second, interval)
+}
+
+func TestProviderRequestTimeout(t *testing.T) {
+	c, server := client.NewTestClient(nil, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	provider := acme.NewProvider(c)
+	provider.RequestTimeout = 50 * time.Millisecond
+	err := provider.Present("www.example.com", "token", "auth")
+	assert.True(t, errors.Is(err, context.DeadlineExceeded), "expected DeadlineExceeded but got %v", err)
+}
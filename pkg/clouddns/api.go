@@ -24,6 +24,7 @@ func (a api) Zone() zone.API {
 	return a.zone
 }
 
+// NewAPI creates a new clouddns API instance with the given client.
 func NewAPI(c client.Client) API {
 	return &api{zone.NewAPI(c)}
 }
@@ -0,0 +1,92 @@
+package zone
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+const (
+	// challengeLabel is prepended to a domain name to get the name of its ACME DNS-01 challenge record.
+	challengeLabel = "_acme-challenge"
+	// challengeTTL is kept short, so a changed challenge value is picked up quickly by ACME servers.
+	challengeTTL = 60
+	// challengeRegion is the region challenge records are created in.
+	challengeRegion = "default"
+)
+
+// ErrDomainNotInZone is raised if a domain name is not part of the given zone.
+var ErrDomainNotInZone = errors.New("domain is not part of zone")
+
+// ChallengeValue returns the value of the ACME DNS-01 challenge TXT record for the given key authorization.
+func ChallengeValue(keyAuth string) string {
+	digest := sha256.Sum256([]byte(keyAuth))
+	return base64.RawURLEncoding.EncodeToString(digest[:])
+}
+
+// ChallengeRecordName returns the name of the ACME DNS-01 challenge record of domain, relative to zone.
+//
+// A wildcard domain shares its challenge record with the domain it is a wildcard of,
+// so '*.example.com' results in '_acme-challenge' for zone 'example.com'.
+func ChallengeRecordName(zone, domain string) (string, error) {
+	domain = strings.TrimSuffix(strings.TrimPrefix(strings.ToLower(domain), "*."), ".")
+	zone = strings.TrimSuffix(strings.ToLower(zone), ".")
+
+	switch {
+	case domain == zone:
+		return challengeLabel, nil
+	case strings.HasSuffix(domain, "."+zone):
+		return challengeLabel + "." + strings.TrimSuffix(domain, "."+zone), nil
+	default:
+		return "", fmt.Errorf("%w: '%s' in '%s'", ErrDomainNotInZone, domain, zone)
+	}
+}
+
+// PresentChallenge creates the TXT record for an ACME DNS-01 challenge of domain.
+//
+// zone is the managed zone containing domain and keyAuth the key authorization of the challenge.
+// Returned is the identifier of the created record to be passed to CleanupChallenge.
+func PresentChallenge(ctx context.Context, a API, zone, domain, keyAuth string) (uuid.UUID, error) {
+	name, err := ChallengeRecordName(zone, domain)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	value := ChallengeValue(keyAuth)
+	_, err = a.NewRecord(ctx, zone, RecordRequest{
+		Name:   name,
+		Type:   string(TypeTXT),
+		RData:  fmt.Sprintf("%q", value),
+		Region: challengeRegion,
+		TTL:    challengeTTL,
+	})
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("could not create challenge record for '%s': %w", domain, err)
+	}
+
+	records, err := a.ListRecords(ctx, zone)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("could not look up challenge record for '%s': %w", domain, err)
+	}
+	for _, record := range records {
+		if record.Name == name && strings.EqualFold(record.Type, string(TypeTXT)) && strings.Trim(record.RData, `"`) == value {
+			return record.Identifier, nil
+		}
+	}
+
+	return uuid.Nil, fmt.Errorf("created challenge record for '%s' not found in zone '%s'", domain, zone)
+}
+
+// CleanupChallenge deletes a challenge record created by PresentChallenge.
+func CleanupChallenge(ctx context.Context, a API, zone string, cleanupID uuid.UUID) error {
+	if err := a.DeleteRecord(ctx, zone, cleanupID); err != nil {
+		return fmt.Errorf("could not delete challenge record '%s': %w", cleanupID, err)
+	}
+
+	return nil
+}
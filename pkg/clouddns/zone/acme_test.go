@@ -0,0 +1,150 @@
+package zone_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/anexia-it/go-anxcloud/pkg/client"
+	"github.com/anexia-it/go-anxcloud/pkg/clouddns/zone"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// recordStore serves the zone listing and the record endpoints of its zones. Unknown zones and records are
+// answered with 404.
+type recordStore struct {
+	t       *testing.T
+	mu      sync.Mutex
+	records map[string][]zone.Record
+}
+
+func newRecordStore(t *testing.T, zoneNames ...string) *recordStore {
+	s := &recordStore{t: t, records: map[string][]zone.Record{}}
+	for _, name := range zoneNames {
+		s.records[name] = []zone.Record{}
+	}
+
+	return s
+}
+
+func (s *recordStore) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/clouddns/v1/zone.json"), "/")
+	if r.Method == http.MethodGet && len(parts) == 1 {
+		zones := make([]zone.Zone, 0, len(s.records))
+		for name := range s.records {
+			zones = append(zones, zone.Zone{Definition: &zone.Definition{Name: name, ZoneName: name}})
+		}
+		assert.NoError(s.t, json.NewEncoder(w).Encode(map[string][]zone.Zone{"results": zones}))
+		return
+	}
+
+	var records []zone.Record
+	ok := len(parts) >= 3 && parts[2] == "records"
+	if ok {
+		records, ok = s.records[parts[1]]
+	}
+	switch {
+	case !ok:
+	case r.Method == http.MethodGet && len(parts) == 3:
+		assert.NoError(s.t, json.NewEncoder(w).Encode(records))
+		return
+	case r.Method == http.MethodPost && len(parts) == 3:
+		var request zone.RecordRequest
+		assert.NoError(s.t, json.NewDecoder(r.Body).Decode(&request))
+		s.add(parts[1], zone.Record{Name: request.Name, Type: request.Type, RData: request.RData, Region: request.Region,
+			TTL: &request.TTL})
+		assert.NoError(s.t, json.NewEncoder(w).Encode(zone.Zone{Definition: &zone.Definition{ZoneName: parts[1]}}))
+		return
+	case r.Method == http.MethodDelete && len(parts) == 4:
+		for i, record := range records {
+			if record.Identifier.String() == parts[3] {
+				s.records[parts[1]] = append(records[:i], records[i+1:]...)
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+		}
+	}
+
+	w.WriteHeader(http.StatusNotFound)
+	_, _ = w.Write([]byte(`{"error":{"code":404,"message":"not found"}}`))
+}
+
+func (s *recordStore) add(zoneName string, record zone.Record) {
+	record.Identifier = uuid.NewV4()
+	s.records[zoneName] = append(s.records[zoneName], record)
+}
+
+func TestChallengeRecordName(t *testing.T) {
+	for domain, expected := range map[string]string{
+		"example.com":          "_acme-challenge",
+		"Example.COM.":         "_acme-challenge",
+		"*.example.com":        "_acme-challenge",
+		"www.example.com":      "_acme-challenge.www",
+		"*.shop.example.com":   "_acme-challenge.shop",
+		"a.b.example.com.":     "_acme-challenge.a.b",
+		"www.sub.example.com":  "_acme-challenge.www.sub",
+		"_service.example.com": "_acme-challenge._service",
+	} {
+		name, err := zone.ChallengeRecordName("example.com", domain)
+		if assert.NoError(t, err, domain) {
+			assert.Equal(t, expected, name, domain)
+		}
+	}
+
+	for _, domain := range []string{"example.org", "notexample.com", "com"} {
+		_, err := zone.ChallengeRecordName("example.com", domain)
+		assert.True(t, errors.Is(err, zone.ErrDomainNotInZone), "expected ErrDomainNotInZone for %s but got %v", domain, err)
+	}
+}
+
+func TestChallengeValue(t *testing.T) {
+	assert.Equal(t, "61rBZ_4knHblO0MNoxFsXZ_eTFUHum0B6IVRbhvUn5I", zone.ChallengeValue("token.thumbprint"))
+}
+
+func TestPresentChallenge(t *testing.T) {
+	ctx := context.Background()
+	store := newRecordStore(t, "example.com")
+	store.add("example.com", zone.Record{Name: "_acme-challenge.www", Type: "TXT", RData: `"other"`})
+	c, server := client.NewTestClient(nil, store)
+	defer server.Close()
+	api := zone.NewAPI(c)
+
+	cleanupID, err := zone.PresentChallenge(ctx, api, "example.com", "*.www.example.com", "token.thumbprint")
+	if !assert.NoError(t, err) {
+		return
+	}
+	if !assert.Len(t, store.records["example.com"], 2) {
+		return
+	}
+	if record := store.records["example.com"][1]; assert.Equal(t, cleanupID, record.Identifier) {
+		assert.Equal(t, "_acme-challenge.www", record.Name)
+		assert.Equal(t, "TXT", record.Type)
+		assert.Equal(t, `"61rBZ_4knHblO0MNoxFsXZ_eTFUHum0B6IVRbhvUn5I"`, record.RData)
+		if assert.NotNil(t, record.TTL) {
+			assert.Equal(t, 60, *record.TTL)
+		}
+	}
+
+	assert.NoError(t, zone.CleanupChallenge(ctx, api, "example.com", cleanupID))
+	if assert.Len(t, store.records["example.com"], 1) {
+		assert.Equal(t, `"other"`, store.records["example.com"][0].RData, "only the challenge record must be deleted")
+	}
+
+	err = zone.CleanupChallenge(ctx, api, "example.com", cleanupID)
+	var responseErr *client.ResponseError
+	assert.True(t, errors.As(err, &responseErr), "expected ResponseError but got %v", err)
+
+	_, err = zone.PresentChallenge(ctx, api, "example.com", "www.example.org", "token.thumbprint")
+	assert.True(t, errors.Is(err, zone.ErrDomainNotInZone), "expected ErrDomainNotInZone but got %v", err)
+
+	_, err = zone.PresentChallenge(ctx, api, "example.org", "www.example.org", "token.thumbprint")
+	assert.True(t, errors.As(err, &responseErr), "expected ResponseError but got %v", err)
+}
@@ -1,10 +1,9 @@
-// Package zone implements API functions residing under /zone.
-// This path contains methods for managing IPs.
 package zone
 
 import (
 	"context"
 	"github.com/anexia-it/go-anxcloud/pkg/client"
+	"github.com/anexia-it/go-anxcloud/pkg/pagination"
 	uuid "github.com/satori/go.uuid"
 )
 
@@ -17,7 +16,13 @@ type API interface {
 	Delete(ctx context.Context, name string) error
 	Apply(ctx context.Context, name string, changeset ChangeSet) ([]Record, error)
 	Import(ctx context.Context, name string, zoneData Import) (Revision, error)
-	ListRecords(ctx context.Context, name string) ([]Record, error)
+	GetTransferConfig(ctx context.Context, name string) (TransferConfig, error)
+	SetTransferConfig(ctx context.Context, name string, config TransferConfig) (Zone, error)
+	ListRecords(ctx context.Context, name string, opts ...ListRecordsOption) ([]Record, error)
+	GetRecord(ctx context.Context, zone string, id uuid.UUID) (Record, error)
+	ListRecordsByType(ctx context.Context, zone string) (map[RecordType][]Record, error)
+	RecordPages(zone string) pagination.Pageable
+	CollectRecords(ctx context.Context, zone string, opts ...pagination.Option) ([]Record, error)
 	NewRecord(ctx context.Context, zone string, record RecordRequest) (Zone, error)
 	UpdateRecord(ctx context.Context, zone string, id uuid.UUID, record RecordRequest) (Zone, error)
 	DeleteRecord(ctx context.Context, zone string, id uuid.UUID) error
@@ -29,6 +34,7 @@ type api struct {
 	client client.Client
 }
 
+// NewAPI creates a new zone API instance with the given client.
 func NewAPI(c client.Client) API {
 	return &api{c}
 }
@@ -0,0 +1,51 @@
+package zone
+
+import (
+	"context"
+	"fmt"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// CreateRecord creates a record like NewRecord, but returns the created record including its identifier
+// and TTL instead of the zone.
+//
+// The API only answers with the zone, so the created record is found by comparing the records of the zone
+// before and after creating it. If the returned zone holds no records, the records are listed once more.
+func CreateRecord(ctx context.Context, a API, zone string, record RecordRequest) (Record, error) {
+	if err := record.Validate(); err != nil {
+		return Record{}, err
+	}
+
+	before, err := a.ListRecords(ctx, zone)
+	if err != nil {
+		return Record{}, fmt.Errorf("could not list records of zone '%s': %w", zone, err)
+	}
+	existing := make(map[uuid.UUID]bool, len(before))
+	for _, r := range before {
+		existing[r.Identifier] = true
+	}
+
+	changed, err := a.NewRecord(ctx, zone, record)
+	if err != nil {
+		return Record{}, err
+	}
+
+	var after []Record
+	if len(changed.Revisions) > 0 {
+		after = changed.Revisions[0].Records
+	}
+	if len(after) == 0 {
+		if after, err = a.ListRecords(ctx, zone); err != nil {
+			return Record{}, fmt.Errorf("could not list records of zone '%s': %w", zone, err)
+		}
+	}
+
+	for _, r := range after {
+		if !existing[r.Identifier] && sameRecord(r, record) {
+			return r, nil
+		}
+	}
+
+	return Record{}, fmt.Errorf("created %s record '%s' not found in zone '%s'", record.Type, record.Name, zone)
+}
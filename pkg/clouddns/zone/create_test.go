@@ -0,0 +1,39 @@
+package zone_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/anexia-it/go-anxcloud/pkg/clouddns/zone"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateRecord(t *testing.T) {
+	ctx := context.Background()
+	api := zone.NewInMemoryAPI()
+	_, err := api.Create(ctx, zone.Definition{ZoneName: "example.com"})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	first, err := zone.CreateRecord(ctx, api, "example.com", zone.RecordRequest{Name: "www", Type: "A", RData: "192.0.2.1", TTL: 300})
+	assert.NoError(t, err)
+	// An equal record is a separate record with its own identifier.
+	second, err := zone.CreateRecord(ctx, api, "example.com", zone.RecordRequest{Name: "www", Type: "A", RData: "192.000.002.001"})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.NotEqual(t, first.Identifier, second.Identifier)
+	if assert.NotNil(t, first.TTL) {
+		assert.Equal(t, 300, *first.TTL)
+	}
+
+	stored, err := api.GetRecord(ctx, "example.com", second.Identifier)
+	if assert.NoError(t, err) {
+		assert.Equal(t, second, stored)
+	}
+
+	_, err = zone.CreateRecord(ctx, api, "example.com", zone.RecordRequest{Name: "www", Type: "CNAME", RData: "example.org."})
+	assert.True(t, errors.Is(err, zone.ErrRecordConflict), "expected ErrRecordConflict but got %v", err)
+}
@@ -0,0 +1,60 @@
+package zone
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// DeleteRecordOption configures DeleteRecordByName.
+type DeleteRecordOption func(o *deleteRecordOptions)
+
+type deleteRecordOptions struct {
+	rdata    string
+	hasRData bool
+}
+
+// WithRData lets DeleteRecordByName only delete the records with the given rdata, e.g. a single address
+// of a round-robin set of A records. The rdata is compared as documented at NormalizeRData.
+func WithRData(rdata string) DeleteRecordOption {
+	return func(o *deleteRecordOptions) {
+		o.rdata, o.hasRData = rdata, true
+	}
+}
+
+// DeleteRecordByName deletes all records of zone with the given name and type and returns how many were deleted.
+//
+// The type is compared case-insensitively. If no record matches, an error wrapping ErrRecordNotFound is
+// returned, which idempotent callers can ignore. If deleting a record fails, the remaining ones are not
+// deleted and the number of records deleted so far is returned with the error.
+func DeleteRecordByName(ctx context.Context, a API, zone, name string, recordType RecordType, opts ...DeleteRecordOption) (int, error) {
+	o := deleteRecordOptions{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	records, err := a.ListRecords(ctx, zone)
+	if err != nil {
+		return 0, fmt.Errorf("could not list records of zone '%s': %w", zone, err)
+	}
+
+	deleted := 0
+	for _, record := range records {
+		if record.Name != name || !strings.EqualFold(record.Type, string(recordType)) {
+			continue
+		}
+		if o.hasRData && !sameRData(RecordType(strings.ToUpper(string(recordType))), record.RData, o.rdata) {
+			continue
+		}
+		if err := a.DeleteRecord(ctx, zone, record.Identifier); err != nil {
+			return deleted, fmt.Errorf("could not delete %s record '%s' in zone '%s': %w", record.Type, name, zone, err)
+		}
+		deleted++
+	}
+
+	if deleted == 0 {
+		return 0, fmt.Errorf("%w: %s record '%s' in zone '%s'", ErrRecordNotFound, recordType, name, zone)
+	}
+
+	return deleted, nil
+}
@@ -0,0 +1,42 @@
+package zone_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/anexia-it/go-anxcloud/pkg/clouddns/zone"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeleteRecordByName(t *testing.T) {
+	ctx := context.Background()
+	api := zone.NewInMemoryAPI()
+	_, err := api.Create(ctx, zone.Definition{ZoneName: "example.com"})
+	if !assert.NoError(t, err) {
+		return
+	}
+	for _, record := range []zone.RecordRequest{
+		{Name: "www", Type: "A", RData: "192.0.2.1"},
+		{Name: "www", Type: "A", RData: "192.0.2.2"},
+		{Name: "www", Type: "A", RData: "192.0.2.3"},
+		{Name: "www", Type: "AAAA", RData: "2001:db8::1"},
+		{Name: "mail", Type: "A", RData: "192.0.2.1"},
+	} {
+		_, err := api.NewRecord(ctx, "example.com", record)
+		assert.NoError(t, err)
+	}
+
+	deleted, err := zone.DeleteRecordByName(ctx, api, "example.com", "www", zone.TypeA, zone.WithRData("192.000.002.002"))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, deleted)
+
+	deleted, err = zone.DeleteRecordByName(ctx, api, "example.com", "www", "a")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, deleted)
+	assert.Equal(t, []string{"mail A 192.0.2.1 0", "www AAAA 2001:db8::1 0"}, recordSet(t, api))
+
+	deleted, err = zone.DeleteRecordByName(ctx, api, "example.com", "www", zone.TypeA)
+	assert.True(t, errors.Is(err, zone.ErrRecordNotFound), "expected ErrRecordNotFound but got %v", err)
+	assert.Equal(t, 0, deleted)
+}
@@ -0,0 +1,236 @@
+package zone
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	// DNSSECManaged is the DNSSEC mode of zones signed by the engine.
+	DNSSECManaged = "managed"
+	// DNSSECUnvalidated is the DNSSEC mode of zones not signed.
+	DNSSECUnvalidated = "unvalidated"
+
+	// TypeDNSKEY is a DNSSEC public key record, published by the engine for signed zones.
+	TypeDNSKEY RecordType = "DNSKEY"
+	// TypeCDS is a child copy of a delegation signer record, published by the engine for signed zones.
+	TypeCDS RecordType = "CDS"
+
+	// dnskeyFlagZone marks keys used to sign the zone.
+	dnskeyFlagZone = 1 << 8
+	// dnskeyFlagSEP marks key signing keys, whose DS records are published in the parent zone.
+	dnskeyFlagSEP = 1
+	// digestTypeSHA256 is the DS digest type of SHA-256 digests.
+	digestTypeSHA256 = 2
+)
+
+// ErrNoDNSKEY is raised if a zone has no DNSSEC key signing keys, e.g. because DNSSEC is not enabled
+// or the engine did not sign the zone yet.
+var ErrNoDNSKEY = errors.New("zone has no DNSSEC keys")
+
+// DSRecord is a delegation signer record to be published in the parent zone of a signed zone.
+type DSRecord struct {
+	KeyTag     uint16
+	Algorithm  uint8
+	DigestType uint8
+	// Digest of the key as upper case hex string.
+	Digest string
+}
+
+// String returns the rdata of the DS record, e.g. "20326 8 2 E06D44B8...".
+func (r DSRecord) String() string {
+	return fmt.Sprintf("%d %d %d %s", r.KeyTag, r.Algorithm, r.DigestType, r.Digest)
+}
+
+// DNSSECInfo describes the DNSSEC state of a zone.
+type DNSSECInfo struct {
+	// Mode is the DNSSEC mode of the zone, DNSSECManaged or DNSSECUnvalidated.
+	Mode string
+	// DSRecords of the zone, empty if the engine did not sign the zone yet.
+	DSRecords []DSRecord
+}
+
+// EnableDNSSEC lets the engine sign the given zone.
+//
+// The DS records are returned if the zone is signed already, signing happens asynchronously after
+// deployment, so they may be missing right after enabling DNSSEC. Use GetDSRecords to fetch them later.
+func EnableDNSSEC(ctx context.Context, a API, zone string) (DNSSECInfo, error) {
+	if err := setDNSSECMode(ctx, a, zone, DNSSECManaged); err != nil {
+		return DNSSECInfo{}, err
+	}
+
+	records, err := GetDSRecords(ctx, a, zone)
+	if err != nil && !errors.Is(err, ErrNoDNSKEY) {
+		return DNSSECInfo{}, err
+	}
+
+	return DNSSECInfo{Mode: DNSSECManaged, DSRecords: records}, nil
+}
+
+// DisableDNSSEC stops the engine from signing the given zone. The DS records have to be removed from the
+// parent zone first, otherwise resolvers validating DNSSEC will fail to resolve the zone.
+func DisableDNSSEC(ctx context.Context, a API, zone string) error {
+	return setDNSSECMode(ctx, a, zone, DNSSECUnvalidated)
+}
+
+func setDNSSECMode(ctx context.Context, a API, zone string, mode string) error {
+	current, err := a.Get(ctx, zone)
+	if err != nil {
+		return fmt.Errorf("could not get zone '%s': %w", zone, err)
+	}
+	if current.Definition == nil {
+		return fmt.Errorf("could not get zone '%s': response holds no zone definition", zone)
+	}
+	if current.DNSSecMode == mode {
+		return nil
+	}
+
+	definition := *current.Definition
+	definition.DNSSecMode = mode
+	if _, err := a.Update(ctx, zone, definition); err != nil {
+		return fmt.Errorf("could not set DNSSEC mode of zone '%s' to '%s': %w", zone, mode, err)
+	}
+
+	return nil
+}
+
+// GetDSRecords returns the DS records to publish in the parent zone of the given signed zone.
+//
+// The CDS records published by the engine are returned if there are any, otherwise SHA-256 DS records
+// are computed from the DNSKEY records of the key signing keys at the zone apex, or of all zone keys if
+// none is marked as key signing key. If the zone has neither, an error wrapping ErrNoDNSKEY is returned.
+func GetDSRecords(ctx context.Context, a API, zone string) ([]DSRecord, error) {
+	records, err := a.ListRecordsByType(ctx, zone)
+	if err != nil {
+		return nil, fmt.Errorf("could not list records of zone '%s': %w", zone, err)
+	}
+
+	var dsRecords []DSRecord
+	for _, record := range records[TypeCDS] {
+		if record.Name != "" {
+			continue
+		}
+		ds, err := parseDSRData(record.RData)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CDS record of zone '%s': %w", zone, err)
+		}
+		dsRecords = append(dsRecords, ds)
+	}
+	if len(dsRecords) > 0 {
+		return dsRecords, nil
+	}
+
+	var zoneKeys []DSRecord
+	for _, record := range records[TypeDNSKEY] {
+		if record.Name != "" {
+			continue
+		}
+		flags, algorithm, key, err := parseDNSKEYRData(record.RData)
+		if err != nil {
+			return nil, fmt.Errorf("invalid DNSKEY record of zone '%s': %w", zone, err)
+		}
+		switch {
+		case flags&dnskeyFlagSEP != 0:
+			dsRecords = append(dsRecords, dsRecordOf(zone, flags, algorithm, key))
+		case flags&dnskeyFlagZone != 0:
+			zoneKeys = append(zoneKeys, dsRecordOf(zone, flags, algorithm, key))
+		}
+	}
+
+	switch {
+	case len(dsRecords) > 0:
+		return dsRecords, nil
+	case len(zoneKeys) > 0:
+		return zoneKeys, nil
+	default:
+		return nil, fmt.Errorf("%w: '%s'", ErrNoDNSKEY, zone)
+	}
+}
+
+// parseDNSKEYRData parses DNSKEY rdata of the form "flags protocol algorithm base64-key".
+func parseDNSKEYRData(rdata string) (uint16, uint8, []byte, error) {
+	fields := strings.Fields(rdata)
+	if len(fields) < 4 {
+		return 0, 0, nil, fmt.Errorf("%w: DNSKEY rdata needs flags, protocol, algorithm and key", ErrInvalidRData)
+	}
+
+	flags, flagsErr := strconv.ParseUint(fields[0], 10, 16)
+	algorithm, algorithmErr := strconv.ParseUint(fields[2], 10, 8)
+	key, keyErr := base64.StdEncoding.DecodeString(strings.Join(fields[3:], ""))
+	if flagsErr != nil || fields[1] != "3" || algorithmErr != nil || keyErr != nil {
+		return 0, 0, nil, fmt.Errorf("%w: invalid DNSKEY rdata", ErrInvalidRData)
+	}
+
+	return uint16(flags), uint8(algorithm), key, nil
+}
+
+// parseDSRData parses DS rdata of the form "key-tag algorithm digest-type hex-digest".
+func parseDSRData(rdata string) (DSRecord, error) {
+	fields := strings.Fields(rdata)
+	if len(fields) < 4 {
+		return DSRecord{}, fmt.Errorf("%w: DS rdata needs key tag, algorithm, digest type and digest", ErrInvalidRData)
+	}
+
+	keyTag, keyTagErr := strconv.ParseUint(fields[0], 10, 16)
+	algorithm, algorithmErr := strconv.ParseUint(fields[1], 10, 8)
+	digestType, digestTypeErr := strconv.ParseUint(fields[2], 10, 8)
+	digest := strings.ToUpper(strings.Join(fields[3:], ""))
+	if _, err := hex.DecodeString(digest); err != nil || keyTagErr != nil || algorithmErr != nil || digestTypeErr != nil {
+		return DSRecord{}, fmt.Errorf("%w: invalid DS rdata", ErrInvalidRData)
+	}
+
+	return DSRecord{KeyTag: uint16(keyTag), Algorithm: uint8(algorithm), DigestType: uint8(digestType), Digest: digest}, nil
+}
+
+// dsRecordOf computes the SHA-256 DS record of a DNSKEY of the zone apex as defined in RFC 4034.
+func dsRecordOf(zone string, flags uint16, algorithm uint8, key []byte) DSRecord {
+	rdata := make([]byte, 4, 4+len(key))
+	binary.BigEndian.PutUint16(rdata, flags)
+	rdata[2], rdata[3] = 3, algorithm
+	rdata = append(rdata, key...)
+
+	digest := sha256.New()
+	_, _ = digest.Write(wireName(zone))
+	_, _ = digest.Write(rdata)
+
+	return DSRecord{
+		KeyTag:     keyTag(rdata),
+		Algorithm:  algorithm,
+		DigestType: digestTypeSHA256,
+		Digest:     strings.ToUpper(hex.EncodeToString(digest.Sum(nil))),
+	}
+}
+
+// keyTag computes the key tag of DNSKEY rdata in wire format as defined in RFC 4034, Appendix B.
+func keyTag(rdata []byte) uint16 {
+	var sum uint32
+	for i, b := range rdata {
+		if i&1 == 0 {
+			sum += uint32(b) << 8
+		} else {
+			sum += uint32(b)
+		}
+	}
+	sum += sum >> 16 & 0xFFFF
+
+	return uint16(sum)
+}
+
+// wireName returns the canonical wire format of the given domain name, lower case labels prefixed by their length.
+func wireName(name string) []byte {
+	var wire []byte
+	for _, label := range strings.Split(strings.ToLower(strings.Trim(name, ".")), ".") {
+		if label != "" {
+			wire = append(append(wire, byte(len(label))), label...)
+		}
+	}
+
+	return append(wire, 0)
+}
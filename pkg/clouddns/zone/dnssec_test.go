@@ -0,0 +1,56 @@
+package zone_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/anexia-it/go-anxcloud/pkg/clouddns/zone"
+	"github.com/stretchr/testify/assert"
+)
+
+// exampleKey is the DNSKEY of dskey.example.com. used in the SHA-256 DS example of RFC 4509.
+const exampleKey = "256 3 5 AQOeiiR0GOMYkDshWoSKz9XzfwJr1AYtsmx3TGkJaNXVbfi/2pHm822aJ5iI9BMzNXxeYCmZ" +
+	"DRD99WYwYqUSdjMmmAphXdvxegXd/M5+X7OrzKBaMbCVdFLUUh6DhweJBjEVv5f2wwjM9XzcnOf+EPbtG9DMBmADjFDc2w/rljwvFw=="
+
+func TestDNSSEC(t *testing.T) {
+	ctx := context.Background()
+	api := zone.NewInMemoryAPI()
+	_, err := api.Create(ctx, zone.Definition{ZoneName: "dskey.example.com", DNSSecMode: zone.DNSSECUnvalidated})
+	assert.NoError(t, err)
+
+	_, err = zone.GetDSRecords(ctx, api, "dskey.example.com")
+	assert.True(t, errors.Is(err, zone.ErrNoDNSKEY), "expected ErrNoDNSKEY but got %v", err)
+
+	info, err := zone.EnableDNSSEC(ctx, api, "dskey.example.com")
+	assert.NoError(t, err)
+	assert.EqualValues(t, zone.DNSSECManaged, info.Mode)
+	assert.Empty(t, info.DSRecords)
+	current, err := api.Get(ctx, "dskey.example.com")
+	assert.NoError(t, err)
+	assert.EqualValues(t, zone.DNSSECManaged, current.DNSSecMode)
+
+	_, err = api.NewRecord(ctx, "dskey.example.com", zone.RecordRequest{Type: string(zone.TypeDNSKEY), RData: exampleKey})
+	assert.NoError(t, err)
+	records, err := zone.GetDSRecords(ctx, api, "dskey.example.com")
+	assert.NoError(t, err)
+	assert.EqualValues(t, []zone.DSRecord{{
+		KeyTag:     60485,
+		Algorithm:  5,
+		DigestType: 2,
+		Digest:     "D4B7D520E7BB5F0F67674A0CCEB1E3E0614B93C4F9E99B8383F6A1E4469DA50A",
+	}}, records)
+
+	_, err = api.NewRecord(ctx, "dskey.example.com", zone.RecordRequest{Type: string(zone.TypeCDS), RData: "60485 5 2 d4b7d520"})
+	assert.NoError(t, err)
+	records, err = zone.GetDSRecords(ctx, api, "dskey.example.com")
+	assert.NoError(t, err)
+	if assert.Len(t, records, 1) {
+		assert.EqualValues(t, "60485 5 2 D4B7D520", records[0].String())
+	}
+
+	assert.NoError(t, zone.DisableDNSSEC(ctx, api, "dskey.example.com"))
+	current, err = api.Get(ctx, "dskey.example.com")
+	assert.NoError(t, err)
+	assert.EqualValues(t, zone.DNSSECUnvalidated, current.DNSSecMode)
+}
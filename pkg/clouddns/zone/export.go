@@ -0,0 +1,219 @@
+package zone
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+const (
+	// DefaultExportPageSize is the number of records fetched per page when exporting a zone
+	// whose record count is not known.
+	DefaultExportPageSize = 100
+	// MaxExportPageSize is the largest number of records the API returns per page.
+	MaxExportPageSize = 1000
+)
+
+type exportOptions struct {
+	pageSize int
+	progress func(exported, total int)
+	sorted   bool
+}
+
+// ExportOption is an optional parameter for StreamRecords and ExportZoneFile.
+type ExportOption func(o *exportOptions)
+
+// ExportPageSize overrides the page size chosen from the record count of the zone.
+func ExportPageSize(size int) ExportOption {
+	return func(o *exportOptions) {
+		o.pageSize = size
+	}
+}
+
+// ExportProgress calls f after every page with the number of records exported so far and the total
+// number of records reported by the API, which is 0 if the API does not report it.
+func ExportProgress(f func(exported, total int)) ExportOption {
+	return func(o *exportOptions) {
+		o.progress = f
+	}
+}
+
+// ExportSorted lets ExportZoneFile write the records sorted, so consecutive exports of a zone diff cleanly.
+// Records are ordered by name, the zone apex first, then by type with SOA and NS records first, then by rdata.
+// All records of the zone are held in memory to sort them.
+func ExportSorted() ExportOption {
+	return func(o *exportOptions) {
+		o.sorted = true
+	}
+}
+
+// exportPageSize chooses the page size for exporting the given number of records: all of them in
+// a single page if the API allows it, otherwise the maximum page size.
+func exportPageSize(count int) int {
+	switch {
+	case count <= 0:
+		return DefaultExportPageSize
+	case count > MaxExportPageSize:
+		return MaxExportPageSize
+	default:
+		return count
+	}
+}
+
+// StreamRecords walks the record pages of the given zone and calls f for every record, without holding
+// all records in memory. Iteration stops at the first error returned by f.
+//
+// The record count of the zone is requested once upfront to choose the page size, falling back to
+// DefaultExportPageSize if it is not available.
+func StreamRecords(ctx context.Context, a API, zone string, f func(record Record) error, opts ...ExportOption) error {
+	o := exportOptions{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	pages := a.RecordPages(zone)
+
+	if o.pageSize <= 0 {
+		count := 0
+		if probe, err := pages.GetPage(ctx, 1, 1); err == nil {
+			count = probe.TotalCount()
+		}
+		o.pageSize = exportPageSize(count)
+	}
+
+	page, err := pages.GetPage(ctx, 1, o.pageSize)
+	exported := 0
+	for {
+		if err != nil {
+			return fmt.Errorf("could not fetch records of zone '%s': %w", zone, err)
+		}
+
+		records, ok := page.Content().([]Record)
+		if !ok {
+			return fmt.Errorf("could not fetch records of zone '%s': unexpected page content %T", zone, page.Content())
+		}
+		for _, record := range records {
+			if err := f(record); err != nil {
+				return err
+			}
+		}
+
+		exported += len(records)
+		if o.progress != nil {
+			o.progress(exported, page.TotalCount())
+		}
+
+		if page.Num() >= page.Total() || len(records) == 0 {
+			return nil
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		page, err = pages.NextPage(ctx, page)
+	}
+}
+
+// ExportZoneFile writes the records of the given zone to w in zone file format, streaming them
+// page by page via StreamRecords. All records returned by the API are written, including the SOA and NS
+// records, the rdata of MX and SRV records with their priority, weight and port. With ExportSorted, the
+// records are written in a stable order.
+func ExportZoneFile(ctx context.Context, a API, zone string, w io.Writer, opts ...ExportOption) error {
+	buffered := bufio.NewWriter(w)
+	if _, err := fmt.Fprintf(buffered, "$ORIGIN %s.\n", strings.TrimSuffix(zone, ".")); err != nil {
+		return fmt.Errorf("could not write zone file: %w", err)
+	}
+
+	o := exportOptions{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	write := func(record Record) error {
+		if _, err := fmt.Fprintln(buffered, zoneFileLine(record)); err != nil {
+			return fmt.Errorf("could not write zone file: %w", err)
+		}
+
+		return nil
+	}
+
+	if !o.sorted {
+		if err := StreamRecords(ctx, a, zone, write, opts...); err != nil {
+			return err
+		}
+	} else {
+		var records []Record
+		err := StreamRecords(ctx, a, zone, func(record Record) error {
+			records = append(records, record)
+			return nil
+		}, opts...)
+		if err != nil {
+			return err
+		}
+
+		sortZoneFileRecords(records)
+		for _, record := range records {
+			if err := write(record); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := buffered.Flush(); err != nil {
+		return fmt.Errorf("could not write zone file: %w", err)
+	}
+
+	return nil
+}
+
+func zoneFileLine(record Record) string {
+	name := record.Name
+	if name == "" {
+		name = "@"
+	}
+
+	ttl := ""
+	if record.TTL != nil {
+		ttl = fmt.Sprintf("%d ", *record.TTL)
+	}
+
+	rdata := record.RData
+	if normalized, err := NormalizeRData(RecordType(strings.ToUpper(record.Type)), rdata); err == nil {
+		rdata = normalized
+	}
+
+	return fmt.Sprintf("%s\t%sIN\t%s\t%s", name, ttl, strings.ToUpper(record.Type), rdata)
+}
+
+// sortZoneFileRecords sorts records by name with the zone apex first, then by type with SOA and NS first,
+// then by rdata.
+func sortZoneFileRecords(records []Record) {
+	typeRank := func(recordType string) int {
+		switch RecordType(strings.ToUpper(recordType)) {
+		case TypeSOA:
+			return 0
+		case TypeNS:
+			return 1
+		default:
+			return 2
+		}
+	}
+
+	sort.SliceStable(records, func(i, j int) bool {
+		a, b := records[i], records[j]
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		if rankA, rankB := typeRank(a.Type), typeRank(b.Type); rankA != rankB {
+			return rankA < rankB
+		}
+		if typeA, typeB := strings.ToUpper(a.Type), strings.ToUpper(b.Type); typeA != typeB {
+			return typeA < typeB
+		}
+
+		return a.RData < b.RData
+	})
+}
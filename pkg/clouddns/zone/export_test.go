@@ -0,0 +1,97 @@
+package zone_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/anexia-it/go-anxcloud/pkg/clouddns/zone"
+	"github.com/stretchr/testify/assert"
+)
+
+func newExportZone(t *testing.T, records int) *zone.InMemoryAPI {
+	ctx := context.Background()
+	api := zone.NewInMemoryAPI()
+	_, err := api.Create(ctx, zone.Definition{ZoneName: "example.com"})
+	assert.NoError(t, err)
+	for i := 0; i < records; i++ {
+		_, err := api.NewRecord(ctx, "example.com", zone.RecordRequest{Name: fmt.Sprintf("host%d", i), Type: "A", RData: "192.0.2.1", TTL: 300})
+		assert.NoError(t, err)
+	}
+
+	return api
+}
+
+func TestStreamRecordsProgress(t *testing.T) {
+	t.Run("SinglePage", func(t *testing.T) {
+		api := newExportZone(t, 5)
+
+		var progress [][2]int
+		streamed := 0
+		err := zone.StreamRecords(context.Background(), api, "example.com", func(zone.Record) error {
+			streamed++
+			return nil
+		}, zone.ExportProgress(func(exported, total int) {
+			progress = append(progress, [2]int{exported, total})
+		}))
+		assert.NoError(t, err)
+		assert.Equal(t, 5, streamed)
+		assert.Equal(t, [][2]int{{5, 5}}, progress)
+	})
+
+	t.Run("ExplicitPageSize", func(t *testing.T) {
+		api := newExportZone(t, 5)
+
+		var progress [][2]int
+		err := zone.StreamRecords(context.Background(), api, "example.com", func(zone.Record) error { return nil },
+			zone.ExportPageSize(2),
+			zone.ExportProgress(func(exported, total int) {
+				progress = append(progress, [2]int{exported, total})
+			}))
+		assert.NoError(t, err)
+		assert.Equal(t, [][2]int{{2, 5}, {4, 5}, {5, 5}}, progress)
+	})
+}
+
+func TestExportZoneFile(t *testing.T) {
+	api := newExportZone(t, 1)
+
+	var buf bytes.Buffer
+	assert.NoError(t, zone.ExportZoneFile(context.Background(), api, "example.com", &buf))
+	assert.Equal(t, "$ORIGIN example.com.\nhost0\t300 IN\tA\t192.0.2.1\n", buf.String())
+}
+
+func TestExportZoneFileSorted(t *testing.T) {
+	ctx := context.Background()
+	api := zone.NewInMemoryAPI()
+	_, err := api.Create(ctx, zone.Definition{ZoneName: "example.com"})
+	assert.NoError(t, err)
+	for _, record := range []zone.RecordRequest{
+		{Name: "www", Type: "A", RData: "192.0.2.2", TTL: 300},
+		{Name: "", Type: "MX", RData: "mail.example.com.", Priority: 10, TTL: 3600},
+		{Name: "", Type: "A", RData: "192.0.2.1", TTL: 300},
+		{Name: "", Type: "NS", RData: "ns1.example.com.", TTL: 86400},
+		{Name: "", Type: "SOA", RData: "ns1.example.com. hostmaster.example.com. 1 3600 600 604800 300", TTL: 86400},
+		{Name: "_sip._tcp", Type: "SRV", RData: "10 5 5060 sip.example.com.", TTL: 300},
+	} {
+		_, err := api.NewRecord(ctx, "example.com", record)
+		assert.NoError(t, err)
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(t, zone.ExportZoneFile(ctx, api, "example.com", &buf, zone.ExportSorted()))
+	assert.Equal(t, "$ORIGIN example.com.\n"+
+		"@\t86400 IN\tSOA\tns1.example.com. hostmaster.example.com. 1 3600 600 604800 300\n"+
+		"@\t86400 IN\tNS\tns1.example.com.\n"+
+		"@\t300 IN\tA\t192.0.2.1\n"+
+		"@\t3600 IN\tMX\t10 mail.example.com.\n"+
+		"_sip._tcp\t300 IN\tSRV\t10 5 5060 sip.example.com.\n"+
+		"www\t300 IN\tA\t192.0.2.2\n", buf.String())
+
+	// The export parses back into the same records, apart from the SOA managed by the engine.
+	records, skipped, err := zone.ParseZoneFile("example.com", &buf)
+	assert.NoError(t, err)
+	assert.Len(t, records, 5)
+	assert.EqualValues(t, []zone.SkippedRecord{{Line: 2, Name: "", Type: "SOA"}}, skipped)
+}
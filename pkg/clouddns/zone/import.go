@@ -0,0 +1,339 @@
+package zone
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ErrZoneFileSyntax is raised if a zone file can not be parsed.
+var ErrZoneFileSyntax = errors.New("invalid zone file")
+
+// ZoneFileError is returned if an entry of a zone file is invalid.
+type ZoneFileError struct {
+	// Line the entry starts at, counting from 1.
+	Line int
+	Err  error
+}
+
+func (e *ZoneFileError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+func (e *ZoneFileError) Unwrap() error {
+	return e.Err
+}
+
+// SkippedRecord is a record of a zone file not imported, as its type is not supported by the engine.
+// SOA records are always skipped, the engine manages them itself.
+type SkippedRecord struct {
+	Line int
+	Name string
+	Type string
+}
+
+// importableTypes are the record types ImportZoneFile creates.
+var importableTypes = map[RecordType]bool{
+	TypeA: true, TypeAAAA: true, TypeCAA: true, TypeCNAME: true, TypeMX: true, TypeNS: true, TypePTR: true,
+	TypeSRV: true, TypeTXT: true,
+}
+
+// ImportOption is an optional parameter for ImportZoneFile.
+type ImportOption func(o *importOptions)
+
+type importOptions struct {
+	onSkipped func(record SkippedRecord)
+}
+
+// OnSkippedRecord lets ImportZoneFile call f for every record not imported because of its type.
+func OnSkippedRecord(f func(record SkippedRecord)) ImportOption {
+	return func(o *importOptions) {
+		o.onSkipped = f
+	}
+}
+
+// ImportZoneFile parses the BIND zone file read from r with ParseZoneFile and applies its records to zone
+// with ReplaceRecords, so importing the same file again changes nothing. Existing records not contained in
+// the file are kept.
+//
+// The zone file is parsed completely before any change is made, if it is invalid a *ZoneFileError is
+// returned naming the line of the invalid entry. Unlike Import, the records are sent one by one.
+func ImportZoneFile(ctx context.Context, a API, zone string, r io.Reader, opts ...ImportOption) (Zone, error) {
+	o := importOptions{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	records, skipped, err := ParseZoneFile(zone, r)
+	if err != nil {
+		return Zone{}, fmt.Errorf("could not import zone file into zone '%s': %w", zone, err)
+	}
+	if o.onSkipped != nil {
+		for _, record := range skipped {
+			o.onSkipped(record)
+		}
+	}
+
+	return ReplaceRecords(ctx, a, zone, records)
+}
+
+// ParseZoneFile parses the records of a BIND zone file of the given zone.
+//
+// The $ORIGIN and $TTL directives, parentheses spanning multiple lines, comments and owner names inherited
+// from the previous entry are supported, $INCLUDE and $GENERATE are not. Names are returned relative to
+// the zone, the rdata as written in the file. Records of types not supported by the engine are returned as
+// skipped. Invalid entries and records outside of the zone are reported as *ZoneFileError wrapping
+// ErrZoneFileSyntax or the validation error of the record.
+func ParseZoneFile(zone string, r io.Reader) ([]RecordRequest, []SkippedRecord, error) {
+	p := zoneFileParser{zone: strings.TrimSuffix(zone, "."), origin: strings.TrimSuffix(zone, ".")}
+
+	var records []RecordRequest
+	var skipped []SkippedRecord
+	var entry zoneFileEntry
+	depth := 0
+
+	scanner := bufio.NewScanner(r)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := scanner.Text()
+		if depth == 0 {
+			entry = zoneFileEntry{line: lineNum, inheritOwner: line != "" && unicode.IsSpace(rune(line[0]))}
+		}
+
+		tokens, newDepth, err := tokenizeZoneFileLine(line, depth)
+		if err != nil {
+			return nil, nil, &ZoneFileError{lineNum, err}
+		}
+		entry.tokens = append(entry.tokens, tokens...)
+		if depth = newDepth; depth > 0 || len(entry.tokens) == 0 {
+			continue
+		}
+
+		record, isRecord, err := p.parseEntry(entry)
+		if err != nil {
+			return nil, nil, &ZoneFileError{entry.line, err}
+		}
+		if !isRecord {
+			continue
+		}
+		if importableTypes[RecordType(record.Type)] {
+			records = append(records, record)
+		} else {
+			skipped = append(skipped, SkippedRecord{Line: entry.line, Name: record.Name, Type: record.Type})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, fmt.Errorf("could not read zone file: %w", err)
+	}
+	if depth > 0 {
+		return nil, nil, &ZoneFileError{entry.line, fmt.Errorf("%w: unclosed parenthesis", ErrZoneFileSyntax)}
+	}
+
+	return records, skipped, nil
+}
+
+type zoneFileEntry struct {
+	line         int
+	inheritOwner bool
+	tokens       []string
+}
+
+type zoneFileParser struct {
+	zone   string
+	origin string
+	ttl    int
+	owner  string
+}
+
+// parseEntry applies directives and returns the record of other entries, which is not validated for
+// types not importable.
+func (p *zoneFileParser) parseEntry(entry zoneFileEntry) (RecordRequest, bool, error) {
+	tokens := entry.tokens
+	if strings.HasPrefix(tokens[0], "$") {
+		return RecordRequest{}, false, p.parseDirective(tokens)
+	}
+
+	if !entry.inheritOwner {
+		p.owner = p.absolute(tokens[0])
+		tokens = tokens[1:]
+	} else if p.owner == "" {
+		return RecordRequest{}, false, fmt.Errorf("%w: entry without owner name", ErrZoneFileSyntax)
+	}
+
+	ttl := p.ttl
+	for i := 0; i < 2 && len(tokens) > 0; i++ {
+		if parsed, err := parseZoneFileTTL(tokens[0]); err == nil {
+			ttl = parsed
+		} else if !isZoneFileClass(tokens[0]) {
+			break
+		} else if !strings.EqualFold(tokens[0], "IN") {
+			return RecordRequest{}, false, fmt.Errorf("%w: class %s is not supported", ErrZoneFileSyntax, tokens[0])
+		}
+		tokens = tokens[1:]
+	}
+	if len(tokens) < 2 {
+		return RecordRequest{}, false, fmt.Errorf("%w: record needs a type and rdata", ErrZoneFileSyntax)
+	}
+
+	name, err := p.relative(p.owner)
+	if err != nil {
+		return RecordRequest{}, false, err
+	}
+	record := RecordRequest{
+		Name:  name,
+		Type:  strings.ToUpper(tokens[0]),
+		RData: strings.Join(tokens[1:], " "),
+		TTL:   ttl,
+	}
+	if importableTypes[RecordType(record.Type)] {
+		if err := record.Validate(); err != nil {
+			return RecordRequest{}, false, fmt.Errorf("invalid %s record '%s': %w", record.Type, record.Name, err)
+		}
+	}
+
+	return record, true, nil
+}
+
+func (p *zoneFileParser) parseDirective(tokens []string) error {
+	directive := strings.ToUpper(tokens[0])
+	if directive != "$ORIGIN" && directive != "$TTL" {
+		return fmt.Errorf("%w: directive %s is not supported", ErrZoneFileSyntax, tokens[0])
+	}
+	if len(tokens) != 2 {
+		return fmt.Errorf("%w: %s needs a single argument", ErrZoneFileSyntax, directive)
+	}
+
+	if directive == "$ORIGIN" {
+		p.origin = p.absolute(tokens[1])
+		return nil
+	}
+
+	ttl, err := parseZoneFileTTL(tokens[1])
+	if err != nil {
+		return err
+	}
+	p.ttl = ttl
+
+	return nil
+}
+
+// absolute returns the given name of the zone file qualified with the origin, without trailing dot.
+func (p *zoneFileParser) absolute(name string) string {
+	switch {
+	case name == "@":
+		return p.origin
+	case strings.HasSuffix(name, "."):
+		return strings.TrimSuffix(name, ".")
+	default:
+		return name + "." + p.origin
+	}
+}
+
+// relative returns the given absolute name relative to the zone, empty for the zone apex.
+func (p *zoneFileParser) relative(name string) (string, error) {
+	if strings.EqualFold(name, p.zone) {
+		return "", nil
+	}
+	suffix := "." + p.zone
+	if len(name) > len(suffix) && strings.EqualFold(name[len(name)-len(suffix):], suffix) {
+		return name[:len(name)-len(suffix)], nil
+	}
+
+	return "", fmt.Errorf("%w: name '%s' is outside of zone '%s'", ErrZoneFileSyntax, name, p.zone)
+}
+
+// parseZoneFileTTL parses a TTL given in seconds or with BIND units, e.g. "1h30m".
+func parseZoneFileTTL(value string) (int, error) {
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return seconds, nil
+	}
+
+	units := map[byte]int{'s': 1, 'm': 60, 'h': 3600, 'd': 86400, 'w': 604800}
+	ttl, number := 0, ""
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		if c >= '0' && c <= '9' {
+			number += string(c)
+			continue
+		}
+		unit, ok := units[byte(unicode.ToLower(rune(c)))]
+		if !ok || number == "" {
+			return 0, fmt.Errorf("%w: '%s' is no TTL", ErrZoneFileSyntax, value)
+		}
+		n, _ := strconv.Atoi(number)
+		ttl, number = ttl+n*unit, ""
+	}
+	if number != "" || ttl == 0 && value != "0" {
+		return 0, fmt.Errorf("%w: '%s' is no TTL", ErrZoneFileSyntax, value)
+	}
+
+	return ttl, nil
+}
+
+func isZoneFileClass(token string) bool {
+	switch strings.ToUpper(token) {
+	case "IN", "CH", "CS", "HS":
+		return true
+	default:
+		return false
+	}
+}
+
+// tokenizeZoneFileLine splits a line of a zone file into tokens, dropping comments and tracking the
+// depth of parentheses. Quoted strings are kept as single tokens including their quotes.
+func tokenizeZoneFileLine(line string, depth int) ([]string, int, error) {
+	var tokens []string
+	var token strings.Builder
+	inToken, quoted, escaped := false, false, false
+	flush := func() {
+		if inToken {
+			tokens = append(tokens, token.String())
+			token.Reset()
+			inToken = false
+		}
+	}
+
+scan:
+	for _, c := range line {
+		switch {
+		case escaped:
+			token.WriteRune(c)
+			escaped = false
+		case c == '\\':
+			token.WriteRune(c)
+			escaped, inToken = true, true
+		case quoted:
+			token.WriteRune(c)
+			quoted = c != '"'
+		case c == '"':
+			token.WriteRune(c)
+			quoted, inToken = true, true
+		case c == ';':
+			break scan
+		case c == '(':
+			flush()
+			depth++
+		case c == ')':
+			flush()
+			if depth == 0 {
+				return nil, 0, fmt.Errorf("%w: unexpected closing parenthesis", ErrZoneFileSyntax)
+			}
+			depth--
+		case unicode.IsSpace(c):
+			flush()
+		default:
+			token.WriteRune(c)
+			inToken = true
+		}
+	}
+	if quoted {
+		return nil, 0, fmt.Errorf("%w: unterminated quoted string", ErrZoneFileSyntax)
+	}
+	flush()
+
+	return tokens, depth, nil
+}
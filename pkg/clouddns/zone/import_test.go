@@ -0,0 +1,94 @@
+package zone_test
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/anexia-it/go-anxcloud/pkg/clouddns/zone"
+	"github.com/stretchr/testify/assert"
+)
+
+const testZoneFile = `$ORIGIN example.com.
+$TTL 1h
+@	IN	SOA	ns1.example.com. hostmaster.example.com. (
+		2023010101 ; serial
+		3600 600 604800 300 )
+@	300	IN	A	192.0.2.1
+	IN	MX	10 mail.example.com.
+www	IN	CNAME	example.com.
+mail.example.com.	IN 600	AAAA	2001:db8::1
+txt	TXT	"v=spf1 include:example.net -all" ; comment
+key	IN	SSHFP	1 1 123456789abcdef67890123456789abcdef67890
+$ORIGIN sub.example.com.
+host	A	192.0.2.2
+`
+
+func TestImportZoneFile(t *testing.T) {
+	ctx := context.Background()
+	api := zone.NewInMemoryAPI()
+	_, err := api.Create(ctx, zone.Definition{ZoneName: "example.com"})
+	assert.NoError(t, err)
+
+	var skipped []zone.SkippedRecord
+	_, err = zone.ImportZoneFile(ctx, api, "example.com", strings.NewReader(testZoneFile),
+		zone.OnSkippedRecord(func(record zone.SkippedRecord) {
+			skipped = append(skipped, record)
+		}))
+	assert.NoError(t, err)
+	assert.EqualValues(t, []zone.SkippedRecord{
+		{Line: 3, Name: "", Type: "SOA"},
+		{Line: 11, Name: "key", Type: "SSHFP"},
+	}, skipped)
+
+	records, err := api.ListRecords(ctx, "example.com")
+	assert.NoError(t, err)
+	var lines []string
+	for _, record := range records {
+		lines = append(lines, strings.Join([]string{record.Name, record.Type, record.RData}, " "))
+		if assert.NotNil(t, record.TTL) && record.Type != "A" && record.Type != "AAAA" {
+			assert.EqualValues(t, 3600, *record.TTL)
+		}
+	}
+	sort.Strings(lines)
+	assert.EqualValues(t, []string{
+		" A 192.0.2.1",
+		" MX 10 mail.example.com.",
+		"host.sub A 192.0.2.2",
+		"mail AAAA 2001:db8::1",
+		"txt TXT \"v=spf1 include:example.net -all\"",
+		"www CNAME example.com.",
+	}, lines)
+
+	// Importing the same file again changes nothing.
+	_, err = zone.ImportZoneFile(ctx, api, "example.com", strings.NewReader(testZoneFile))
+	assert.NoError(t, err)
+	again, err := api.ListRecords(ctx, "example.com")
+	assert.NoError(t, err)
+	assert.Len(t, again, len(records))
+}
+
+func TestParseZoneFileErrors(t *testing.T) {
+	for name, test := range map[string]struct {
+		file string
+		line int
+		err  error
+	}{
+		"UnknownDirective": {"$INCLUDE other.zone\n", 1, zone.ErrZoneFileSyntax},
+		"OutsideZone":      {"@ A 192.0.2.1\nexample.net. A 192.0.2.1\n", 2, zone.ErrZoneFileSyntax},
+		"MissingRData":     {"\n\nwww IN A\n", 3, zone.ErrZoneFileSyntax},
+		"Unclosed":         {"@ SOA ns1 hostmaster (\n1 2 3 4 5\n", 1, zone.ErrZoneFileSyntax},
+		"InvalidRData":     {"@ 300 IN A 192.0.2.1\nwww AAAA 192.0.2.1\n", 2, zone.ErrInvalidRData},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, _, err := zone.ParseZoneFile("example.com", strings.NewReader(test.file))
+			var fileErr *zone.ZoneFileError
+			if assert.True(t, errors.As(err, &fileErr), "expected ZoneFileError but got %v", err) {
+				assert.EqualValues(t, test.line, fileErr.Line)
+			}
+			assert.True(t, errors.Is(err, test.err), "expected %v but got %v", test.err, err)
+		})
+	}
+}
@@ -0,0 +1,562 @@
+package zone
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/anexia-it/go-anxcloud/pkg/pagination"
+	uuid "github.com/satori/go.uuid"
+)
+
+var (
+	// ErrZoneNotFound is raised by the in-memory API if the given zone does not exist.
+	ErrZoneNotFound = errors.New("zone not found")
+	// ErrZoneExists is raised by the in-memory API if a zone to be created already exists.
+	ErrZoneExists = errors.New("zone already exists")
+	// ErrRecordNotFound is raised by the in-memory API if the given record does not exist.
+	ErrRecordNotFound = errors.New("record not found")
+	// ErrRecordConflict is raised by the in-memory API if a record conflicts with a CNAME record of the same name.
+	ErrRecordConflict = errors.New("record conflicts with CNAME record")
+	// ErrStoreFull is raised by the in-memory API if creating a record would exceed its maximum number of records.
+	ErrStoreFull = errors.New("maximum number of records reached")
+	// ErrNotSupported is raised by the in-memory API for operations it can not emulate.
+	ErrNotSupported = errors.New("not supported by the in-memory API")
+)
+
+// InMemoryOption is an optional parameter for NewInMemoryAPI.
+type InMemoryOption func(a *InMemoryAPI)
+
+// InMemoryMaxRecords limits the total number of records over all zones stored by the in-memory API.
+func InMemoryMaxRecords(n int) InMemoryOption {
+	return func(a *InMemoryAPI) {
+		a.maxRecords = n
+	}
+}
+
+// InMemoryAPI is an implementation of API keeping zones and records in memory, meant for developing
+// and testing against the zone API without network access.
+//
+// Like the real API it assigns identifiers to records, returns the zone when records are changed and
+// rejects records conflicting with CNAME records of the same name. It is safe for concurrent use.
+// Importing zone files is not supported.
+type InMemoryAPI struct {
+	maxRecords int
+
+	mu    sync.Mutex
+	zones map[string]*memoryZone
+}
+
+type memoryZone struct {
+	definition Definition
+	records    []Record
+	createdAt  time.Time
+	updatedAt  time.Time
+	revision   uuid.UUID
+	serial     int
+}
+
+// NewInMemoryAPI creates an empty in-memory zone API.
+func NewInMemoryAPI(opts ...InMemoryOption) *InMemoryAPI {
+	a := &InMemoryAPI{zones: make(map[string]*memoryZone)}
+	for _, opt := range opts {
+		opt(a)
+	}
+
+	return a
+}
+
+// Reset removes all zones and records.
+func (a *InMemoryAPI) Reset() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.zones = make(map[string]*memoryZone)
+}
+
+// zone returns the zone with the given name. The caller has to hold the lock.
+func (a *InMemoryAPI) zone(name string) (*memoryZone, error) {
+	z, ok := a.zones[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: '%s'", ErrZoneNotFound, name)
+	}
+
+	return z, nil
+}
+
+func (z *memoryZone) toZone() Zone {
+	definition := z.definition
+	records := make([]Record, len(z.records))
+	copy(records, z.records)
+
+	return Zone{
+		Definition: &definition,
+		CreatedAt:  z.createdAt,
+		UpdatedAt:  z.updatedAt,
+		IsEditable: true,
+		Revisions: []Revision{{
+			CreatedAt:  z.updatedAt,
+			Identifier: z.revision,
+			ModifiedAt: z.updatedAt,
+			Records:    records,
+			Serial:     z.serial,
+			State:      "active",
+		}},
+	}
+}
+
+// changed starts a new revision of the zone.
+func (z *memoryZone) changed() {
+	z.updatedAt = time.Now().UTC()
+	z.revision = uuid.NewV4()
+	z.serial++
+}
+
+func (a *InMemoryAPI) recordCount() int {
+	count := 0
+	for _, z := range a.zones {
+		count += len(z.records)
+	}
+
+	return count
+}
+
+// List returns all zones ordered by name.
+func (a *InMemoryAPI) List(ctx context.Context) ([]Zone, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	zones := make([]Zone, 0, len(a.zones))
+	for _, z := range a.zones {
+		zones = append(zones, z.toZone())
+	}
+	sort.Slice(zones, func(i, j int) bool {
+		return zones[i].Name < zones[j].Name
+	})
+
+	return zones, nil
+}
+
+// Get returns the zone with the given name.
+func (a *InMemoryAPI) Get(ctx context.Context, name string) (Zone, error) {
+	if err := ctx.Err(); err != nil {
+		return Zone{}, err
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	z, err := a.zone(name)
+	if err != nil {
+		return Zone{}, err
+	}
+
+	return z.toZone(), nil
+}
+
+// Create creates a zone named after the ZoneName of the definition.
+func (a *InMemoryAPI) Create(ctx context.Context, create Definition) (Zone, error) {
+	if err := ctx.Err(); err != nil {
+		return Zone{}, err
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	name := create.ZoneName
+	if _, exists := a.zones[name]; exists {
+		return Zone{}, fmt.Errorf("%w: '%s'", ErrZoneExists, name)
+	}
+
+	create.Name = name
+	z := &memoryZone{definition: create, createdAt: time.Now().UTC()}
+	z.changed()
+	a.zones[name] = z
+
+	return z.toZone(), nil
+}
+
+// Update replaces the definition of the zone with the given name.
+func (a *InMemoryAPI) Update(ctx context.Context, name string, update Definition) (Zone, error) {
+	if err := ctx.Err(); err != nil {
+		return Zone{}, err
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	z, err := a.zone(name)
+	if err != nil {
+		return Zone{}, err
+	}
+
+	update.Name = name
+	update.ZoneName = name
+	z.definition = update
+	z.changed()
+
+	return z.toZone(), nil
+}
+
+// Delete deletes the zone with the given name including its records.
+func (a *InMemoryAPI) Delete(ctx context.Context, name string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if _, err := a.zone(name); err != nil {
+		return err
+	}
+	delete(a.zones, name)
+
+	return nil
+}
+
+// Apply deletes the records of the changeset matching by name, type, region and rdata and creates the
+// records to be created. It returns the created records. The changeset is applied completely or not at all.
+func (a *InMemoryAPI) Apply(ctx context.Context, name string, changeset ChangeSet) ([]Record, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	z, err := a.zone(name)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]Record, 0, len(z.records))
+	for _, record := range z.records {
+		deleted := false
+		for _, toDelete := range changeset.Delete {
+			if record.Name == toDelete.Name && strings.EqualFold(record.Type, toDelete.Type) &&
+				record.Region == toDelete.Region && sameRData(RecordType(record.Type), record.RData, toDelete.RData) {
+				deleted = true
+				break
+			}
+		}
+		if !deleted {
+			records = append(records, record)
+		}
+	}
+
+	created := make([]Record, 0, len(changeset.Create))
+	for _, toCreate := range changeset.Create {
+		record, err := newMemoryRecord(RecordRequest{
+			Name:   toCreate.Name,
+			Type:   toCreate.Type,
+			RData:  toCreate.RData,
+			Region: toCreate.Region,
+			TTL:    toCreate.TTL,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if err := checkConflicts(records, record); err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+		created = append(created, record)
+	}
+
+	if err := a.checkCapacity(len(records) - len(z.records)); err != nil {
+		return nil, err
+	}
+	z.records = records
+	z.changed()
+
+	return created, nil
+}
+
+// Import is not supported by the in-memory API and returns ErrNotSupported.
+func (a *InMemoryAPI) Import(context.Context, string, Import) (Revision, error) {
+	return Revision{}, fmt.Errorf("%w: importing zone files", ErrNotSupported)
+}
+
+// GetTransferConfig returns the transfer settings of the given zone.
+func (a *InMemoryAPI) GetTransferConfig(ctx context.Context, name string) (TransferConfig, error) {
+	zone, err := a.Get(ctx, name)
+	if err != nil {
+		return TransferConfig{}, err
+	}
+
+	return transferConfigOf(name, zone)
+}
+
+// SetTransferConfig validates and replaces the transfer settings of the given zone.
+func (a *InMemoryAPI) SetTransferConfig(ctx context.Context, name string, config TransferConfig) (Zone, error) {
+	if err := config.Validate(); err != nil {
+		return Zone{}, err
+	}
+
+	zone, err := a.Get(ctx, name)
+	if err != nil {
+		return Zone{}, err
+	}
+
+	definition, err := withTransferConfig(name, zone, config)
+	if err != nil {
+		return Zone{}, err
+	}
+
+	return a.Update(ctx, name, definition)
+}
+
+// ListRecords returns the records of the given zone in the order they were created.
+func (a *InMemoryAPI) ListRecords(ctx context.Context, name string, opts ...ListRecordsOption) ([]Record, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	z, err := a.zone(name)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]Record, len(z.records))
+	copy(records, z.records)
+
+	return newListRecordsOptions(opts).project(records), nil
+}
+
+// GetRecord returns the record with the given identifier.
+func (a *InMemoryAPI) GetRecord(ctx context.Context, zone string, id uuid.UUID) (Record, error) {
+	if err := ctx.Err(); err != nil {
+		return Record{}, err
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	z, err := a.zone(zone)
+	if err != nil {
+		return Record{}, err
+	}
+
+	index := z.recordIndex(id)
+	if index < 0 {
+		return Record{}, fmt.Errorf("%w: '%s' in zone '%s'", ErrRecordNotFound, id, zone)
+	}
+
+	return z.records[index], nil
+}
+
+// ListRecordsByType lists the records of a zone grouped by their type.
+func (a *InMemoryAPI) ListRecordsByType(ctx context.Context, zone string) (map[RecordType][]Record, error) {
+	records, err := a.ListRecords(ctx, zone)
+	if err != nil {
+		return nil, err
+	}
+
+	return groupByType(records), nil
+}
+
+// RecordPages returns the paged record listing of the given zone.
+func (a *InMemoryAPI) RecordPages(zone string) pagination.Pageable {
+	return memoryRecordPager{a, zone}
+}
+
+// CollectRecords walks all record pages of the given zone and returns the records ordered
+// by name, type and identifier.
+func (a *InMemoryAPI) CollectRecords(ctx context.Context, zone string, opts ...pagination.Option) ([]Record, error) {
+	return collectRecords(ctx, a.RecordPages(zone), zone, opts...)
+}
+
+// NewRecord creates a record in the given zone and returns the zone.
+//
+// The record is rejected with ErrRecordConflict if it conflicts with a CNAME record, and with
+// an AlreadyExistsError if EnsureUnique is set and an equal record exists.
+func (a *InMemoryAPI) NewRecord(ctx context.Context, zone string, record RecordRequest) (Zone, error) {
+	if err := ctx.Err(); err != nil {
+		return Zone{}, err
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	z, err := a.zone(zone)
+	if err != nil {
+		return Zone{}, err
+	}
+
+	created, err := newMemoryRecord(record)
+	if err != nil {
+		return Zone{}, err
+	}
+
+	if record.EnsureUnique {
+		for _, existing := range z.records {
+			if existing.Name == created.Name && strings.EqualFold(existing.Type, created.Type) &&
+				sameRData(RecordType(created.Type), existing.RData, created.RData) {
+				return Zone{}, &AlreadyExistsError{Record: existing}
+			}
+		}
+	}
+	if err := checkConflicts(z.records, created); err != nil {
+		return Zone{}, err
+	}
+	if err := a.checkCapacity(1); err != nil {
+		return Zone{}, err
+	}
+
+	z.records = append(z.records, created)
+	z.changed()
+
+	return z.toZone(), nil
+}
+
+// UpdateRecord replaces the record with the given identifier and returns the zone.
+func (a *InMemoryAPI) UpdateRecord(ctx context.Context, zone string, id uuid.UUID, record RecordRequest) (Zone, error) {
+	if err := ctx.Err(); err != nil {
+		return Zone{}, err
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	z, err := a.zone(zone)
+	if err != nil {
+		return Zone{}, err
+	}
+
+	index := z.recordIndex(id)
+	if index < 0 {
+		return Zone{}, fmt.Errorf("%w: '%s' in zone '%s'", ErrRecordNotFound, id, zone)
+	}
+
+	updated, err := newMemoryRecord(record)
+	if err != nil {
+		return Zone{}, err
+	}
+	updated.Identifier = id
+
+	others := make([]Record, 0, len(z.records)-1)
+	others = append(others, z.records[:index]...)
+	others = append(others, z.records[index+1:]...)
+	if err := checkConflicts(others, updated); err != nil {
+		return Zone{}, err
+	}
+
+	z.records[index] = updated
+	z.changed()
+
+	return z.toZone(), nil
+}
+
+// DeleteRecord deletes the record with the given identifier.
+func (a *InMemoryAPI) DeleteRecord(ctx context.Context, zone string, id uuid.UUID) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	z, err := a.zone(zone)
+	if err != nil {
+		return err
+	}
+
+	index := z.recordIndex(id)
+	if index < 0 {
+		return fmt.Errorf("%w: '%s' in zone '%s'", ErrRecordNotFound, id, zone)
+	}
+	z.records = append(z.records[:index], z.records[index+1:]...)
+	z.changed()
+
+	return nil
+}
+
+func (z *memoryZone) recordIndex(id uuid.UUID) int {
+	for i, record := range z.records {
+		if uuid.Equal(record.Identifier, id) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func (a *InMemoryAPI) checkCapacity(added int) error {
+	if a.maxRecords > 0 && added > 0 && a.recordCount()+added > a.maxRecords {
+		return fmt.Errorf("%w: %d", ErrStoreFull, a.maxRecords)
+	}
+
+	return nil
+}
+
+func newMemoryRecord(request RecordRequest) (Record, error) {
+	if err := request.Validate(); err != nil {
+		return Record{}, err
+	}
+
+	record := Record{
+		Identifier: uuid.NewV4(),
+		Name:       request.Name,
+		RData:      request.RData,
+		Region:     request.Region,
+		Type:       strings.ToUpper(request.Type),
+	}
+	if request.TTL > 0 {
+		ttl := request.TTL
+		record.TTL = &ttl
+	}
+
+	return record, nil
+}
+
+// checkConflicts rejects CNAME records sharing their name with other records and records sharing their name
+// with a CNAME record.
+func checkConflicts(records []Record, record Record) error {
+	for _, existing := range records {
+		if existing.Name != record.Name {
+			continue
+		}
+		if existing.Type == string(TypeCNAME) || record.Type == string(TypeCNAME) {
+			return fmt.Errorf("%w: %s record '%s'", ErrRecordConflict, existing.Type, existing.Name)
+		}
+	}
+
+	return nil
+}
+
+type memoryRecordPager struct {
+	api  *InMemoryAPI
+	zone string
+}
+
+func (p memoryRecordPager) GetPage(ctx context.Context, page, limit int) (pagination.Page, error) {
+	if page < 1 || limit < 1 {
+		return nil, fmt.Errorf("%w: page %d with limit %d", pagination.ErrInvalidPage, page, limit)
+	}
+
+	records, err := p.api.ListRecords(ctx, p.zone)
+	if err != nil {
+		return nil, err
+	}
+
+	start, end := (page-1)*limit, page*limit
+	if start > len(records) {
+		start = len(records)
+	}
+	if end > len(records) {
+		end = len(records)
+	}
+
+	return RecordPage{
+		Page:         page,
+		Limit:        limit,
+		TotalPages:   (len(records) + limit - 1) / limit,
+		TotalResults: len(records),
+		Results:      records[start:end],
+	}, nil
+}
+
+func (p memoryRecordPager) NextPage(ctx context.Context, page pagination.Page) (pagination.Page, error) {
+	return p.GetPage(ctx, page.Num()+1, page.Size())
+}
@@ -0,0 +1,95 @@
+package zone_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/anexia-it/go-anxcloud/pkg/clouddns/zone"
+	"github.com/anexia-it/go-anxcloud/pkg/pagination"
+	"github.com/stretchr/testify/assert"
+)
+
+var _ zone.API = (*zone.InMemoryAPI)(nil)
+
+func TestInMemoryAPI(t *testing.T) {
+	ctx := context.Background()
+	api := zone.NewInMemoryAPI(zone.InMemoryMaxRecords(3))
+
+	_, err := api.Create(ctx, zone.Definition{ZoneName: "example.com", AdminEmail: "admin@example.com"})
+	assert.NoError(t, err)
+	_, err = api.Create(ctx, zone.Definition{ZoneName: "example.com"})
+	assert.True(t, errors.Is(err, zone.ErrZoneExists), "expected ErrZoneExists but got %v", err)
+
+	created, err := api.NewRecord(ctx, "example.com", zone.RecordRequest{Name: "www", Type: "A", RData: "192.0.2.1", TTL: 300})
+	if !assert.NoError(t, err) || !assert.Len(t, created.Revisions, 1) || !assert.Len(t, created.Revisions[0].Records, 1) {
+		return
+	}
+	record := created.Revisions[0].Records[0]
+	assert.NotEqual(t, "00000000-0000-0000-0000-000000000000", record.Identifier.String())
+
+	_, err = api.NewRecord(ctx, "example.com", zone.RecordRequest{Name: "www", Type: "CNAME", RData: "example.org."})
+	assert.True(t, errors.Is(err, zone.ErrRecordConflict), "expected ErrRecordConflict but got %v", err)
+
+	_, err = api.NewRecord(ctx, "example.com", zone.RecordRequest{Name: "www", Type: "A", RData: "192.000.002.001", EnsureUnique: true})
+	var exists *zone.AlreadyExistsError
+	assert.True(t, errors.As(err, &exists), "expected AlreadyExistsError but got %v", err)
+
+	_, err = api.UpdateRecord(ctx, "example.com", record.Identifier, zone.RecordRequest{Name: "www", Type: "A", RData: "192.0.2.2"})
+	assert.NoError(t, err)
+
+	for _, name := range []string{"a", "b"} {
+		_, err = api.NewRecord(ctx, "example.com", zone.RecordRequest{Name: name, Type: "TXT", RData: name})
+		assert.NoError(t, err)
+	}
+	_, err = api.NewRecord(ctx, "example.com", zone.RecordRequest{Name: "c", Type: "TXT", RData: "c"})
+	assert.True(t, errors.Is(err, zone.ErrStoreFull), "expected ErrStoreFull but got %v", err)
+
+	records, err := api.CollectRecords(ctx, "example.com")
+	if assert.NoError(t, err) && assert.Len(t, records, 3) {
+		assert.EqualValues(t, "192.0.2.2", records[2].RData)
+	}
+
+	assert.NoError(t, api.DeleteRecord(ctx, "example.com", record.Identifier))
+	err = api.DeleteRecord(ctx, "example.com", record.Identifier)
+	assert.True(t, errors.Is(err, zone.ErrRecordNotFound), "expected ErrRecordNotFound but got %v", err)
+
+	api.Reset()
+	_, err = api.Get(ctx, "example.com")
+	assert.True(t, errors.Is(err, zone.ErrZoneNotFound), "expected ErrZoneNotFound but got %v", err)
+}
+
+func TestInMemoryAPIConcurrent(t *testing.T) {
+	ctx := context.Background()
+	api := zone.NewInMemoryAPI()
+	_, err := api.Create(ctx, zone.Definition{ZoneName: "example.com"})
+	assert.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := api.NewRecord(ctx, "example.com", zone.RecordRequest{Name: "txt", Type: "TXT", RData: "value"})
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	records, err := api.ListRecords(ctx, "example.com")
+	assert.NoError(t, err)
+	assert.Len(t, records, 20)
+}
+
+func TestInMemoryRecordPagesBounds(t *testing.T) {
+	ctx := context.Background()
+	api := zone.NewInMemoryAPI()
+	_, err := api.Create(ctx, zone.Definition{ZoneName: "example.com"})
+	assert.NoError(t, err)
+
+	for _, bounds := range [][2]int{{0, 10}, {1, 0}, {-1, 10}, {1, -5}} {
+		_, err := api.RecordPages("example.com").GetPage(ctx, bounds[0], bounds[1])
+		assert.True(t, errors.Is(err, pagination.ErrInvalidPage), "expected ErrInvalidPage for %v but got %v", bounds, err)
+	}
+}
@@ -0,0 +1,129 @@
+package zone
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"strings"
+	"sync"
+
+	"github.com/anexia-it/go-anxcloud/pkg/client"
+)
+
+// findRecordsConcurrency is the maximum number of zones whose records are listed concurrently by FindRecordsForIPs.
+const findRecordsConcurrency = 4
+
+// FindRecordsForIPs searches all zones for records pointing at the given addresses, e.g. to find the
+// records left dangling when a VM is deleted.
+//
+// ctx is attached to all requests and will cancel them on cancelation.
+//
+// Found are A and AAAA records with one of the addresses as rdata and PTR records named after the reverse
+// name of one of the addresses. Addresses without records are not contained in the result.
+func FindRecordsForIPs(ctx context.Context, c client.Client, ips []netip.Addr) (map[netip.Addr][]Record, error) {
+	a := NewAPI(c)
+	zones, err := a.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not list zones: %w", err)
+	}
+
+	byReverseName := make(map[string]netip.Addr, len(ips))
+	wanted := make(map[netip.Addr]struct{}, len(ips))
+	for _, ip := range ips {
+		ip = ip.Unmap()
+		wanted[ip] = struct{}{}
+		byReverseName[ReverseName(ip)] = ip
+	}
+
+	var mu sync.Mutex
+	var firstErr error
+	found := make(map[netip.Addr][]Record)
+	slots := make(chan struct{}, findRecordsConcurrency)
+	wg := sync.WaitGroup{}
+	for _, z := range zones {
+		if z.Definition == nil {
+			continue
+		}
+
+		wg.Add(1)
+		slots <- struct{}{}
+		go func(zoneName string) {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			records, err := a.ListRecords(ctx, zoneName)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = fmt.Errorf("could not list records of zone '%s': %w", zoneName, err)
+				}
+				return
+			}
+
+			for _, record := range records {
+				if ip, ok := recordIP(record, zoneName, wanted, byReverseName); ok {
+					found[ip] = append(found[ip], record)
+				}
+			}
+		}(z.Name)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	return found, nil
+}
+
+// recordIP returns the wanted address the record points at.
+func recordIP(record Record, zoneName string, wanted map[netip.Addr]struct{}, byReverseName map[string]netip.Addr) (netip.Addr, bool) {
+	switch RecordType(strings.ToUpper(record.Type)) {
+	case TypeA, TypeAAAA:
+		ip, err := netip.ParseAddr(strings.TrimSpace(record.RData))
+		if err != nil {
+			return netip.Addr{}, false
+		}
+		_, ok := wanted[ip.Unmap()]
+
+		return ip.Unmap(), ok
+	case TypePTR:
+		ip, ok := byReverseName[recordFQDN(record.Name, zoneName)]
+		return ip, ok
+	default:
+		return netip.Addr{}, false
+	}
+}
+
+// recordFQDN returns the fully qualified name of a record in the given zone, without trailing dot and in lower case.
+func recordFQDN(name, zoneName string) string {
+	zoneName = strings.ToLower(strings.TrimSuffix(zoneName, "."))
+	name = strings.ToLower(name)
+	switch {
+	case name == "" || name == "@":
+		return zoneName
+	case strings.HasSuffix(name, "."):
+		return strings.TrimSuffix(name, ".")
+	default:
+		return name + "." + zoneName
+	}
+}
+
+// ReverseName returns the name of the PTR record of the given address, without trailing dot,
+// e.g. "1.2.0.192.in-addr.arpa" for 192.0.2.1.
+func ReverseName(ip netip.Addr) string {
+	ip = ip.Unmap()
+	if ip.Is4() {
+		octets := ip.As4()
+		return fmt.Sprintf("%d.%d.%d.%d.in-addr.arpa", octets[3], octets[2], octets[1], octets[0])
+	}
+
+	bytes := ip.As16()
+	nibbles := make([]string, 0, 32)
+	for i := len(bytes) - 1; i >= 0; i-- {
+		nibbles = append(nibbles, fmt.Sprintf("%x", bytes[i]&0x0f), fmt.Sprintf("%x", bytes[i]>>4))
+	}
+
+	return strings.Join(nibbles, ".") + ".ip6.arpa"
+}
@@ -0,0 +1,94 @@
+package zone_test
+
+import (
+	"context"
+	"net/http"
+	"net/netip"
+	"strings"
+	"testing"
+
+	"github.com/anexia-it/go-anxcloud/pkg/client"
+	"github.com/anexia-it/go-anxcloud/pkg/clouddns/zone"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReverseName(t *testing.T) {
+	assert.Equal(t, "1.2.0.192.in-addr.arpa", zone.ReverseName(netip.MustParseAddr("192.0.2.1")))
+	assert.Equal(t, "1.2.0.192.in-addr.arpa", zone.ReverseName(netip.MustParseAddr("::ffff:192.0.2.1")))
+	assert.Equal(t, "1.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa",
+		zone.ReverseName(netip.MustParseAddr("2001:db8::1")))
+}
+
+func TestFindRecordsForIPs(t *testing.T) {
+	ctx := context.Background()
+	v6 := netip.MustParseAddr("2001:db8::1")
+	records := map[string][]zone.Record{
+		"example.com": {
+			{Name: "www", Type: "A", RData: "192.0.2.1"},
+			{Name: "mail", Type: "A", RData: "192.0.2.2"},
+			{Name: "www", Type: "AAAA", RData: "2001:db8::1"},
+			{Name: "other", Type: "A", RData: "192.0.2.3"},
+			{Name: "@", Type: "TXT", RData: `"192.0.2.1"`},
+		},
+		"2.0.192.in-addr.arpa": {
+			{Name: "1", Type: "PTR", RData: "www.example.com."},
+			{Name: "3", Type: "PTR", RData: "other.example.com."},
+		},
+		"8.b.d.0.1.0.0.2.ip6.arpa": {
+			{Name: strings.TrimSuffix(zone.ReverseName(v6), ".8.b.d.0.1.0.0.2.ip6.arpa"), Type: "PTR", RData: "www.example.com."},
+		},
+	}
+	store := newRecordStore(t)
+	for zoneName, zoneRecords := range records {
+		for _, record := range zoneRecords {
+			store.add(zoneName, record)
+		}
+	}
+	c, server := client.NewTestClient(nil, store)
+	defer server.Close()
+
+	wanted := []netip.Addr{
+		netip.MustParseAddr("192.0.2.1"),
+		netip.MustParseAddr("::ffff:192.0.2.2"),
+		v6,
+		netip.MustParseAddr("192.0.2.99"),
+	}
+	found, err := zone.FindRecordsForIPs(ctx, c, wanted)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Len(t, found, 3, "addresses without records must be left out")
+	assert.ElementsMatch(t, []string{"A www", "PTR 1"}, recordKeys(found[netip.MustParseAddr("192.0.2.1")]))
+	assert.ElementsMatch(t, []string{"A mail"}, recordKeys(found[netip.MustParseAddr("192.0.2.2")]), "mapped addresses must be unmapped")
+	if assert.Len(t, found[v6], 2) {
+		assert.ElementsMatch(t, []string{"AAAA", "PTR"}, []string{found[v6][0].Type, found[v6][1].Type})
+	}
+}
+
+func recordKeys(records []zone.Record) []string {
+	keys := make([]string, 0, len(records))
+	for _, record := range records {
+		keys = append(keys, record.Type+" "+record.Name)
+	}
+
+	return keys
+}
+
+func TestFindRecordsForIPsError(t *testing.T) {
+	c, server := client.NewTestClient(nil, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/clouddns/v1/zone.json" {
+			_, _ = w.Write([]byte(`{"results":[{"name":"example.com"},{"name":"example.org"}]}`))
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(`{"error":{"code":500,"message":"failed"}}`))
+	}))
+	defer server.Close()
+
+	found, err := zone.FindRecordsForIPs(context.Background(), c, []netip.Addr{netip.MustParseAddr("192.0.2.1")})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "could not list records of zone")
+	}
+	assert.Nil(t, found)
+}
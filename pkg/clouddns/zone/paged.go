@@ -0,0 +1,152 @@
+package zone
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/anexia-it/go-anxcloud/pkg/client"
+	"github.com/anexia-it/go-anxcloud/pkg/pagination"
+)
+
+// RecordPage is a single page of the record listing of a zone.
+type RecordPage struct {
+	Page         int      `json:"page"`
+	Limit        int      `json:"limit"`
+	TotalPages   int      `json:"total_pages"`
+	TotalResults int      `json:"total_results"`
+	Results      []Record `json:"results"`
+}
+
+// Num returns the number of this page.
+func (p RecordPage) Num() int {
+	return p.Page
+}
+
+// Size returns the maximum number of entries of this page.
+func (p RecordPage) Size() int {
+	return p.Limit
+}
+
+// Total returns the total number of pages.
+func (p RecordPage) Total() int {
+	return p.TotalPages
+}
+
+// TotalCount returns the total number of records over all pages.
+func (p RecordPage) TotalCount() int {
+	return p.TotalResults
+}
+
+// Content returns the records of this page as []Record.
+func (p RecordPage) Content() interface{} {
+	return p.Results
+}
+
+type recordPager struct {
+	api     api
+	zone    string
+	options listRecordsOptions
+}
+
+// RecordPages returns the paged record listing of the given zone.
+//
+// Its pages can be streamed with pagination.AsChan, which avoids holding all records of large zones in memory.
+func (a api) RecordPages(zone string) pagination.Pageable {
+	return recordPager{api: a, zone: zone}
+}
+
+func (r recordPager) GetPage(ctx context.Context, page, limit int) (pagination.Page, error) {
+	query := r.options.values()
+	query.Set("page", strconv.Itoa(page))
+	query.Set("limit", strconv.Itoa(limit))
+	url := fmt.Sprintf(
+		"%s%s/%s/records?%s",
+		r.api.client.BaseURL(),
+		pathPrefix,
+		r.zone,
+		query.Encode(),
+	)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not create record page request: %w", err)
+	}
+
+	httpResponse, err := r.api.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("could not execute record page request: %w", err)
+	}
+	if err := client.CheckResponse(httpResponse, "execute record page request"); err != nil {
+		return nil, err
+	}
+
+	var responsePayload RecordPage
+	err = json.NewDecoder(httpResponse.Body).Decode(&responsePayload)
+	_ = httpResponse.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("could not decode record page response: %w", err)
+	}
+
+	return responsePayload, nil
+}
+
+func (r recordPager) NextPage(ctx context.Context, page pagination.Page) (pagination.Page, error) {
+	return r.GetPage(ctx, page.Num()+1, page.Size())
+}
+
+// remainingRecords returns the records of page and all pages following it.
+func (r recordPager) remainingRecords(ctx context.Context, page RecordPage) ([]Record, error) {
+	records := page.Results
+	var current pagination.Page = page
+	for pagination.HasNext(current) {
+		var err error
+		if current, err = r.NextPage(ctx, current); err != nil {
+			return nil, err
+		}
+		records = append(records, current.(RecordPage).Results...)
+	}
+
+	return records, nil
+}
+
+// CollectRecords walks all record pages of the given zone and returns the records ordered
+// by name, type and identifier.
+//
+// The walk is not atomic, records created or deleted by others while walking the pages
+// shift the remaining ones between pages. The result is guaranteed not to contain a record twice,
+// as records are deduplicated by their identifier. Records created during the walk may or may not be
+// included, records deleted during the walk may still be included, and shifted records can be missed.
+// Pass pagination.RestartOnChanges to retry the walk until the record count stayed the same for all pages,
+// or pagination.DetectChanges to fail with pagination.ErrPaginationInconsistent instead.
+func (a api) CollectRecords(ctx context.Context, zone string, opts ...pagination.Option) ([]Record, error) {
+	return collectRecords(ctx, a.RecordPages(zone), zone, opts...)
+}
+
+func collectRecords(ctx context.Context, pages pagination.Pageable, zone string, opts ...pagination.Option) ([]Record, error) {
+	items, err := pagination.Collect(ctx, pages, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("could not list records of zone '%s': %w", zone, err)
+	}
+
+	records := make([]Record, 0, len(items))
+	for _, item := range items {
+		records = append(records, item.(Record))
+	}
+
+	sort.SliceStable(records, func(i, j int) bool {
+		if records[i].Name != records[j].Name {
+			return records[i].Name < records[j].Name
+		}
+		if records[i].Type != records[j].Type {
+			return records[i].Type < records[j].Type
+		}
+
+		return records[i].Identifier.String() < records[j].Identifier.String()
+	})
+
+	return records, nil
+}
@@ -0,0 +1,146 @@
+package zone_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/anexia-it/go-anxcloud/pkg/client"
+	"github.com/anexia-it/go-anxcloud/pkg/clouddns/zone"
+	"github.com/anexia-it/go-anxcloud/pkg/pagination"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// recordServer serves the records of a zone in pages. beforePage is called with the page number
+// before a page is served, which allows modifying the records between page fetches.
+type recordServer struct {
+	t          *testing.T
+	mu         sync.Mutex
+	records    []zone.Record
+	beforePage func(s *recordServer, page int)
+}
+
+func newRecordServer(t *testing.T, names ...string) *recordServer {
+	s := &recordServer{t: t}
+	for _, name := range names {
+		s.records = append(s.records, newRecord(name))
+	}
+
+	return s
+}
+
+func newRecord(name string) zone.Record {
+	return zone.Record{Identifier: uuid.NewV4(), Name: name, Type: string(zone.TypeA), RData: "192.0.2.1"}
+}
+
+func (s *recordServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Requests without page, as sent by ListRecords, are answered with the first page of two records.
+	page, limit := 1, 2
+	if r.URL.Query().Has("page") {
+		page, _ = strconv.Atoi(r.URL.Query().Get("page"))
+		limit, _ = strconv.Atoi(r.URL.Query().Get("limit"))
+	}
+	if s.beforePage != nil {
+		s.beforePage(s, page)
+	}
+
+	start, end := (page-1)*limit, page*limit
+	if start > len(s.records) {
+		start = len(s.records)
+	}
+	if end > len(s.records) {
+		end = len(s.records)
+	}
+
+	assert.NoError(s.t, json.NewEncoder(w).Encode(zone.RecordPage{
+		Page:         page,
+		Limit:        limit,
+		TotalPages:   (len(s.records) + limit - 1) / limit,
+		TotalResults: len(s.records),
+		Results:      s.records[start:end],
+	}))
+}
+
+// addBeforeSecondPage creates a record sorting before all others when the second page is requested the first time.
+func addBeforeSecondPage(added *zone.Record) func(s *recordServer, page int) {
+	return func(s *recordServer, page int) {
+		if page == 2 && added.Name == "" {
+			*added = newRecord("aaa")
+			s.records = append([]zone.Record{*added}, s.records...)
+		}
+	}
+}
+
+func names(records []zone.Record) []string {
+	result := make([]string, 0, len(records))
+	for _, record := range records {
+		result = append(result, record.Name)
+	}
+
+	return result
+}
+
+func TestCollectRecords(t *testing.T) {
+	t.Run("Ordered", func(t *testing.T) {
+		server := newRecordServer(t, "www", "mail", "ftp")
+		c, httpServer := client.NewTestClient(nil, server)
+		defer httpServer.Close()
+
+		records, err := zone.NewAPI(c).CollectRecords(context.Background(), "example.com", pagination.PageSize(2))
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"ftp", "mail", "www"}, names(records))
+	})
+
+	t.Run("RecordAddedBetweenPages", func(t *testing.T) {
+		server := newRecordServer(t, "a", "b", "c", "d")
+		var added zone.Record
+		server.beforePage = addBeforeSecondPage(&added)
+		c, httpServer := client.NewTestClient(nil, server)
+		defer httpServer.Close()
+
+		records, err := zone.NewAPI(c).CollectRecords(context.Background(), "example.com", pagination.PageSize(2))
+		assert.NoError(t, err)
+		// "b" is shifted onto the second page and served twice, but must only be returned once.
+		assert.Equal(t, []string{"a", "b", "c", "d"}, names(records))
+	})
+
+	t.Run("RecordAddedBetweenPagesWithRestart", func(t *testing.T) {
+		server := newRecordServer(t, "a", "b", "c", "d")
+		var added zone.Record
+		server.beforePage = addBeforeSecondPage(&added)
+		c, httpServer := client.NewTestClient(nil, server)
+		defer httpServer.Close()
+
+		records, err := zone.NewAPI(c).CollectRecords(context.Background(), "example.com", pagination.PageSize(2),
+			pagination.RestartOnChanges(1))
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"a", "aaa", "b", "c", "d"}, names(records))
+		assert.Contains(t, records, added)
+	})
+}
+
+func TestListRecordsPaged(t *testing.T) {
+	server := newRecordServer(t, "www", "mail", "ftp")
+	c, httpServer := client.NewTestClient(nil, server)
+	defer httpServer.Close()
+	api := zone.NewAPI(c)
+
+	records, err := api.ListRecords(context.Background(), "example.com")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"www", "mail", "ftp"}, names(records))
+
+	var streamed []zone.Record
+	items, cancel := pagination.AsChan(context.Background(), api.RecordPages("example.com"), pagination.PageSize(2))
+	defer cancel()
+	for item := range items {
+		streamed = append(streamed, item.(zone.Record))
+	}
+	assert.Equal(t, []string{"www", "mail", "ftp"}, names(streamed))
+}
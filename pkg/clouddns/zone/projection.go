@@ -0,0 +1,140 @@
+package zone
+
+import (
+	"net/url"
+	"strings"
+)
+
+// RecordField is a field of a record that can be selected with Fields.
+type RecordField string
+
+const (
+	FieldName      RecordField = "name"
+	FieldType      RecordField = "type"
+	FieldRData     RecordField = "rdata"
+	FieldRegion    RecordField = "region"
+	FieldTTL       RecordField = "ttl"
+	FieldImmutable RecordField = "immutable"
+)
+
+type listRecordsOptions struct {
+	fields []RecordField
+	types  []RecordType
+}
+
+// ListRecordsOption is an optional parameter for ListRecords.
+type ListRecordsOption func(o *listRecordsOptions)
+
+// Fields lets ListRecords request only the given fields of the records, reducing the size of the response.
+//
+// The Identifier is always present. All other fields not selected are left at their zero value,
+// even if the API returns them anyway.
+func Fields(fields ...RecordField) ListRecordsOption {
+	return func(o *listRecordsOptions) {
+		o.fields = append(o.fields, fields...)
+	}
+}
+
+// Types lets ListRecords return only records of the given types, compared case-insensitively.
+//
+// The filter is passed to the API and applied to the returned records as well, so it also takes effect
+// where the API ignores it. Empty types are ignored, passing no or only empty types returns all records.
+func Types(types ...RecordType) ListRecordsOption {
+	return func(o *listRecordsOptions) {
+		for _, recordType := range types {
+			if recordType = RecordType(strings.ToUpper(strings.TrimSpace(string(recordType)))); recordType != "" {
+				o.types = append(o.types, recordType)
+			}
+		}
+	}
+}
+
+func newListRecordsOptions(opts []ListRecordsOption) listRecordsOptions {
+	o := listRecordsOptions{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
+
+// query returns the query string selecting the fields and types, or an empty string if all records
+// are requested with all fields.
+func (o listRecordsOptions) query() string {
+	values := o.values()
+	if len(values) == 0 {
+		return ""
+	}
+
+	return "?" + values.Encode()
+}
+
+// values returns the query parameters selecting the fields and types.
+func (o listRecordsOptions) values() url.Values {
+	values := url.Values{}
+	if len(o.fields) > 0 {
+		fields := make([]string, 0, len(o.fields)+1)
+		fields = append(fields, "identifier")
+		for _, field := range o.fields {
+			fields = append(fields, string(field))
+		}
+		values.Set("fields", strings.Join(fields, ","))
+	}
+	if len(o.types) > 0 {
+		types := make([]string, 0, len(o.types))
+		for _, recordType := range o.types {
+			types = append(types, string(recordType))
+		}
+		values.Set("type", strings.Join(types, ","))
+	}
+
+	return values
+}
+
+// project drops the records not matching the selected types and clears all fields that were not selected.
+func (o listRecordsOptions) project(records []Record) []Record {
+	if len(o.types) > 0 {
+		filtered := make([]Record, 0, len(records))
+		for _, record := range records {
+			for _, recordType := range o.types {
+				if strings.EqualFold(strings.TrimSpace(record.Type), string(recordType)) {
+					filtered = append(filtered, record)
+					break
+				}
+			}
+		}
+		records = filtered
+	}
+	if len(o.fields) == 0 {
+		return records
+	}
+
+	selected := make(map[RecordField]bool, len(o.fields))
+	for _, field := range o.fields {
+		selected[field] = true
+	}
+
+	for i, record := range records {
+		records[i] = Record{Identifier: record.Identifier}
+		if selected[FieldName] {
+			records[i].Name = record.Name
+		}
+		if selected[FieldType] {
+			records[i].Type = record.Type
+		}
+		if selected[FieldRData] {
+			records[i].RData = record.RData
+		}
+		if selected[FieldRegion] {
+			records[i].Region = record.Region
+		}
+		if selected[FieldTTL] {
+			records[i].TTL = record.TTL
+		}
+		if selected[FieldImmutable] {
+			records[i].Immutable = record.Immutable
+		}
+	}
+
+	return records
+}
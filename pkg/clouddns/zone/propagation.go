@@ -0,0 +1,150 @@
+package zone
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"strings"
+	"time"
+)
+
+// ErrUnsupportedRecordType is raised if a record type can not be handled by the called function.
+var ErrUnsupportedRecordType = errors.New("unsupported record type")
+
+// propagationPollInterval is used by WaitForPropagation if no valid poll interval is given.
+const propagationPollInterval = 5 * time.Second
+
+// WaitForPropagation blocks until all given resolvers answer a query for fqdn with the expected value.
+//
+// This confirms that a record is resolvable by clients, which is more than the zone being deployed by
+// CloudDNS and is needed for example before completing an ACME DNS-01 challenge.
+// Queries are sent directly to each resolver using the resolver of the standard library, so no
+// local cache is involved. resolvers are addresses with an optional port, which defaults to 53.
+// Supported record types are A, AAAA, CNAME, MX, NS and TXT. For MX records, expected is the mail
+// exchange host without priority. A pollInterval of zero or less defaults to five seconds.
+//
+// ctx will be checked for cancellation and the method returns immediately if so.
+func WaitForPropagation(ctx context.Context, fqdn string, recordType RecordType, expected string, resolvers []string,
+	pollInterval time.Duration) error {
+	if len(resolvers) == 0 {
+		return errors.New("no resolvers given to check propagation")
+	}
+
+	if pollInterval <= 0 {
+		pollInterval = propagationPollInterval
+	}
+
+	pending := make(map[string]*net.Resolver, len(resolvers))
+	for _, address := range resolvers {
+		pending[address] = resolverFor(address)
+	}
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		for address, resolver := range pending {
+			values, err := lookup(ctx, resolver, fqdn, recordType)
+			if errors.Is(err, ErrUnsupportedRecordType) {
+				return err
+			}
+			if err == nil && containsValue(values, expected, recordType) {
+				delete(pending, address)
+			}
+		}
+
+		if len(pending) == 0 {
+			return nil
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			addresses := make([]string, 0, len(pending))
+			for address := range pending {
+				addresses = append(addresses, address)
+			}
+
+			return fmt.Errorf("%s record of '%s' did not propagate to %v in time: %w", recordType, fqdn,
+				addresses, ctx.Err())
+		}
+	}
+}
+
+// resolverFor creates a resolver sending all queries to the given address.
+func resolverFor(address string) *net.Resolver {
+	if _, _, err := net.SplitHostPort(address); err != nil {
+		address = net.JoinHostPort(address, "53")
+	}
+
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			dialer := net.Dialer{}
+			return dialer.DialContext(ctx, network, address)
+		},
+	}
+}
+
+func lookup(ctx context.Context, resolver *net.Resolver, fqdn string, recordType RecordType) ([]string, error) {
+	switch recordType {
+	case TypeA, TypeAAAA:
+		network := "ip4"
+		if recordType == TypeAAAA {
+			network = "ip6"
+		}
+		addresses, err := resolver.LookupNetIP(ctx, network, fqdn)
+		values := make([]string, 0, len(addresses))
+		for _, address := range addresses {
+			values = append(values, address.Unmap().String())
+		}
+
+		return values, err
+	case TypeCNAME:
+		cname, err := resolver.LookupCNAME(ctx, fqdn)
+		return []string{cname}, err
+	case TypeMX:
+		records, err := resolver.LookupMX(ctx, fqdn)
+		values := make([]string, 0, len(records))
+		for _, record := range records {
+			values = append(values, record.Host)
+		}
+
+		return values, err
+	case TypeNS:
+		records, err := resolver.LookupNS(ctx, fqdn)
+		values := make([]string, 0, len(records))
+		for _, record := range records {
+			values = append(values, record.Host)
+		}
+
+		return values, err
+	case TypeTXT:
+		return resolver.LookupTXT(ctx, fqdn)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedRecordType, recordType)
+	}
+}
+
+func containsValue(values []string, expected string, recordType RecordType) bool {
+	for _, value := range values {
+		switch recordType {
+		case TypeA, TypeAAAA:
+			expectedAddress, err := netip.ParseAddr(expected)
+			if err == nil && value == expectedAddress.Unmap().String() {
+				return true
+			}
+		case TypeTXT:
+			if value == strings.Trim(expected, `"`) {
+				return true
+			}
+		default:
+			if strings.EqualFold(strings.TrimSuffix(value, "."), strings.TrimSuffix(expected, ".")) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
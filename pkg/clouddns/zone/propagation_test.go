@@ -0,0 +1,155 @@
+package zone_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/anexia-it/go-anxcloud/pkg/clouddns/zone"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+type question struct {
+	name  string
+	qtype dnsmessage.Type
+}
+
+// stubResolver answers DNS queries over UDP from its records, unknown questions get an empty answer.
+type stubResolver struct {
+	t       *testing.T
+	conn    net.PacketConn
+	mu      sync.Mutex
+	records map[question][]dnsmessage.Resource
+}
+
+func newStubResolver(t *testing.T) *stubResolver {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("could not listen for DNS queries: %v", err)
+	}
+
+	s := &stubResolver{t: t, conn: conn, records: map[question][]dnsmessage.Resource{}}
+	go s.serve()
+
+	return s
+}
+
+func (s *stubResolver) Address() string {
+	return s.conn.LocalAddr().String()
+}
+
+func (s *stubResolver) Close() {
+	_ = s.conn.Close()
+}
+
+func (s *stubResolver) Set(name string, qtype dnsmessage.Type, bodies ...dnsmessage.ResourceBody) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	resources := make([]dnsmessage.Resource, 0, len(bodies))
+	for _, body := range bodies {
+		header := dnsmessage.ResourceHeader{Name: dnsmessage.MustNewName(name), Class: dnsmessage.ClassINET, TTL: 60}
+		resources = append(resources, dnsmessage.Resource{Header: header, Body: body})
+	}
+	s.records[question{name, qtype}] = resources
+}
+
+func (s *stubResolver) serve() {
+	buffer := make([]byte, 512)
+	for {
+		n, from, err := s.conn.ReadFrom(buffer)
+		if err != nil {
+			return
+		}
+
+		var parser dnsmessage.Parser
+		header, err := parser.Start(buffer[:n])
+		if err != nil {
+			continue
+		}
+		q, err := parser.Question()
+		if err != nil {
+			continue
+		}
+
+		s.mu.Lock()
+		answers := s.records[question{q.Name.String(), q.Type}]
+		s.mu.Unlock()
+
+		response := dnsmessage.Message{
+			Header:    dnsmessage.Header{ID: header.ID, Response: true, Authoritative: true, RecursionAvailable: true},
+			Questions: []dnsmessage.Question{q},
+			Answers:   answers,
+		}
+		packed, err := response.Pack()
+		if !assert.NoError(s.t, err) {
+			continue
+		}
+		_, _ = s.conn.WriteTo(packed, from)
+	}
+}
+
+func TestWaitForPropagation(t *testing.T) {
+	stub := newStubResolver(t)
+	defer stub.Close()
+
+	stub.Set("www.example.com.", dnsmessage.TypeA, &dnsmessage.AResource{A: [4]byte{192, 0, 2, 1}})
+	stub.Set("www.example.com.", dnsmessage.TypeAAAA, &dnsmessage.AAAAResource{AAAA: [16]byte{0x20, 0x01, 0x0d, 0xb8, 15: 1}})
+	stub.Set("example.com.", dnsmessage.TypeMX, &dnsmessage.MXResource{Pref: 10, MX: dnsmessage.MustNewName("mail.example.com.")})
+	stub.Set("example.com.", dnsmessage.TypeNS, &dnsmessage.NSResource{NS: dnsmessage.MustNewName("ns1.example.com.")})
+	stub.Set("_acme-challenge.example.com.", dnsmessage.TypeTXT, &dnsmessage.TXTResource{TXT: []string{"token"}})
+
+	for name, tc := range map[string]struct {
+		fqdn       string
+		recordType zone.RecordType
+		expected   string
+	}{
+		"A":              {"www.example.com.", zone.TypeA, "192.0.2.1"},
+		"AAAA":           {"www.example.com.", zone.TypeAAAA, "2001:DB8::1"},
+		"MX":             {"example.com.", zone.TypeMX, "Mail.Example.com"},
+		"NS":             {"example.com.", zone.TypeNS, "ns1.example.com."},
+		"TXTQuoted":      {"_acme-challenge.example.com.", zone.TypeTXT, `"token"`},
+		"TXTUnquoted":    {"_acme-challenge.example.com.", zone.TypeTXT, "token"},
+		"DefaultPolling": {"www.example.com.", zone.TypeA, "192.0.2.1"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			pollInterval := 10 * time.Millisecond
+			if name == "DefaultPolling" {
+				pollInterval = 0
+			}
+			err := zone.WaitForPropagation(ctx, tc.fqdn, tc.recordType, tc.expected, []string{stub.Address()}, pollInterval)
+			assert.NoError(t, err)
+		})
+	}
+
+	t.Run("Propagating", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		time.AfterFunc(50*time.Millisecond, func() {
+			stub.Set("new.example.com.", dnsmessage.TypeTXT, &dnsmessage.TXTResource{TXT: []string{"later"}})
+		})
+		err := zone.WaitForPropagation(ctx, "new.example.com.", zone.TypeTXT, "later", []string{stub.Address()}, 10*time.Millisecond)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Mismatch", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+		defer cancel()
+
+		err := zone.WaitForPropagation(ctx, "www.example.com.", zone.TypeA, "192.0.2.2", []string{stub.Address()}, 10*time.Millisecond)
+		assert.True(t, errors.Is(err, context.DeadlineExceeded), "expected DeadlineExceeded but got %v", err)
+	})
+
+	t.Run("Unsupported", func(t *testing.T) {
+		err := zone.WaitForPropagation(context.Background(), "example.com.", zone.TypeSRV, "x", []string{stub.Address()}, time.Second)
+		assert.True(t, errors.Is(err, zone.ErrUnsupportedRecordType), "expected ErrUnsupportedRecordType but got %v", err)
+	})
+}
@@ -0,0 +1,175 @@
+package zone
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidRData is raised if the rdata of a record is not valid for its type.
+var ErrInvalidRData = errors.New("invalid record data")
+
+// NormalizeRData returns the canonical form of the rdata of a record of the given type.
+//
+// IP addresses of A and AAAA records are canonicalized, e.g. leading zeros of IPv4 octets are removed
+// and IPv6 addresses are compressed, so equal addresses are represented by equal strings. If the rdata
+// is no IP address of the family of the record type, ErrInvalidRData is returned.
+//
+// MX rdata has to be "<priority> <target>", SRV rdata "<priority> <weight> <port> <target>", with numbers
+// between 0 and 65535. Its fields are joined by single spaces. The rdata of other record types is returned
+// unchanged.
+func NormalizeRData(recordType RecordType, rdata string) (string, error) {
+	switch RecordType(strings.ToUpper(string(recordType))) {
+	case TypeA:
+		addr, err := netip.ParseAddr(trimOctetZeros(strings.TrimSpace(rdata)))
+		if err != nil || !addr.Is4() {
+			return "", fmt.Errorf("%w: '%s' is no IPv4 address", ErrInvalidRData, rdata)
+		}
+
+		return addr.String(), nil
+	case TypeAAAA:
+		addr, err := netip.ParseAddr(strings.TrimSpace(rdata))
+		if err != nil || !addr.Is6() || addr.Zone() != "" {
+			return "", fmt.Errorf("%w: '%s' is no IPv6 address", ErrInvalidRData, rdata)
+		}
+
+		return addr.String(), nil
+	case TypeMX:
+		return normalizePriorityRData(TypeMX, rdata, 1)
+	case TypeSRV:
+		return normalizePriorityRData(TypeSRV, rdata, 3)
+	default:
+		return rdata, nil
+	}
+}
+
+// normalizePriorityRData checks that rdata consists of the given number of 16 bit numbers followed by a target.
+func normalizePriorityRData(recordType RecordType, rdata string, numbers int) (string, error) {
+	fields := strings.Fields(rdata)
+	if len(fields) != numbers+1 {
+		return "", fmt.Errorf("%w: %s rdata '%s' needs %d numbers and a target", ErrInvalidRData, recordType, rdata, numbers)
+	}
+	for _, field := range fields[:numbers] {
+		if _, err := strconv.ParseUint(field, 10, 16); err != nil {
+			return "", fmt.Errorf("%w: '%s' of %s rdata '%s' is no number between 0 and 65535", ErrInvalidRData, field,
+				recordType, rdata)
+		}
+	}
+
+	return strings.Join(fields, " "), nil
+}
+
+// PriorityRData holds the fields of the rdata of MX and SRV records. Weight and Port are only used by SRV records.
+type PriorityRData struct {
+	Priority int
+	Weight   int
+	Port     int
+	Target   string
+}
+
+// PriorityRData returns the fields of the rdata of an MX or SRV record.
+func (r Record) PriorityRData() (PriorityRData, error) {
+	recordType := RecordType(strings.ToUpper(r.Type))
+	if recordType != TypeMX && recordType != TypeSRV {
+		return PriorityRData{}, fmt.Errorf("%w: %s records have no priority", ErrInvalidRData, r.Type)
+	}
+	rdata, err := NormalizeRData(recordType, r.RData)
+	if err != nil {
+		return PriorityRData{}, err
+	}
+
+	fields := strings.Fields(rdata)
+	numbers := make([]int, len(fields)-1)
+	for i := range numbers {
+		numbers[i], _ = strconv.Atoi(fields[i])
+	}
+	data := PriorityRData{Priority: numbers[0], Target: fields[len(fields)-1]}
+	if recordType == TypeSRV {
+		data.Weight, data.Port = numbers[1], numbers[2]
+	}
+
+	return data, nil
+}
+
+// packPriorityRData prepends the Priority, Weight and Port of MX and SRV requests to rdata only holding the target.
+// If the rdata holds the numbers already, the fields have to be either zero or equal to them.
+func (r *RecordRequest) packPriorityRData() error {
+	recordType := RecordType(strings.ToUpper(r.Type))
+	numbers := []int{r.Priority}
+	switch recordType {
+	case TypeMX:
+		if r.Weight != 0 || r.Port != 0 {
+			return fmt.Errorf("%w: MX records have no weight and port", ErrInvalidRData)
+		}
+	case TypeSRV:
+		numbers = append(numbers, r.Weight, r.Port)
+	default:
+		if r.Priority != 0 || r.Weight != 0 || r.Port != 0 {
+			return fmt.Errorf("%w: %s records have no priority, weight and port", ErrInvalidRData, r.Type)
+		}
+		return nil
+	}
+
+	fields := strings.Fields(r.RData)
+	if len(fields) == 1 {
+		packed := make([]string, 0, len(numbers)+1)
+		for _, number := range numbers {
+			packed = append(packed, strconv.Itoa(number))
+		}
+		r.RData = strings.Join(append(packed, fields[0]), " ")
+		return nil
+	}
+
+	if len(fields) == len(numbers)+1 {
+		for i, number := range numbers {
+			if number != 0 && fields[i] != strconv.Itoa(number) {
+				return fmt.Errorf("%w: rdata '%s' contradicts the priority, weight or port given", ErrInvalidRData, r.RData)
+			}
+		}
+	}
+
+	return nil
+}
+
+// trimOctetZeros removes leading zeros of the octets of a dotted IPv4 address, which netip rejects.
+func trimOctetZeros(address string) string {
+	octets := strings.Split(address, ".")
+	for i, octet := range octets {
+		if trimmed := strings.TrimLeft(octet, "0"); trimmed != octet {
+			if trimmed == "" {
+				trimmed = "0"
+			}
+			octets[i] = trimmed
+		}
+	}
+
+	return strings.Join(octets, ".")
+}
+
+// Validate checks the record request and normalizes its rdata as documented at NormalizeRData.
+// The Priority, Weight and Port of MX and SRV records are packed into the rdata first.
+func (r *RecordRequest) Validate() error {
+	if err := r.packPriorityRData(); err != nil {
+		return err
+	}
+	rdata, err := NormalizeRData(RecordType(r.Type), r.RData)
+	if err != nil {
+		return err
+	}
+	r.RData = rdata
+
+	return nil
+}
+
+// sameRData compares the rdata of two records of the given type by their canonical form.
+func sameRData(recordType RecordType, a, b string) bool {
+	normalizedA, errA := NormalizeRData(recordType, a)
+	normalizedB, errB := NormalizeRData(recordType, b)
+	if errA != nil || errB != nil {
+		return a == b
+	}
+
+	return normalizedA == normalizedB
+}
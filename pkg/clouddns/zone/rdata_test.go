@@ -0,0 +1,99 @@
+package zone_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/anexia-it/go-anxcloud/pkg/clouddns/zone"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeRData(t *testing.T) {
+	for _, testCase := range []struct {
+		recordType zone.RecordType
+		rdata      string
+		expected   string
+	}{
+		{zone.TypeA, "192.0.2.1", "192.0.2.1"},
+		{zone.TypeA, "192.000.002.010", "192.0.2.10"},
+		{zone.TypeA, " 10.0.0.1 ", "10.0.0.1"},
+		{zone.TypeAAAA, "2001:DB8:0:0:0:0:0:1", "2001:db8::1"},
+		{zone.TypeAAAA, "0:0:0:1:0:0:0:1", "::1:0:0:0:1"},
+		{"aaaa", "2001:0db8::0001", "2001:db8::1"},
+		{zone.TypeTXT, "192.000.002.001", "192.000.002.001"},
+		{zone.TypeMX, " 10   mail.example.com. ", "10 mail.example.com."},
+		{zone.TypeSRV, "0 5 5060 sip.example.com.", "0 5 5060 sip.example.com."},
+	} {
+		normalized, err := zone.NormalizeRData(testCase.recordType, testCase.rdata)
+		if assert.NoError(t, err, "%s %s", testCase.recordType, testCase.rdata) {
+			assert.EqualValues(t, testCase.expected, normalized, "%s %s", testCase.recordType, testCase.rdata)
+		}
+	}
+
+	for _, testCase := range []struct {
+		recordType zone.RecordType
+		rdata      string
+	}{
+		{zone.TypeA, "2001:db8::1"},
+		{zone.TypeA, "192.0.2"},
+		{zone.TypeAAAA, "192.0.2.1"},
+		{zone.TypeAAAA, "fe80::1%eth0"},
+		{zone.TypeAAAA, "not-an-address"},
+		{zone.TypeMX, "mail.example.com."},
+		{zone.TypeMX, "high mail.example.com."},
+		{zone.TypeSRV, "0 5 70000 sip.example.com."},
+		{zone.TypeSRV, "0 5 sip.example.com."},
+	} {
+		_, err := zone.NormalizeRData(testCase.recordType, testCase.rdata)
+		assert.True(t, errors.Is(err, zone.ErrInvalidRData), "%s %s: expected ErrInvalidRData but got %v",
+			testCase.recordType, testCase.rdata, err)
+	}
+}
+
+func TestPriorityRecords(t *testing.T) {
+	ctx := context.Background()
+	api := zone.NewInMemoryAPI()
+	_, err := api.Create(ctx, zone.Definition{ZoneName: "example.com"})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	_, err = api.NewRecord(ctx, "example.com", zone.RecordRequest{Name: "", Type: "MX", RData: "mail.example.com.", Priority: 10})
+	assert.NoError(t, err)
+	_, err = api.NewRecord(ctx, "example.com", zone.RecordRequest{Name: "_sip._tcp", Type: "SRV",
+		RData: "sip.example.com.", Priority: 1, Weight: 5, Port: 5060})
+	assert.NoError(t, err)
+
+	records, err := api.ListRecords(ctx, "example.com")
+	if !assert.NoError(t, err) || !assert.Len(t, records, 2) {
+		return
+	}
+	for _, record := range records {
+		data, err := record.PriorityRData()
+		if !assert.NoError(t, err) {
+			continue
+		}
+		switch record.Type {
+		case "MX":
+			assert.EqualValues(t, "10 mail.example.com.", record.RData)
+			assert.EqualValues(t, zone.PriorityRData{Priority: 10, Target: "mail.example.com."}, data)
+		case "SRV":
+			assert.EqualValues(t, "1 5 5060 sip.example.com.", record.RData)
+			assert.EqualValues(t, zone.PriorityRData{Priority: 1, Weight: 5, Port: 5060, Target: "sip.example.com."}, data)
+		}
+	}
+
+	for _, request := range []zone.RecordRequest{
+		{Name: "", Type: "MX", RData: "20 mail.example.com.", Priority: 10},
+		{Name: "", Type: "MX", RData: "mail.example.com.", Priority: 10, Port: 25},
+		{Name: "", Type: "TXT", RData: "text", Priority: 10},
+		{Name: "_sip._tcp", Type: "SRV", RData: "sip.example.com.", Port: 70000},
+	} {
+		_, err := api.NewRecord(ctx, "example.com", request)
+		assert.True(t, errors.Is(err, zone.ErrInvalidRData), "%v: expected ErrInvalidRData but got %v", request, err)
+	}
+
+	_, err = zone.Record{Type: "A", RData: "192.0.2.1"}.PriorityRData()
+	assert.True(t, errors.Is(err, zone.ErrInvalidRData), "expected ErrInvalidRData but got %v", err)
+}
@@ -4,9 +4,38 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"github.com/anexia-it/go-anxcloud/pkg/client"
 	uuid "github.com/satori/go.uuid"
 	"net/http"
+	"strings"
+)
+
+// RecordType is the type of a DNS record.
+type RecordType string
+
+const (
+	// TypeA is an IPv4 address record.
+	TypeA RecordType = "A"
+	// TypeAAAA is an IPv6 address record.
+	TypeAAAA RecordType = "AAAA"
+	// TypeCAA is a certification authority authorization record.
+	TypeCAA RecordType = "CAA"
+	// TypeCNAME is a canonical name record.
+	TypeCNAME RecordType = "CNAME"
+	// TypeMX is a mail exchange record.
+	TypeMX RecordType = "MX"
+	// TypeNS is a name server record.
+	TypeNS RecordType = "NS"
+	// TypePTR is a pointer record.
+	TypePTR RecordType = "PTR"
+	// TypeSOA is a start of authority record.
+	TypeSOA RecordType = "SOA"
+	// TypeSRV is a service locator record.
+	TypeSRV RecordType = "SRV"
+	// TypeTXT is a text record.
+	TypeTXT RecordType = "TXT"
 )
 
 type RecordRequest struct {
@@ -15,15 +44,52 @@ type RecordRequest struct {
 	RData  string `json:"rdata"`
 	Region string `json:"region"`
 	TTL    int    `json:"ttl,omitempty"`
+
+	// Priority, Weight and Port of MX and SRV records. The API expects them as part of the rdata, so if RData
+	// only holds the target, Validate prepends them: "<priority> <target>" for MX and
+	// "<priority> <weight> <port> <target>" for SRV records. Weight and Port are only valid for SRV records.
+	Priority int `json:"-"`
+	Weight   int `json:"-"`
+	Port     int `json:"-"`
+
+	// EnsureUnique lets NewRecord look for an existing record with the same name, type and rdata first
+	// and fail with an AlreadyExistsError instead of sending the create request.
+	EnsureUnique bool `json:"-"`
+}
+
+// ErrAlreadyExists is raised if a record to be created conflicts with an existing one.
+var ErrAlreadyExists = errors.New("record already exists")
+
+// AlreadyExistsError is returned if a record with the same name, type and rdata already exists.
+type AlreadyExistsError struct {
+	// Record is the existing record.
+	Record Record
+}
+
+func (e *AlreadyExistsError) Error() string {
+	return fmt.Sprintf("%v: %s record '%s' with identifier '%s'", ErrAlreadyExists, e.Record.Type, e.Record.Name,
+		e.Record.Identifier)
+}
+
+// Is reports whether target is ErrAlreadyExists.
+func (e *AlreadyExistsError) Is(target error) bool {
+	return target == ErrAlreadyExists
 }
 
 // ListRecords API method
-func (a api) ListRecords(ctx context.Context, zone string) ([]Record, error) {
+//
+// If the API answers with the first page of the listing, as it does for large zones, the remaining pages are
+// fetched and all records returned. Use RecordPages to stream the records of large zones instead.
+//
+// Pass Fields to request only some fields of the records and Types to request only records of some types.
+func (a api) ListRecords(ctx context.Context, zone string, opts ...ListRecordsOption) ([]Record, error) {
+	o := newListRecordsOptions(opts)
 	url := fmt.Sprintf(
-		"%s%s/%s/records",
+		"%s%s/%s/records%s",
 		a.client.BaseURL(),
 		pathPrefix,
 		zone,
+		o.query(),
 	)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
@@ -35,22 +101,109 @@ func (a api) ListRecords(ctx context.Context, zone string) ([]Record, error) {
 	if err != nil {
 		return nil, fmt.Errorf("could not execute record list request: %w", err)
 	}
-	if httpResponse.StatusCode >= 500 && httpResponse.StatusCode < 600 {
-		return nil, fmt.Errorf("could not execute record list request, got response %s", httpResponse.Status)
+	if err := client.CheckResponse(httpResponse, "execute record list request"); err != nil {
+		return nil, err
 	}
 
-	responsePayload := make([]Record, 0)
-	err = json.NewDecoder(httpResponse.Body).Decode(&responsePayload)
+	var body json.RawMessage
+	err = json.NewDecoder(httpResponse.Body).Decode(&body)
 	_ = httpResponse.Body.Close()
 	if err != nil {
 		return nil, fmt.Errorf("could not decode zone list response: %w", err)
 	}
 
+	// Large zones are answered with the first page of the listing instead of all records.
+	if trimmed := bytes.TrimSpace(body); len(trimmed) > 0 && trimmed[0] == '{' {
+		var page RecordPage
+		if err := json.Unmarshal(body, &page); err != nil {
+			return nil, fmt.Errorf("could not decode record page response: %w", err)
+		}
+		records, err := recordPager{api: a, zone: zone, options: o}.remainingRecords(ctx, page)
+		if err != nil {
+			return nil, err
+		}
+		return o.project(records), nil
+	}
+
+	responsePayload := make([]Record, 0)
+	if err := json.Unmarshal(body, &responsePayload); err != nil {
+		return nil, fmt.Errorf("could not decode zone list response: %w", err)
+	}
+
+	return o.project(responsePayload), nil
+}
+
+// GetRecord returns the record with the given identifier.
+// If the zone has no such record, an error wrapping ErrRecordNotFound is returned.
+func (a api) GetRecord(ctx context.Context, zone string, id uuid.UUID) (Record, error) {
+	url := fmt.Sprintf(
+		"%s%s/%s/records/%s",
+		a.client.BaseURL(),
+		pathPrefix,
+		zone,
+		id,
+	)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return Record{}, fmt.Errorf("could not create record get request: %w", err)
+	}
+
+	httpResponse, err := a.client.Do(req)
+	if err != nil {
+		err = fmt.Errorf("could not execute record get request: %w", err)
+	} else {
+		err = client.CheckResponse(httpResponse, "execute record get request")
+	}
+	var responseError *client.ResponseError
+	if errors.As(err, &responseError) && responseError.Response.StatusCode == http.StatusNotFound {
+		return Record{}, fmt.Errorf("%w: '%s' in zone '%s'", ErrRecordNotFound, id, zone)
+	}
+	if err != nil {
+		return Record{}, err
+	}
+	defer func() { _ = httpResponse.Body.Close() }()
+
+	var responsePayload Record
+	if err := json.NewDecoder(httpResponse.Body).Decode(&responsePayload); err != nil {
+		return Record{}, fmt.Errorf("could not decode record get response: %w", err)
+	}
+
 	return responsePayload, nil
 }
 
+// ListRecordsByType lists the records of a zone grouped by their type.
+//
+// Types are normalized to upper case, so they match the RecordType constants.
+// Records of types without constant are grouped under their normalized type as well.
+func (a api) ListRecordsByType(ctx context.Context, zone string) (map[RecordType][]Record, error) {
+	records, err := a.ListRecords(ctx, zone)
+	if err != nil {
+		return nil, err
+	}
+
+	return groupByType(records), nil
+}
+
+func groupByType(records []Record) map[RecordType][]Record {
+	grouped := make(map[RecordType][]Record)
+	for _, record := range records {
+		recordType := RecordType(strings.ToUpper(strings.TrimSpace(record.Type)))
+		grouped[recordType] = append(grouped[recordType], record)
+	}
+
+	return grouped
+}
+
 // NewRecord new record API method
+//
+// The rdata of A and AAAA records is normalized before it is sent, invalid addresses are rejected
+// with ErrInvalidRData. Use CreateRecord to get the created record instead of the zone.
 func (a api) NewRecord(ctx context.Context, zone string, record RecordRequest) (Zone, error) {
+	if err := record.Validate(); err != nil {
+		return Zone{}, err
+	}
+
 	url := fmt.Sprintf(
 		"%s%s/%s/records",
 		a.client.BaseURL(),
@@ -58,6 +211,19 @@ func (a api) NewRecord(ctx context.Context, zone string, record RecordRequest) (
 		zone,
 	)
 
+	if record.EnsureUnique {
+		records, err := a.ListRecords(ctx, zone)
+		if err != nil {
+			return Zone{}, err
+		}
+		for _, existing := range records {
+			if existing.Name == record.Name && strings.EqualFold(existing.Type, record.Type) &&
+				sameRData(RecordType(record.Type), existing.RData, record.RData) {
+				return Zone{}, &AlreadyExistsError{Record: existing}
+			}
+		}
+	}
+
 	requestData := bytes.Buffer{}
 	if err := json.NewEncoder(&requestData).Encode(record); err != nil {
 		panic(fmt.Sprintf("could not create request data for create zone: %v", err))
@@ -72,8 +238,8 @@ func (a api) NewRecord(ctx context.Context, zone string, record RecordRequest) (
 	if err != nil {
 		return Zone{}, fmt.Errorf("could not execute record create request: %w", err)
 	}
-	if httpResponse.StatusCode >= 500 && httpResponse.StatusCode < 600 {
-		return Zone{}, fmt.Errorf("could not execute record create request, got response %s", httpResponse.Status)
+	if err := client.CheckResponse(httpResponse, "execute record create request"); err != nil {
+		return Zone{}, err
 	}
 
 	var responsePayload Zone
@@ -87,7 +253,13 @@ func (a api) NewRecord(ctx context.Context, zone string, record RecordRequest) (
 }
 
 // UpdateRecord record API method
+//
+// The rdata is normalized like with NewRecord.
 func (a api) UpdateRecord(ctx context.Context, zone string, id uuid.UUID, record RecordRequest) (Zone, error) {
+	if err := record.Validate(); err != nil {
+		return Zone{}, err
+	}
+
 	url := fmt.Sprintf(
 		"%s%s/%s/records/%s",
 		a.client.BaseURL(),
@@ -110,8 +282,8 @@ func (a api) UpdateRecord(ctx context.Context, zone string, id uuid.UUID, record
 	if err != nil {
 		return Zone{}, fmt.Errorf("could not execute record update request: %w", err)
 	}
-	if httpResponse.StatusCode >= 500 && httpResponse.StatusCode < 600 {
-		return Zone{}, fmt.Errorf("could not execute record update request, got response %s", httpResponse.Status)
+	if err := client.CheckResponse(httpResponse, "execute record update request"); err != nil {
+		return Zone{}, err
 	}
 
 	var responsePayload Zone
@@ -143,8 +315,8 @@ func (a api) DeleteRecord(ctx context.Context, zone string, id uuid.UUID) error
 	if err != nil {
 		return fmt.Errorf("could not execute record delete request: %w", err)
 	}
-	if httpResponse.StatusCode >= 500 && httpResponse.StatusCode < 600 {
-		return fmt.Errorf("could not execute record delete request, got response %s", httpResponse.Status)
+	if err := client.CheckResponse(httpResponse, "execute record delete request"); err != nil {
+		return err
 	}
 
 	return httpResponse.Body.Close()
@@ -0,0 +1,219 @@
+package zone
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// RecordAction is the kind of change ReplaceRecords applies to a record.
+type RecordAction string
+
+const (
+	// RecordCreate creates a new record.
+	RecordCreate RecordAction = "create"
+	// RecordUpdate updates an existing record in place.
+	RecordUpdate RecordAction = "update"
+	// RecordDelete deletes an existing record.
+	RecordDelete RecordAction = "delete"
+)
+
+// RecordChange is a single change applied by ReplaceRecords.
+type RecordChange struct {
+	Action RecordAction
+	// Identifier of the existing record, empty for RecordCreate.
+	Identifier uuid.UUID
+	// Record is the desired record, or the deleted one for RecordDelete.
+	Record RecordRequest
+}
+
+// FailedRecordChange is a change ReplaceRecords could not apply.
+type FailedRecordChange struct {
+	RecordChange
+	Err error
+}
+
+// ReplaceRecordsError is returned by ReplaceRecords if some of the changes could not be applied.
+type ReplaceRecordsError struct {
+	// Applied lists the changes applied successfully.
+	Applied []RecordChange
+	// Failed lists the changes that could not be applied, together with the error encountered.
+	Failed []FailedRecordChange
+}
+
+func (e *ReplaceRecordsError) Error() string {
+	messages := make([]string, 0, len(e.Failed))
+	for _, failed := range e.Failed {
+		messages = append(messages, fmt.Sprintf("%s %s record '%s': %v", failed.Action, failed.Record.Type,
+			failed.Record.Name, failed.Err))
+	}
+
+	return fmt.Sprintf("could not apply %d of %d record changes: %s", len(e.Failed), len(e.Failed)+len(e.Applied),
+		strings.Join(messages, "; "))
+}
+
+// Is reports whether any of the failed changes failed with target.
+func (e *ReplaceRecordsError) Is(target error) bool {
+	for _, failed := range e.Failed {
+		if errors.Is(failed.Err, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ReplaceOption configures ReplaceRecords.
+type ReplaceOption func(o *replaceOptions)
+
+type replaceOptions struct {
+	prune bool
+}
+
+// PruneRecords lets ReplaceRecords delete the records of the zone not contained in the desired set.
+// Immutable records are never deleted.
+func PruneRecords() ReplaceOption {
+	return func(o *replaceOptions) {
+		o.prune = true
+	}
+}
+
+// ReplaceRecords converges the records of zone to the desired records, issuing as few requests as possible.
+//
+// Records are identified by name, type, region and rdata; a record only differing in TTL is updated in place.
+// Records not contained in records are left alone, unless PruneRecords is given. With PruneRecords, records to
+// delete are reused for records to create with the same name and type, updating them instead.
+//
+// All records are validated before any change is made. Deletions are applied first, so e.g. a CNAME can
+// replace other records of the same name, then updates and creations. If changes fail, the remaining ones are
+// still applied and a ReplaceRecordsError is returned listing the applied and failed changes.
+func ReplaceRecords(ctx context.Context, a API, zone string, records []RecordRequest, opts ...ReplaceOption) (Zone, error) {
+	o := replaceOptions{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	desired := make([]RecordRequest, len(records))
+	for i, record := range records {
+		if err := record.Validate(); err != nil {
+			return Zone{}, fmt.Errorf("invalid %s record '%s': %w", record.Type, record.Name, err)
+		}
+		desired[i] = record
+	}
+
+	existing, err := a.ListRecords(ctx, zone)
+	if err != nil {
+		return Zone{}, fmt.Errorf("could not list records of zone '%s': %w", zone, err)
+	}
+
+	var result Zone
+	resultSet := false
+	replaceErr := &ReplaceRecordsError{}
+	for _, change := range planRecordChanges(existing, desired, o.prune) {
+		var changed Zone
+		switch change.Action {
+		case RecordDelete:
+			err = a.DeleteRecord(ctx, zone, change.Identifier)
+		case RecordUpdate:
+			changed, err = a.UpdateRecord(ctx, zone, change.Identifier, change.Record)
+		case RecordCreate:
+			changed, err = a.NewRecord(ctx, zone, change.Record)
+		}
+
+		if err != nil {
+			replaceErr.Failed = append(replaceErr.Failed, FailedRecordChange{change, err})
+			continue
+		}
+		replaceErr.Applied = append(replaceErr.Applied, change)
+		if change.Action != RecordDelete {
+			result, resultSet = changed, true
+		}
+	}
+
+	if len(replaceErr.Failed) > 0 {
+		return Zone{}, replaceErr
+	}
+	if !resultSet {
+		return a.Get(ctx, zone)
+	}
+
+	return result, nil
+}
+
+// planRecordChanges computes the changes needed to converge the existing records to the desired ones,
+// ordered deletions first, then updates and creations.
+func planRecordChanges(existing []Record, desired []RecordRequest, prune bool) []RecordChange {
+	matched := make([]bool, len(existing))
+	var updates, creates []RecordChange
+
+	var unmatched []RecordRequest
+	for _, record := range desired {
+		index := -1
+		for i, candidate := range existing {
+			if !matched[i] && sameRecord(candidate, record) {
+				index = i
+				break
+			}
+		}
+		if index < 0 {
+			unmatched = append(unmatched, record)
+			continue
+		}
+
+		matched[index] = true
+		if record.TTL != 0 && (existing[index].TTL == nil || *existing[index].TTL != record.TTL) {
+			updates = append(updates, RecordChange{Action: RecordUpdate, Identifier: existing[index].Identifier, Record: record})
+		}
+	}
+
+	for _, record := range unmatched {
+		index := -1
+		if prune {
+			for i, candidate := range existing {
+				if !matched[i] && !candidate.Immutable && candidate.Name == record.Name &&
+					strings.EqualFold(candidate.Type, record.Type) {
+					index = i
+					break
+				}
+			}
+		}
+		if index < 0 {
+			creates = append(creates, RecordChange{Action: RecordCreate, Record: record})
+			continue
+		}
+
+		matched[index] = true
+		updates = append(updates, RecordChange{Action: RecordUpdate, Identifier: existing[index].Identifier, Record: record})
+	}
+
+	var changes []RecordChange
+	if prune {
+		for i, record := range existing {
+			if matched[i] || record.Immutable {
+				continue
+			}
+			changes = append(changes, RecordChange{Action: RecordDelete, Identifier: record.Identifier, Record: recordRequestOf(record)})
+		}
+	}
+
+	return append(append(changes, updates...), creates...)
+}
+
+// sameRecord reports whether the existing record is the desired one, ignoring the TTL.
+func sameRecord(existing Record, desired RecordRequest) bool {
+	return existing.Name == desired.Name && strings.EqualFold(existing.Type, desired.Type) &&
+		existing.Region == desired.Region && sameRData(RecordType(strings.ToUpper(desired.Type)), existing.RData, desired.RData)
+}
+
+// recordRequestOf returns the request describing the given record.
+func recordRequestOf(record Record) RecordRequest {
+	request := RecordRequest{Name: record.Name, Type: record.Type, RData: record.RData, Region: record.Region}
+	if record.TTL != nil {
+		request.TTL = *record.TTL
+	}
+
+	return request
+}
@@ -0,0 +1,141 @@
+package zone_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/anexia-it/go-anxcloud/pkg/clouddns/zone"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+var errCreateRejected = errors.New("create rejected")
+
+// changeCountingAPI counts the record changes sent to the wrapped API and rejects creating records named reject.
+type changeCountingAPI struct {
+	*zone.InMemoryAPI
+	reject  string
+	changes int
+}
+
+func (c *changeCountingAPI) NewRecord(ctx context.Context, name string, record zone.RecordRequest) (zone.Zone, error) {
+	c.changes++
+	if record.Name == c.reject {
+		return zone.Zone{}, errCreateRejected
+	}
+	return c.InMemoryAPI.NewRecord(ctx, name, record)
+}
+
+func (c *changeCountingAPI) UpdateRecord(ctx context.Context, name string, id uuid.UUID, record zone.RecordRequest) (zone.Zone, error) {
+	c.changes++
+	return c.InMemoryAPI.UpdateRecord(ctx, name, id, record)
+}
+
+func (c *changeCountingAPI) DeleteRecord(ctx context.Context, name string, id uuid.UUID) error {
+	c.changes++
+	return c.InMemoryAPI.DeleteRecord(ctx, name, id)
+}
+
+// recordSet returns the records of the zone as sorted "name type rdata ttl" strings.
+func recordSet(t *testing.T, api zone.API) []string {
+	records, err := api.ListRecords(context.Background(), "example.com")
+	assert.NoError(t, err)
+
+	set := make([]string, 0, len(records))
+	for _, record := range records {
+		ttl := 0
+		if record.TTL != nil {
+			ttl = *record.TTL
+		}
+		set = append(set, fmt.Sprintf("%s %s %s %d", record.Name, record.Type, record.RData, ttl))
+	}
+	sort.Strings(set)
+
+	return set
+}
+
+func TestReplaceRecords(t *testing.T) {
+	ctx := context.Background()
+	setup := func() *changeCountingAPI {
+		api := &changeCountingAPI{InMemoryAPI: zone.NewInMemoryAPI()}
+		_, err := api.Create(ctx, zone.Definition{ZoneName: "example.com"})
+		assert.NoError(t, err)
+		for _, record := range []zone.RecordRequest{
+			{Name: "www", Type: "A", RData: "192.0.2.1", TTL: 300},
+			{Name: "www", Type: "A", RData: "192.0.2.2", TTL: 300},
+			{Name: "mail", Type: "TXT", RData: "old", TTL: 300},
+		} {
+			_, err := api.NewRecord(ctx, "example.com", record)
+			assert.NoError(t, err)
+		}
+		api.changes = 0
+
+		return api
+	}
+	desired := []zone.RecordRequest{
+		{Name: "www", Type: "A", RData: "192.000.002.001", TTL: 300},
+		{Name: "www", Type: "A", RData: "192.0.2.2", TTL: 600},
+		{Name: "mail", Type: "TXT", RData: "new", TTL: 300},
+	}
+
+	t.Run("KeepOthers", func(t *testing.T) {
+		api := setup()
+		_, err := zone.ReplaceRecords(ctx, api, "example.com", desired)
+		assert.NoError(t, err)
+		assert.EqualValues(t, 2, api.changes)
+		assert.EqualValues(t, []string{
+			"mail TXT new 300",
+			"mail TXT old 300",
+			"www A 192.0.2.1 300",
+			"www A 192.0.2.2 600",
+		}, recordSet(t, api))
+
+		api.changes = 0
+		_, err = zone.ReplaceRecords(ctx, api, "example.com", desired)
+		assert.NoError(t, err)
+		assert.EqualValues(t, 0, api.changes)
+	})
+
+	t.Run("Prune", func(t *testing.T) {
+		api := setup()
+		_, err := zone.ReplaceRecords(ctx, api, "example.com", desired[1:], zone.PruneRecords())
+		assert.NoError(t, err)
+		assert.EqualValues(t, 3, api.changes)
+		assert.EqualValues(t, []string{
+			"mail TXT new 300",
+			"www A 192.0.2.2 600",
+		}, recordSet(t, api))
+	})
+
+	t.Run("PartialFailure", func(t *testing.T) {
+		api := setup()
+		api.reject = "ftp"
+		_, err := zone.ReplaceRecords(ctx, api, "example.com", []zone.RecordRequest{
+			{Name: "ftp", Type: "A", RData: "192.0.2.3"},
+			{Name: "www", Type: "A", RData: "192.0.2.2", TTL: 600},
+		})
+
+		assert.True(t, errors.Is(err, errCreateRejected), "expected errCreateRejected but got %v", err)
+		var replaceErr *zone.ReplaceRecordsError
+		if assert.True(t, errors.As(err, &replaceErr), "expected ReplaceRecordsError but got %v", err) {
+			assert.Len(t, replaceErr.Applied, 1)
+			if assert.Len(t, replaceErr.Failed, 1) {
+				assert.EqualValues(t, zone.RecordCreate, replaceErr.Failed[0].Action)
+				assert.EqualValues(t, "ftp", replaceErr.Failed[0].Record.Name)
+			}
+		}
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		api := setup()
+		_, err := zone.ReplaceRecords(ctx, api, "example.com", []zone.RecordRequest{
+			{Name: "www", Type: "A", RData: "192.0.2.9", TTL: 300},
+			{Name: "www", Type: "A", RData: "not an address"},
+		})
+		assert.True(t, errors.Is(err, zone.ErrInvalidRData), "expected ErrInvalidRData but got %v", err)
+		assert.EqualValues(t, 0, api.changes)
+	})
+}
@@ -0,0 +1,134 @@
+package zone
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultResolverTTL is the time a ZoneResolver caches the list of managed zones if no other TTL is given.
+const DefaultResolverTTL = 5 * time.Minute
+
+// ErrNoZone is raised if no managed zone contains a domain.
+var ErrNoZone = errors.New("no managed zone found for domain")
+
+// ZoneResolver finds the managed zone owning a domain name, caching the list of zones for a TTL.
+// It is safe for concurrent use.
+type ZoneResolver struct {
+	api API
+	ttl time.Duration
+
+	mu        sync.Mutex
+	zones     []string
+	fetchedAt time.Time
+}
+
+// NewZoneResolver creates a ZoneResolver listing zones with the given API and caching them for ttl.
+// A ttl of 0 uses DefaultResolverTTL.
+func NewZoneResolver(a API, ttl time.Duration) *ZoneResolver {
+	if ttl <= 0 {
+		ttl = DefaultResolverTTL
+	}
+
+	return &ZoneResolver{api: a, ttl: ttl}
+}
+
+// ZoneForFQDN returns the name of the managed zone with the longest name containing fqdn.
+// A leading wildcard label and a trailing dot of fqdn are ignored.
+func (r *ZoneResolver) ZoneForFQDN(ctx context.Context, fqdn string) (string, error) {
+	zones, err := r.cachedZones(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	domain := normalizeZoneName(strings.TrimPrefix(strings.ToLower(fqdn), "*."))
+	match := ""
+	for _, name := range zones {
+		if (domain == name || strings.HasSuffix(domain, "."+name)) && len(name) > len(match) {
+			match = name
+		}
+	}
+
+	if match == "" {
+		return "", fmt.Errorf("%w: '%s'", ErrNoZone, domain)
+	}
+
+	return match, nil
+}
+
+// Zones returns the names of all managed zones, in lower case and without trailing dot.
+func (r *ZoneResolver) Zones(ctx context.Context) ([]string, error) {
+	zones, err := r.cachedZones(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, len(zones))
+	copy(names, zones)
+
+	return names, nil
+}
+
+// Refresh fetches the list of managed zones regardless of the age of the cached one.
+func (r *ZoneResolver) Refresh(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.refresh(ctx)
+}
+
+// Invalidate drops the cached list of managed zones, so it is fetched again on the next lookup.
+func (r *ZoneResolver) Invalidate() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.zones = nil
+	r.fetchedAt = time.Time{}
+}
+
+// cachedZones returns the cached zone names, fetching them if they expired. Concurrent callers
+// wait for a single refresh instead of each listing the zones.
+func (r *ZoneResolver) cachedZones(ctx context.Context) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.fetchedAt.IsZero() || time.Since(r.fetchedAt) >= r.ttl {
+		if err := r.refresh(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	return r.zones, nil
+}
+
+// refresh fetches the list of managed zones. The caller has to hold the lock.
+func (r *ZoneResolver) refresh(ctx context.Context) error {
+	zones, err := r.api.List(ctx)
+	if err != nil {
+		return fmt.Errorf("could not list zones: %w", err)
+	}
+
+	names := make([]string, 0, len(zones))
+	for _, z := range zones {
+		if z.Definition == nil {
+			continue
+		}
+		name := z.Name
+		if name == "" {
+			name = z.ZoneName
+		}
+		names = append(names, normalizeZoneName(name))
+	}
+
+	r.zones = names
+	r.fetchedAt = time.Now()
+
+	return nil
+}
+
+func normalizeZoneName(name string) string {
+	return strings.TrimSuffix(strings.ToLower(name), ".")
+}
@@ -0,0 +1,58 @@
+package zone_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/anexia-it/go-anxcloud/pkg/clouddns/zone"
+	"github.com/stretchr/testify/assert"
+)
+
+// countingAPI counts the zone listings of the wrapped API.
+type countingAPI struct {
+	*zone.InMemoryAPI
+	lists int
+}
+
+func (c *countingAPI) List(ctx context.Context) ([]zone.Zone, error) {
+	c.lists++
+	return c.InMemoryAPI.List(ctx)
+}
+
+func TestZoneResolver(t *testing.T) {
+	ctx := context.Background()
+	api := &countingAPI{InMemoryAPI: zone.NewInMemoryAPI()}
+	for _, name := range []string{"example.com", "sub.example.com"} {
+		_, err := api.Create(ctx, zone.Definition{ZoneName: name})
+		assert.NoError(t, err)
+	}
+
+	resolver := zone.NewZoneResolver(api, time.Hour)
+	for fqdn, expected := range map[string]string{
+		"example.com":                     "example.com",
+		"www.example.com.":                "example.com",
+		"*.sub.example.com":               "sub.example.com",
+		"a.b.sub.example.com":             "sub.example.com",
+		"notsub.example.com":              "example.com",
+		"_acme-challenge.Sub.Example.COM": "sub.example.com",
+	} {
+		name, err := resolver.ZoneForFQDN(ctx, fqdn)
+		if assert.NoError(t, err, fqdn) {
+			assert.Equal(t, expected, name, fqdn)
+		}
+	}
+	assert.Equal(t, 1, api.lists)
+
+	_, err := resolver.ZoneForFQDN(ctx, "example.org")
+	assert.True(t, errors.Is(err, zone.ErrNoZone), "expected ErrNoZone but got %v", err)
+
+	_, err = api.Create(ctx, zone.Definition{ZoneName: "example.org"})
+	assert.NoError(t, err)
+	resolver.Invalidate()
+	name, err := resolver.ZoneForFQDN(ctx, "www.example.org")
+	assert.NoError(t, err)
+	assert.Equal(t, "example.org", name)
+	assert.Equal(t, 2, api.lists)
+}
@@ -0,0 +1,237 @@
+package zone
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"strconv"
+	"strings"
+)
+
+const (
+	// DefaultReverseZonePrefixIPv4 is the prefix length of IPv4 reverse zones created by SetPTR.
+	DefaultReverseZonePrefixIPv4 = 24
+	// DefaultReverseZonePrefixIPv6 is the prefix length of IPv6 reverse zones created by SetPTR.
+	DefaultReverseZonePrefixIPv6 = 48
+)
+
+var (
+	// ErrInvalidReversePrefix is raised if no reverse zone can be named for a prefix.
+	ErrInvalidReversePrefix = errors.New("no reverse zone for prefix")
+	// ErrReverseZoneNotDelegated is raised if the public DNS does not delegate a reverse zone to the
+	// name servers of the managed zone, so PTR records in it would not be resolvable.
+	ErrReverseZoneNotDelegated = errors.New("reverse zone is not delegated to CloudDNS")
+)
+
+type ptrOptions struct {
+	createZone      bool
+	template        Definition
+	prefixIPv4      int
+	prefixIPv6      int
+	lookupNS        func(ctx context.Context, name string) ([]*net.NS, error)
+	checkDelegation bool
+}
+
+// PTROption is an optional parameter for SetPTR.
+type PTROption func(o *ptrOptions)
+
+// CreateMissingReverseZone lets SetPTR create the reverse zone if no managed zone contains the address.
+// The zone is created from template with the zone name set to the reverse zone name.
+func CreateMissingReverseZone(template Definition) PTROption {
+	return func(o *ptrOptions) {
+		o.createZone = true
+		o.template = template
+	}
+}
+
+// ReverseZonePrefix sets the prefix lengths of reverse zones created by SetPTR. IPv4 lengths of 8, 16 and 24
+// name classful zones, 25 to 31 classless zones named after RFC 2317. IPv6 lengths have to be multiples of 4.
+func ReverseZonePrefix(ipv4Bits, ipv6Bits int) PTROption {
+	return func(o *ptrOptions) {
+		o.prefixIPv4 = ipv4Bits
+		o.prefixIPv6 = ipv6Bits
+	}
+}
+
+// NSLookup replaces the lookup of the public name servers of a reverse zone used to check its delegation.
+func NSLookup(f func(ctx context.Context, name string) ([]*net.NS, error)) PTROption {
+	return func(o *ptrOptions) {
+		o.lookupNS = f
+	}
+}
+
+// SkipDelegationCheck lets SetPTR set the record without checking the delegation of the reverse zone.
+func SkipDelegationCheck() PTROption {
+	return func(o *ptrOptions) {
+		o.checkDelegation = false
+	}
+}
+
+// ReverseZoneName returns the name of the reverse zone of the given prefix, without trailing dot.
+//
+// IPv4 prefixes of 8, 16 and 24 bits map to classful zones like "2.0.192.in-addr.arpa", prefixes of
+// 25 to 31 bits to RFC 2317 classless zones like "128-25.2.0.192.in-addr.arpa". IPv6 prefixes map to
+// nibble zones like "8.b.d.0.1.0.0.2.ip6.arpa" and have to be a multiple of 4 bits.
+func ReverseZoneName(prefix netip.Prefix) (string, error) {
+	prefix = prefix.Masked()
+	bits := prefix.Bits()
+	if prefix.Addr().Is4() {
+		labels := strings.Split(ReverseName(prefix.Addr()), ".")
+		switch {
+		case bits == 8 || bits == 16 || bits == 24:
+			return strings.Join(labels[4-bits/8:], "."), nil
+		case bits > 24 && bits < 32:
+			return fmt.Sprintf("%s-%d.%s", labels[0], bits, strings.Join(labels[1:], ".")), nil
+		}
+	} else if bits > 0 && bits < 128 && bits%4 == 0 {
+		labels := strings.Split(ReverseName(prefix.Addr()), ".")
+		return strings.Join(labels[32-bits/4:], "."), nil
+	}
+
+	return "", fmt.Errorf("%w: %s", ErrInvalidReversePrefix, prefix)
+}
+
+// FindReverseZone returns the managed zone containing the PTR record of ip and the name of the record
+// within it. RFC 2317 classless zones are preferred over the classful zone containing them.
+func FindReverseZone(ctx context.Context, resolver *ZoneResolver, ip netip.Addr) (zone, name string, err error) {
+	ip = ip.Unmap()
+	if ip.Is4() {
+		zones, err := resolver.Zones(ctx)
+		if err != nil {
+			return "", "", err
+		}
+		for _, zone := range zones {
+			if prefix, ok := parseClasslessZone(zone); ok && prefix.Contains(ip) {
+				return zone, strconv.Itoa(int(ip.As4()[3])), nil
+			}
+		}
+	}
+
+	fqdn := ReverseName(ip)
+	zone, err = resolver.ZoneForFQDN(ctx, fqdn)
+	if err != nil {
+		return "", "", err
+	}
+
+	return zone, strings.TrimSuffix(strings.TrimSuffix(fqdn, zone), "."), nil
+}
+
+// parseClasslessZone parses an RFC 2317 zone name like "128-25.2.0.192.in-addr.arpa".
+func parseClasslessZone(zone string) (netip.Prefix, bool) {
+	labels := strings.Split(strings.TrimSuffix(zone, ".in-addr.arpa"), ".")
+	if len(labels) != 4 || !strings.HasSuffix(zone, ".in-addr.arpa") {
+		return netip.Prefix{}, false
+	}
+
+	first := strings.SplitN(labels[0], "-", 2)
+	if len(first) != 2 {
+		return netip.Prefix{}, false
+	}
+	bits, err := strconv.Atoi(first[1])
+	if err != nil || bits <= 24 || bits >= 32 {
+		return netip.Prefix{}, false
+	}
+
+	ip, err := netip.ParseAddr(strings.Join([]string{labels[3], labels[2], labels[1], first[0]}, "."))
+	if err != nil {
+		return netip.Prefix{}, false
+	}
+
+	return netip.PrefixFrom(ip, bits), true
+}
+
+// SetPTR creates a PTR record pointing ip at hostname in the managed reverse zone containing it.
+//
+// The reverse zone is looked up via resolver. If there is none, ErrNoZone is returned unless
+// CreateMissingReverseZone was passed. Unless SkipDelegationCheck was passed, the public name servers
+// of the reverse zone are compared with its NS records and ErrReverseZoneNotDelegated is returned if none matches.
+func SetPTR(ctx context.Context, a API, resolver *ZoneResolver, ip netip.Addr, hostname string, opts ...PTROption) (Zone, error) {
+	o := ptrOptions{
+		prefixIPv4:      DefaultReverseZonePrefixIPv4,
+		prefixIPv6:      DefaultReverseZonePrefixIPv6,
+		lookupNS:        net.DefaultResolver.LookupNS,
+		checkDelegation: true,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	ip = ip.Unmap()
+	zone, name, err := FindReverseZone(ctx, resolver, ip)
+	if errors.Is(err, ErrNoZone) && o.createZone {
+		zone, name, err = createReverseZone(ctx, a, resolver, ip, o)
+	}
+	if err != nil {
+		return Zone{}, fmt.Errorf("could not find reverse zone of %s: %w", ip, err)
+	}
+
+	if o.checkDelegation {
+		if err := checkDelegation(ctx, a, zone, o.lookupNS); err != nil {
+			return Zone{}, err
+		}
+	}
+
+	if !strings.HasSuffix(hostname, ".") {
+		hostname += "."
+	}
+
+	return a.NewRecord(ctx, zone, RecordRequest{Name: name, Type: string(TypePTR), RData: hostname})
+}
+
+func createReverseZone(ctx context.Context, a API, resolver *ZoneResolver, ip netip.Addr, o ptrOptions) (string, string, error) {
+	bits := o.prefixIPv6
+	if ip.Is4() {
+		bits = o.prefixIPv4
+	}
+
+	prefix, err := ip.Prefix(bits)
+	if err != nil {
+		return "", "", fmt.Errorf("%w: %v", ErrInvalidReversePrefix, err)
+	}
+	zone, err := ReverseZoneName(prefix)
+	if err != nil {
+		return "", "", err
+	}
+
+	definition := o.template
+	definition.ZoneName = zone
+	if _, err := a.Create(ctx, definition); err != nil {
+		return "", "", fmt.Errorf("could not create reverse zone '%s': %w", zone, err)
+	}
+	resolver.Invalidate()
+
+	return FindReverseZone(ctx, resolver, ip)
+}
+
+// checkDelegation verifies that at least one public name server of the zone is listed in its NS records.
+// Zones without NS records can not be checked and are accepted.
+func checkDelegation(ctx context.Context, a API, zone string, lookupNS func(ctx context.Context, name string) ([]*net.NS, error)) error {
+	records, err := a.ListRecordsByType(ctx, zone)
+	if err != nil {
+		return err
+	}
+
+	managed := make(map[string]struct{})
+	for _, record := range records[TypeNS] {
+		if record.Name == "" || record.Name == "@" {
+			managed[normalizeZoneName(record.RData)] = struct{}{}
+		}
+	}
+	if len(managed) == 0 {
+		return nil
+	}
+
+	public, err := lookupNS(ctx, zone+".")
+	if err != nil {
+		return fmt.Errorf("%w: '%s': %v", ErrReverseZoneNotDelegated, zone, err)
+	}
+	for _, ns := range public {
+		if _, ok := managed[normalizeZoneName(ns.Host)]; ok {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: '%s'", ErrReverseZoneNotDelegated, zone)
+}
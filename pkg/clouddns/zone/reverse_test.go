@@ -0,0 +1,100 @@
+package zone_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/netip"
+	"testing"
+
+	"github.com/anexia-it/go-anxcloud/pkg/clouddns/zone"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReverseZoneName(t *testing.T) {
+	for prefix, expected := range map[string]string{
+		"192.0.2.0/24":    "2.0.192.in-addr.arpa",
+		"10.0.0.0/8":      "10.in-addr.arpa",
+		"192.0.2.128/25":  "128-25.2.0.192.in-addr.arpa",
+		"2001:db8::/32":   "8.b.d.0.1.0.0.2.ip6.arpa",
+		"2001:db8:1::/48": "1.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa",
+	} {
+		name, err := zone.ReverseZoneName(netip.MustParsePrefix(prefix))
+		if assert.NoError(t, err, prefix) {
+			assert.Equal(t, expected, name, prefix)
+		}
+	}
+
+	for _, prefix := range []string{"192.0.0.0/20", "2001:db8::/30"} {
+		_, err := zone.ReverseZoneName(netip.MustParsePrefix(prefix))
+		assert.True(t, errors.Is(err, zone.ErrInvalidReversePrefix), "expected ErrInvalidReversePrefix for %s but got %v", prefix, err)
+	}
+}
+
+func TestSetPTR(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Classless", func(t *testing.T) {
+		api := zone.NewInMemoryAPI()
+		for _, name := range []string{"2.0.192.in-addr.arpa", "128-25.2.0.192.in-addr.arpa"} {
+			_, err := api.Create(ctx, zone.Definition{ZoneName: name})
+			assert.NoError(t, err)
+		}
+		resolver := zone.NewZoneResolver(api, 0)
+
+		name, record, err := zone.FindReverseZone(ctx, resolver, netip.MustParseAddr("192.0.2.130"))
+		assert.NoError(t, err)
+		assert.Equal(t, "128-25.2.0.192.in-addr.arpa", name)
+		assert.Equal(t, "130", record)
+
+		name, record, err = zone.FindReverseZone(ctx, resolver, netip.MustParseAddr("192.0.2.5"))
+		assert.NoError(t, err)
+		assert.Equal(t, "2.0.192.in-addr.arpa", name)
+		assert.Equal(t, "5", record)
+	})
+
+	t.Run("CreateMissing", func(t *testing.T) {
+		api := zone.NewInMemoryAPI()
+		resolver := zone.NewZoneResolver(api, 0)
+		ip := netip.MustParseAddr("2001:db8:1::1")
+
+		_, err := zone.SetPTR(ctx, api, resolver, ip, "host.example.com", zone.SkipDelegationCheck())
+		assert.True(t, errors.Is(err, zone.ErrNoZone), "expected ErrNoZone but got %v", err)
+
+		created, err := zone.SetPTR(ctx, api, resolver, ip, "host.example.com",
+			zone.SkipDelegationCheck(), zone.CreateMissingReverseZone(zone.Definition{IsMaster: true}))
+		if assert.NoError(t, err) {
+			assert.Equal(t, "1.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa", created.Name)
+			records := created.Revisions[0].Records
+			if assert.Len(t, records, 1) {
+				assert.Equal(t, "host.example.com.", records[0].RData)
+				assert.Equal(t, zone.ReverseName(ip), records[0].Name+"."+created.Name)
+			}
+		}
+	})
+
+	t.Run("NotDelegated", func(t *testing.T) {
+		api := zone.NewInMemoryAPI()
+		_, err := api.Create(ctx, zone.Definition{ZoneName: "2.0.192.in-addr.arpa"})
+		assert.NoError(t, err)
+		_, err = api.NewRecord(ctx, "2.0.192.in-addr.arpa", zone.RecordRequest{Name: "@", Type: "NS", RData: "ns1.anexia-it.com."})
+		assert.NoError(t, err)
+		resolver := zone.NewZoneResolver(api, 0)
+
+		lookup := func(nameservers ...string) zone.PTROption {
+			return zone.NSLookup(func(context.Context, string) ([]*net.NS, error) {
+				result := make([]*net.NS, 0, len(nameservers))
+				for _, host := range nameservers {
+					result = append(result, &net.NS{Host: host})
+				}
+				return result, nil
+			})
+		}
+
+		_, err = zone.SetPTR(ctx, api, resolver, netip.MustParseAddr("192.0.2.1"), "host.example.com.", lookup("ns.other.net."))
+		assert.True(t, errors.Is(err, zone.ErrReverseZoneNotDelegated), "expected ErrReverseZoneNotDelegated but got %v", err)
+
+		_, err = zone.SetPTR(ctx, api, resolver, netip.MustParseAddr("192.0.2.1"), "host.example.com.", lookup("ns1.anexia-it.com."))
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,71 @@
+package zone
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrNoSOA is raised if a zone has no SOA record or it can not be parsed.
+var ErrNoSOA = errors.New("zone has no valid SOA record")
+
+// SOA holds the values of the start of authority record of a zone.
+type SOA struct {
+	PrimaryNS  string
+	AdminEmail string
+	Serial     int
+	Refresh    int
+	Retry      int
+	Expire     int
+	// Minimum is the TTL of negative answers and the lowest TTL records of the zone should have.
+	Minimum int
+}
+
+// GetSOA returns the SOA record of the given zone.
+func GetSOA(ctx context.Context, a API, zone string) (SOA, error) {
+	records, err := a.ListRecordsByType(ctx, zone)
+	if err != nil {
+		return SOA{}, err
+	}
+
+	soaRecords := records[TypeSOA]
+	if len(soaRecords) == 0 {
+		return SOA{}, fmt.Errorf("%w: '%s'", ErrNoSOA, zone)
+	}
+
+	soa, err := parseSOA(soaRecords[0].RData)
+	if err != nil {
+		return SOA{}, fmt.Errorf("%w: '%s': %v", ErrNoSOA, zone, err)
+	}
+
+	return soa, nil
+}
+
+// parseSOA parses SOA rdata of the form "primary-ns admin-email serial refresh retry expire minimum".
+func parseSOA(rdata string) (SOA, error) {
+	fields := strings.Fields(rdata)
+	if len(fields) != 7 {
+		return SOA{}, fmt.Errorf("expected 7 fields but got %d", len(fields))
+	}
+
+	values := make([]int, 5)
+	for i, field := range fields[2:] {
+		value, err := strconv.Atoi(field)
+		if err != nil {
+			return SOA{}, fmt.Errorf("invalid value '%s': %w", field, err)
+		}
+		values[i] = value
+	}
+
+	return SOA{
+		PrimaryNS:  fields[0],
+		AdminEmail: fields[1],
+		Serial:     values[0],
+		Refresh:    values[1],
+		Retry:      values[2],
+		Expire:     values[3],
+		Minimum:    values[4],
+	}, nil
+}
@@ -0,0 +1,140 @@
+package zone
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"strings"
+	"unicode"
+
+	"github.com/anexia-it/go-anxcloud/pkg/client"
+)
+
+// ErrInvalidTransferConfig is raised if a TransferConfig is rejected before sending it to the API.
+var ErrInvalidTransferConfig = errors.New("invalid zone transfer configuration")
+
+// TSIGKey is a shared secret used to authenticate zone transfers.
+//
+// The secret is never contained in the output of fmt verbs, and requests containing
+// TSIG keys are not dumped with their body to the client LogWriter.
+type TSIGKey struct {
+	// Name of the key, e.g. 'transfer-key.example.com.'.
+	Name string `json:"name"`
+	// Algorithm of the key, e.g. 'hmac-sha256'.
+	Algorithm string `json:"algorithm"`
+	// Secret is the base64 encoded key.
+	Secret string `json:"secret"`
+}
+
+// String returns the key without its secret.
+func (k TSIGKey) String() string {
+	return fmt.Sprintf("{Name:%s Algorithm:%s Secret:REDACTED}", k.Name, k.Algorithm)
+}
+
+// GoString returns the key without its secret.
+func (k TSIGKey) GoString() string {
+	return fmt.Sprintf("zone.TSIGKey{Name:%q, Algorithm:%q, Secret:\"REDACTED\"}", k.Name, k.Algorithm)
+}
+
+// TransferConfig contains the settings of a zone relevant for transfers between CloudDNS and other name servers.
+type TransferConfig struct {
+	// IsMaster designates if CloudDNS is primary for the zone. If false, CloudDNS is secondary
+	// and transfers the zone from MasterNS.
+	IsMaster bool
+	// MasterNS contains the IP addresses of the primary name servers of the zone if CloudDNS is secondary,
+	// separated by commas.
+	MasterNS string
+	// NotifyAllowedIPs are the addresses of primaries allowed to notify CloudDNS about changes.
+	NotifyAllowedIPs []string
+	// AllowTransferIPs are the addresses of secondaries allowed to transfer the zone from CloudDNS.
+	AllowTransferIPs []string
+	// TSIGKeys authenticate zone transfers.
+	TSIGKeys []TSIGKey
+}
+
+// Validate checks that all addresses are valid IP addresses and a secondary zone has a primary name server.
+func (c TransferConfig) Validate() error {
+	masters := strings.FieldsFunc(c.MasterNS, func(r rune) bool {
+		return r == ',' || unicode.IsSpace(r)
+	})
+	if !c.IsMaster && len(masters) == 0 {
+		return fmt.Errorf("%w: secondary zone requires a master name server", ErrInvalidTransferConfig)
+	}
+
+	ips := append(append(masters, c.NotifyAllowedIPs...), c.AllowTransferIPs...)
+	for _, ip := range ips {
+		if _, err := netip.ParseAddr(ip); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidTransferConfig, err)
+		}
+	}
+
+	for _, key := range c.TSIGKeys {
+		if key.Name == "" || key.Algorithm == "" || key.Secret == "" {
+			return fmt.Errorf("%w: TSIG key %v is incomplete", ErrInvalidTransferConfig, key)
+		}
+	}
+
+	return nil
+}
+
+// GetTransferConfig returns the transfer settings of the given zone.
+func (a api) GetTransferConfig(ctx context.Context, name string) (TransferConfig, error) {
+	zone, err := a.Get(client.WithRedactedBody(ctx), name)
+	if err != nil {
+		return TransferConfig{}, err
+	}
+
+	return transferConfigOf(name, zone)
+}
+
+func transferConfigOf(name string, zone Zone) (TransferConfig, error) {
+	if zone.Definition == nil {
+		return TransferConfig{}, fmt.Errorf("zone get response for '%s' contains no definition", name)
+	}
+
+	return TransferConfig{
+		IsMaster:         zone.IsMaster,
+		MasterNS:         zone.MasterNS,
+		NotifyAllowedIPs: zone.NotifyAllowedIPs,
+		AllowTransferIPs: zone.AllowTransferIPs,
+		TSIGKeys:         zone.TSIGKeys,
+	}, nil
+}
+
+// SetTransferConfig validates and replaces the transfer settings of the given zone, leaving its other settings unchanged.
+func (a api) SetTransferConfig(ctx context.Context, name string, config TransferConfig) (Zone, error) {
+	if err := config.Validate(); err != nil {
+		return Zone{}, err
+	}
+
+	ctx = client.WithRedactedBody(ctx)
+	zone, err := a.Get(ctx, name)
+	if err != nil {
+		return Zone{}, err
+	}
+
+	definition, err := withTransferConfig(name, zone, config)
+	if err != nil {
+		return Zone{}, err
+	}
+
+	return a.Update(ctx, name, definition)
+}
+
+// withTransferConfig returns the definition of the zone with its transfer settings replaced.
+func withTransferConfig(name string, zone Zone, config TransferConfig) (Definition, error) {
+	if zone.Definition == nil {
+		return Definition{}, fmt.Errorf("zone get response for '%s' contains no definition", name)
+	}
+
+	definition := *zone.Definition
+	definition.ZoneName = name
+	definition.IsMaster = config.IsMaster
+	definition.MasterNS = config.MasterNS
+	definition.NotifyAllowedIPs = config.NotifyAllowedIPs
+	definition.AllowTransferIPs = config.AllowTransferIPs
+	definition.TSIGKeys = config.TSIGKeys
+
+	return definition, nil
+}
@@ -0,0 +1,159 @@
+package zone_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/anexia-it/go-anxcloud/pkg/client"
+	"github.com/anexia-it/go-anxcloud/pkg/clouddns/zone"
+	"github.com/stretchr/testify/assert"
+)
+
+const tsigSecret = "c2VjcmV0LXRzaWcta2V5"
+
+// newTransferMock serves the secondary zone example.com with a TSIG key and stores the definition of updates.
+func newTransferMock(t *testing.T, updated *zone.Definition, updates *int) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/clouddns/v1/zone.json/example.com" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":{"code":404,"message":"not found"}}`))
+			return
+		}
+
+		definition := zone.Definition{
+			Name:             "example.com",
+			ZoneName:         "example.com",
+			AdminEmail:       "admin@example.com",
+			Refresh:          3600,
+			MasterNS:         "192.0.2.53",
+			NotifyAllowedIPs: []string{"192.0.2.53"},
+			TSIGKeys:         []zone.TSIGKey{{Name: "transfer.", Algorithm: "hmac-sha256", Secret: tsigSecret}},
+		}
+		if r.Method == http.MethodPut {
+			*updates++
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(updated))
+			definition = *updated
+		}
+		assert.NoError(t, json.NewEncoder(w).Encode(zone.Zone{Definition: &definition}))
+	})
+}
+
+func TestGetTransferConfig(t *testing.T) {
+	var updated zone.Definition
+	updates := 0
+	c, server := client.NewTestClient(nil, newTransferMock(t, &updated, &updates))
+	defer server.Close()
+
+	config, err := zone.NewAPI(c).GetTransferConfig(context.Background(), "example.com")
+	if assert.NoError(t, err) {
+		assert.False(t, config.IsMaster)
+		assert.EqualValues(t, "192.0.2.53", config.MasterNS)
+		assert.EqualValues(t, []string{"192.0.2.53"}, config.NotifyAllowedIPs)
+		if assert.Len(t, config.TSIGKeys, 1) {
+			assert.EqualValues(t, tsigSecret, config.TSIGKeys[0].Secret)
+		}
+	}
+
+	_, err = zone.NewAPI(c).GetTransferConfig(context.Background(), "example.org")
+	var responseErr *client.ResponseError
+	assert.True(t, errors.As(err, &responseErr), "expected ResponseError but got %v", err)
+}
+
+func TestSetTransferConfig(t *testing.T) {
+	var updated zone.Definition
+	updates := 0
+	c, server := client.NewTestClient(nil, newTransferMock(t, &updated, &updates))
+	defer server.Close()
+	api := zone.NewAPI(c)
+
+	config := zone.TransferConfig{
+		IsMaster:         true,
+		AllowTransferIPs: []string{"192.0.2.54", "2001:db8::53"},
+		TSIGKeys:         []zone.TSIGKey{{Name: "new.", Algorithm: "hmac-sha512", Secret: "bmV3"}},
+	}
+	_, err := api.SetTransferConfig(context.Background(), "example.com", config)
+	if assert.NoError(t, err) {
+		assert.True(t, updated.IsMaster)
+		assert.Empty(t, updated.MasterNS)
+		assert.Empty(t, updated.NotifyAllowedIPs)
+		assert.EqualValues(t, config.AllowTransferIPs, updated.AllowTransferIPs)
+		assert.EqualValues(t, config.TSIGKeys, updated.TSIGKeys)
+		assert.EqualValues(t, "admin@example.com", updated.AdminEmail, "other settings must be kept")
+		assert.EqualValues(t, 3600, updated.Refresh, "other settings must be kept")
+	}
+
+	for name, invalid := range map[string]zone.TransferConfig{
+		"NoMaster":        {IsMaster: false},
+		"EmptyMaster":     {IsMaster: false, MasterNS: " , "},
+		"MasterHostname":  {IsMaster: false, MasterNS: "ns1.example.org"},
+		"InvalidMaster":   {IsMaster: false, MasterNS: "192.0.2.53, 192.0.2.300"},
+		"InvalidNotifyIP": {IsMaster: true, NotifyAllowedIPs: []string{"ns1.example.org"}},
+		"InvalidAXFRIP":   {IsMaster: true, AllowTransferIPs: []string{"192.0.2.300"}},
+		"IncompleteKey":   {IsMaster: true, TSIGKeys: []zone.TSIGKey{{Name: "key.", Algorithm: "hmac-sha256"}}},
+	} {
+		_, err := api.SetTransferConfig(context.Background(), "example.com", invalid)
+		assert.True(t, errors.Is(err, zone.ErrInvalidTransferConfig), "%s: expected ErrInvalidTransferConfig but got %v", name, err)
+	}
+	assert.Equal(t, 1, updates, "invalid configurations must not be sent")
+}
+
+func TestTransferConfigValidate(t *testing.T) {
+	for _, masters := range []string{"192.0.2.53", "192.0.2.53,2001:db8::53", " 192.0.2.53, 192.0.2.54 "} {
+		assert.NoError(t, zone.TransferConfig{MasterNS: masters}.Validate(), masters)
+	}
+}
+
+// redirectTransport sends every request to target, so a client with the default base URL talks to a test server.
+type redirectTransport struct {
+	target *url.URL
+}
+
+func (rt redirectTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	r = r.Clone(r.Context())
+	r.URL.Scheme = rt.target.Scheme
+	r.URL.Host = rt.target.Host
+
+	return http.DefaultTransport.RoundTrip(r)
+}
+
+func TestTransferConfigRedaction(t *testing.T) {
+	key := zone.TSIGKey{Name: "transfer.", Algorithm: "hmac-sha256", Secret: tsigSecret}
+	for _, formatted := range []string{fmt.Sprint(key), fmt.Sprintf("%v", key), fmt.Sprintf("%+v", key), fmt.Sprintf("%#v", key),
+		fmt.Sprint(zone.TransferConfig{TSIGKeys: []zone.TSIGKey{key}})} {
+		assert.NotContains(t, formatted, tsigSecret)
+		assert.Contains(t, formatted, "transfer.")
+	}
+
+	var updated zone.Definition
+	updates := 0
+	server := httptest.NewServer(newTransferMock(t, &updated, &updates))
+	defer server.Close()
+
+	target, err := url.Parse(server.URL)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	logs := bytes.Buffer{}
+	httpClient := &http.Client{Transport: redirectTransport{target}}
+	c, err := client.New(client.TokenFromString("token"), client.HTTPClient(httpClient), client.LogWriter(&logs))
+	if !assert.NoError(t, err) {
+		return
+	}
+	api := zone.NewAPI(c)
+
+	_, err = api.GetTransferConfig(context.Background(), "example.com")
+	assert.NoError(t, err)
+	_, err = api.SetTransferConfig(context.Background(), "example.com", zone.TransferConfig{IsMaster: true, TSIGKeys: []zone.TSIGKey{key}})
+	assert.NoError(t, err)
+
+	assert.Contains(t, logs.String(), "/api/clouddns/v1/zone.json/example.com", "requests must still be logged")
+	assert.NotContains(t, logs.String(), tsigSecret)
+}
@@ -0,0 +1,134 @@
+package zone
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// soaCacheTTL is the time the SOA of a zone is cached by an API returned from WithTTLValidation.
+const soaCacheTTL = 5 * time.Minute
+
+// ErrTTLBelowMinimum is raised if the TTL of a record is below the minimum of the SOA of its zone.
+var ErrTTLBelowMinimum = errors.New("record TTL is below zone minimum")
+
+// TTLBelowMinimumError is returned if the TTL of a record is below the minimum of the SOA of its zone.
+type TTLBelowMinimumError struct {
+	Zone    string
+	Record  RecordRequest
+	Minimum int
+}
+
+func (e *TTLBelowMinimumError) Error() string {
+	return fmt.Sprintf("%v: %s record '%s' in zone '%s' has TTL %d, minimum is %d", ErrTTLBelowMinimum,
+		e.Record.Type, e.Record.Name, e.Zone, e.Record.TTL, e.Minimum)
+}
+
+// Is reports whether target is ErrTTLBelowMinimum.
+func (e *TTLBelowMinimumError) Is(target error) bool {
+	return target == ErrTTLBelowMinimum
+}
+
+// TTLPolicy decides what happens to records with a TTL below the zone minimum.
+type TTLPolicy int
+
+const (
+	// TTLWarn passes a TTLBelowMinimumError to the warning callback and sends the request anyway.
+	TTLWarn TTLPolicy = iota
+	// TTLReject fails with a TTLBelowMinimumError without sending the request.
+	TTLReject
+)
+
+// WithTTLValidation returns an API checking the TTL of records created or updated via NewRecord and UpdateRecord
+// against the minimum of the SOA of their zone. Records without TTL use the zone default and are not checked.
+//
+// With TTLWarn, onWarning is called for records below the minimum; it may be nil with TTLReject.
+// The SOA of every zone is cached for some minutes, so bulk operations fetch it only once. If a zone
+// has no SOA record, its records are not checked.
+func WithTTLValidation(a API, policy TTLPolicy, onWarning func(err error)) API {
+	return &ttlValidatingAPI{
+		API:       a,
+		policy:    policy,
+		onWarning: onWarning,
+		soas:      make(map[string]cachedSOA),
+	}
+}
+
+type ttlValidatingAPI struct {
+	API
+	policy    TTLPolicy
+	onWarning func(err error)
+
+	mu   sync.Mutex
+	soas map[string]cachedSOA
+}
+
+type cachedSOA struct {
+	soa       SOA
+	available bool
+	fetchedAt time.Time
+}
+
+func (a *ttlValidatingAPI) NewRecord(ctx context.Context, zone string, record RecordRequest) (Zone, error) {
+	if err := a.checkTTL(ctx, zone, record); err != nil {
+		return Zone{}, err
+	}
+
+	return a.API.NewRecord(ctx, zone, record)
+}
+
+func (a *ttlValidatingAPI) UpdateRecord(ctx context.Context, zone string, id uuid.UUID, record RecordRequest) (Zone, error) {
+	if err := a.checkTTL(ctx, zone, record); err != nil {
+		return Zone{}, err
+	}
+
+	return a.API.UpdateRecord(ctx, zone, id, record)
+}
+
+func (a *ttlValidatingAPI) checkTTL(ctx context.Context, zone string, record RecordRequest) error {
+	if record.TTL <= 0 {
+		return nil
+	}
+
+	soa, available, err := a.soa(ctx, zone)
+	if err != nil {
+		return fmt.Errorf("could not check TTL against zone minimum: %w", err)
+	}
+	if !available || record.TTL >= soa.Minimum {
+		return nil
+	}
+
+	belowMinimum := &TTLBelowMinimumError{Zone: zone, Record: record, Minimum: soa.Minimum}
+	if a.policy == TTLReject {
+		return belowMinimum
+	}
+	if a.onWarning != nil {
+		a.onWarning(belowMinimum)
+	}
+
+	return nil
+}
+
+// soa returns the cached SOA of the zone, fetching it if it expired. available is false if the zone has no SOA.
+func (a *ttlValidatingAPI) soa(ctx context.Context, zone string) (SOA, bool, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if cached, ok := a.soas[zone]; ok && time.Since(cached.fetchedAt) < soaCacheTTL {
+		return cached.soa, cached.available, nil
+	}
+
+	soa, err := GetSOA(ctx, a.API, zone)
+	if err != nil && !errors.Is(err, ErrNoSOA) {
+		return SOA{}, false, err
+	}
+
+	cached := cachedSOA{soa: soa, available: err == nil, fetchedAt: time.Now()}
+	a.soas[zone] = cached
+
+	return cached.soa, cached.available, nil
+}
@@ -0,0 +1,51 @@
+package zone_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/anexia-it/go-anxcloud/pkg/clouddns/zone"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithTTLValidation(t *testing.T) {
+	ctx := context.Background()
+	memory := zone.NewInMemoryAPI()
+	_, err := memory.Create(ctx, zone.Definition{ZoneName: "example.com"})
+	assert.NoError(t, err)
+	_, err = memory.NewRecord(ctx, "example.com", zone.RecordRequest{
+		Name: "@", Type: "SOA", RData: "ns1.example.com. admin.example.com. 2024010101 3600 600 86400 300",
+	})
+	assert.NoError(t, err)
+
+	soa, err := zone.GetSOA(ctx, memory, "example.com")
+	if assert.NoError(t, err) {
+		assert.Equal(t, 300, soa.Minimum)
+	}
+
+	t.Run("Reject", func(t *testing.T) {
+		api := zone.WithTTLValidation(memory, zone.TTLReject, nil)
+		_, err := api.NewRecord(ctx, "example.com", zone.RecordRequest{Name: "www", Type: "A", RData: "192.0.2.1", TTL: 60})
+		assert.True(t, errors.Is(err, zone.ErrTTLBelowMinimum), "expected ErrTTLBelowMinimum but got %v", err)
+
+		_, err = api.NewRecord(ctx, "example.com", zone.RecordRequest{Name: "www", Type: "A", RData: "192.0.2.1", TTL: 300})
+		assert.NoError(t, err)
+		_, err = api.NewRecord(ctx, "example.com", zone.RecordRequest{Name: "mail", Type: "A", RData: "192.0.2.2"})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Warn", func(t *testing.T) {
+		var warnings []error
+		api := zone.WithTTLValidation(memory, zone.TTLWarn, func(err error) {
+			warnings = append(warnings, err)
+		})
+		_, err := api.NewRecord(ctx, "example.com", zone.RecordRequest{Name: "ftp", Type: "A", RData: "192.0.2.3", TTL: 60})
+		assert.NoError(t, err)
+		if assert.Len(t, warnings, 1) {
+			var belowMinimum *zone.TTLBelowMinimumError
+			assert.True(t, errors.As(warnings[0], &belowMinimum))
+			assert.Equal(t, 300, belowMinimum.Minimum)
+		}
+	})
+}
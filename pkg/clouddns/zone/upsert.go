@@ -0,0 +1,95 @@
+package zone
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrAmbiguousRecord is returned by UpsertRecord if more than one existing record matches the desired one.
+var ErrAmbiguousRecord = errors.New("multiple records match")
+
+// UpsertOption configures UpsertRecord.
+type UpsertOption func(o *upsertOptions)
+
+type upsertOptions struct {
+	matchRData bool
+	first      bool
+}
+
+// MatchRData lets UpsertRecord also compare the rdata of records, as needed for record sets holding multiple
+// values, e.g. several A records of the same name. Records with other rdata are left alone and a record is
+// created if none has the desired rdata.
+func MatchRData() UpsertOption {
+	return func(o *upsertOptions) {
+		o.matchRData = true
+	}
+}
+
+// UpsertFirst lets UpsertRecord update the first of multiple matching records instead of returning
+// ErrAmbiguousRecord. A record already matching the desired one completely is preferred.
+func UpsertFirst() UpsertOption {
+	return func(o *upsertOptions) {
+		o.first = true
+	}
+}
+
+// UpsertRecord updates the record of zone with the name, type and region of record, or creates it if there is none.
+//
+// Calling it repeatedly with the same record converges to a single record without further changes, a record
+// already matching is not updated again. If multiple records match, an error wrapping ErrAmbiguousRecord is
+// returned unless UpsertFirst is given. The returned zone is the one after the change, or the current one if no
+// change was needed.
+func UpsertRecord(ctx context.Context, a API, zone string, record RecordRequest, opts ...UpsertOption) (Zone, error) {
+	o := upsertOptions{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if err := record.Validate(); err != nil {
+		return Zone{}, fmt.Errorf("invalid %s record '%s': %w", record.Type, record.Name, err)
+	}
+
+	existing, err := a.ListRecords(ctx, zone)
+	if err != nil {
+		return Zone{}, fmt.Errorf("could not list records of zone '%s': %w", zone, err)
+	}
+
+	var candidates []Record
+	for _, candidate := range existing {
+		if candidate.Name != record.Name || !strings.EqualFold(candidate.Type, record.Type) || candidate.Region != record.Region {
+			continue
+		}
+		if o.matchRData && !sameRecord(candidate, record) {
+			continue
+		}
+		candidates = append(candidates, candidate)
+	}
+
+	switch {
+	case len(candidates) == 0:
+		return a.NewRecord(ctx, zone, record)
+	case len(candidates) > 1 && !o.first:
+		return Zone{}, fmt.Errorf("%w: %d %s records named '%s' in zone '%s'", ErrAmbiguousRecord, len(candidates),
+			record.Type, record.Name, zone)
+	}
+
+	target := candidates[0]
+	for _, candidate := range candidates {
+		if upToDate(candidate, record) {
+			target = candidate
+			break
+		}
+	}
+	if upToDate(target, record) {
+		return a.Get(ctx, zone)
+	}
+
+	return a.UpdateRecord(ctx, zone, target.Identifier, record)
+}
+
+// upToDate reports whether the existing record needs no update to become the desired one.
+func upToDate(existing Record, desired RecordRequest) bool {
+	return sameRecord(existing, desired) && (desired.TTL == 0 || existing.TTL != nil && *existing.TTL == desired.TTL)
+}
@@ -0,0 +1,48 @@
+package zone_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/anexia-it/go-anxcloud/pkg/clouddns/zone"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpsertRecord(t *testing.T) {
+	ctx := context.Background()
+	api := &changeCountingAPI{InMemoryAPI: zone.NewInMemoryAPI()}
+	_, err := api.Create(ctx, zone.Definition{ZoneName: "example.com"})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	for i := 0; i < 2; i++ {
+		_, err = zone.UpsertRecord(ctx, api, "example.com", zone.RecordRequest{Name: "www", Type: "A", RData: "192.0.2.1", TTL: 300})
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 1, api.changes)
+
+	_, err = zone.UpsertRecord(ctx, api, "example.com", zone.RecordRequest{Name: "www", Type: "A", RData: "192.0.2.2", TTL: 300})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, api.changes)
+	assert.Equal(t, []string{"www A 192.0.2.2 300"}, recordSet(t, api))
+
+	_, err = zone.UpsertRecord(ctx, api, "example.com", zone.RecordRequest{Name: "www", Type: "A", RData: "192.0.2.3", TTL: 300}, zone.MatchRData())
+	assert.NoError(t, err)
+	_, err = zone.UpsertRecord(ctx, api, "example.com", zone.RecordRequest{Name: "www", Type: "A", RData: "192.0.2.3", TTL: 600}, zone.MatchRData())
+	assert.NoError(t, err)
+	assert.Equal(t, 4, api.changes)
+	assert.Equal(t, []string{"www A 192.0.2.2 300", "www A 192.0.2.3 600"}, recordSet(t, api))
+
+	_, err = zone.UpsertRecord(ctx, api, "example.com", zone.RecordRequest{Name: "www", Type: "A", RData: "192.0.2.4"})
+	assert.True(t, errors.Is(err, zone.ErrAmbiguousRecord), "expected ErrAmbiguousRecord but got %v", err)
+
+	_, err = zone.UpsertRecord(ctx, api, "example.com", zone.RecordRequest{Name: "www", Type: "A", RData: "192.0.2.3", TTL: 600}, zone.UpsertFirst())
+	assert.NoError(t, err)
+	assert.Equal(t, 4, api.changes)
+
+	_, err = zone.UpsertRecord(ctx, api, "example.com", zone.RecordRequest{Name: "www", Type: "A", RData: "192.0.2"})
+	assert.True(t, errors.Is(err, zone.ErrInvalidRData), "expected ErrInvalidRData but got %v", err)
+	assert.Equal(t, 4, api.changes)
+}
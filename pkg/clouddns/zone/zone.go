@@ -7,6 +7,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"github.com/anexia-it/go-anxcloud/pkg/client"
 	uuid "github.com/satori/go.uuid"
 	"net/http"
 	"time"
@@ -92,6 +93,12 @@ type Definition struct {
 
 	// Configured DNS servers (empty means default servers).
 	DNSServers []DNSServer `json:"dns_servers,omitempty"`
+
+	// IP addresses allowed to transfer the zone from CloudDNS (AXFR).
+	AllowTransferIPs []string `json:"allow_transfer_ips,omitempty"`
+
+	// TSIG keys used to authenticate zone transfers.
+	TSIGKeys []TSIGKey `json:"tsig_keys,omitempty"`
 }
 
 type Zone struct {
@@ -145,8 +152,8 @@ func (a api) List(ctx context.Context) ([]Zone, error) {
 	if err != nil {
 		return nil, fmt.Errorf("could not execute zone list request: %w", err)
 	}
-	if httpResponse.StatusCode >= 500 && httpResponse.StatusCode < 600 {
-		return nil, fmt.Errorf("could not execute zone list request, got response %s", httpResponse.Status)
+	if err := client.CheckResponse(httpResponse, "execute zone list request"); err != nil {
+		return nil, err
 	}
 
 	var responsePayload listResponse
@@ -177,8 +184,8 @@ func (a api) Get(ctx context.Context, name string) (Zone, error) {
 	if err != nil {
 		return Zone{}, fmt.Errorf("could not execute zone get request: %w", err)
 	}
-	if httpResponse.StatusCode >= 500 && httpResponse.StatusCode < 600 {
-		return Zone{}, fmt.Errorf("could not execute zone get request, got response %s", httpResponse.Status)
+	if err := client.CheckResponse(httpResponse, "execute zone get request"); err != nil {
+		return Zone{}, err
 	}
 
 	var responsePayload Zone
@@ -213,8 +220,8 @@ func (a api) Create(ctx context.Context, create Definition) (Zone, error) {
 	if err != nil {
 		return Zone{}, fmt.Errorf("could not execute zone create request: %w", err)
 	}
-	if httpResponse.StatusCode >= 500 && httpResponse.StatusCode < 600 {
-		return Zone{}, fmt.Errorf("could not execute zone create request, got response %s", httpResponse.Status)
+	if err := client.CheckResponse(httpResponse, "execute zone create request"); err != nil {
+		return Zone{}, err
 	}
 
 	var responsePayload Zone
@@ -230,9 +237,10 @@ func (a api) Create(ctx context.Context, create Definition) (Zone, error) {
 // Update zone API method
 func (a api) Update(ctx context.Context, name string, update Definition) (Zone, error) {
 	url := fmt.Sprintf(
-		"%s%s",
+		"%s%s/%s",
 		a.client.BaseURL(),
 		pathPrefix,
+		name,
 	)
 
 	requestData := bytes.Buffer{}
@@ -249,8 +257,8 @@ func (a api) Update(ctx context.Context, name string, update Definition) (Zone,
 	if err != nil {
 		return Zone{}, fmt.Errorf("could not execute zone update request: %w", err)
 	}
-	if httpResponse.StatusCode >= 500 && httpResponse.StatusCode < 600 {
-		return Zone{}, fmt.Errorf("could not execute zone update request, got response %s", httpResponse.Status)
+	if err := client.CheckResponse(httpResponse, "execute zone update request"); err != nil {
+		return Zone{}, err
 	}
 
 	var responsePayload Zone
@@ -263,7 +271,7 @@ func (a api) Update(ctx context.Context, name string, update Definition) (Zone,
 	return responsePayload, nil
 }
 
-//Delete zone API method
+// Delete zone API method
 func (a api) Delete(ctx context.Context, name string) error {
 	url := fmt.Sprintf(
 		"%s%s/%s",
@@ -278,11 +286,11 @@ func (a api) Delete(ctx context.Context, name string) error {
 	}
 
 	httpResponse, err := a.client.Do(req)
-	if err != nil && httpResponse.StatusCode != http.StatusNoContent {
+	if err != nil {
 		return fmt.Errorf("could not execute zone delete request: %w", err)
 	}
-	if httpResponse.StatusCode >= 500 && httpResponse.StatusCode < 600 {
-		return fmt.Errorf("could not execute zone delete request, got response %s", httpResponse.Status)
+	if err := client.CheckResponse(httpResponse, "execute zone delete request"); err != nil {
+		return err
 	}
 
 	return httpResponse.Body.Close()
@@ -311,8 +319,8 @@ func (a api) Apply(ctx context.Context, name string, changeset ChangeSet) ([]Rec
 	if err != nil {
 		return nil, fmt.Errorf("could not execute zone changeset request: %w", err)
 	}
-	if httpResponse.StatusCode >= 500 && httpResponse.StatusCode < 600 {
-		return nil, fmt.Errorf("could not execute zone changeset request, got response %s", httpResponse.Status)
+	if err := client.CheckResponse(httpResponse, "execute zone changeset request"); err != nil {
+		return nil, err
 	}
 
 	var responsePayload []Record
@@ -348,8 +356,8 @@ func (a api) Import(ctx context.Context, name string, zoneData Import) (Revision
 	if err != nil {
 		return Revision{}, fmt.Errorf("could not execute zone import request: %w", err)
 	}
-	if httpResponse.StatusCode >= 500 && httpResponse.StatusCode < 600 {
-		return Revision{}, fmt.Errorf("could not execute zone import request, got response %s", httpResponse.Status)
+	if err := client.CheckResponse(httpResponse, "execute zone import request"); err != nil {
+		return Revision{}, err
 	}
 
 	var responsePayload Revision
@@ -0,0 +1,244 @@
+package zone_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/anexia-it/go-anxcloud/pkg/client"
+	"github.com/anexia-it/go-anxcloud/pkg/clouddns/zone"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetRetryAfter(t *testing.T) {
+	c, server := client.NewTestClient(nil, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte(`{"error":{"code":503,"message":"maintenance"}}`))
+	}))
+	defer server.Close()
+
+	_, err := zone.NewAPI(c).Get(context.Background(), "example.com")
+
+	var retryAfterErr *client.RetryAfterError
+	if assert.True(t, errors.As(err, &retryAfterErr), "expected RetryAfterError but got %v", err) {
+		assert.EqualValues(t, 30*time.Second, retryAfterErr.RetryAfter)
+	}
+	var responseErr *client.ResponseError
+	if assert.True(t, errors.As(err, &responseErr), "expected ResponseError but got %v", err) {
+		assert.EqualValues(t, "maintenance", responseErr.ErrorData.Message)
+	}
+}
+
+func TestListRecordsFields(t *testing.T) {
+	c, server := client.NewTestClient(nil, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.EqualValues(t, "identifier,name,type", r.URL.Query().Get("fields"))
+		_, _ = w.Write([]byte(`[{"identifier":"6ba7b810-9dad-11d1-80b4-00c04fd430c8","name":"www","Type":"A","rdata":"192.0.2.1","ttl":300}]`))
+	}))
+	defer server.Close()
+
+	records, err := zone.NewAPI(c).ListRecords(context.Background(), "example.com", zone.Fields(zone.FieldName, zone.FieldType))
+	if assert.NoError(t, err) && assert.Len(t, records, 1) {
+		assert.EqualValues(t, "6ba7b810-9dad-11d1-80b4-00c04fd430c8", records[0].Identifier.String())
+		assert.EqualValues(t, "www", records[0].Name)
+		assert.EqualValues(t, "A", records[0].Type)
+		assert.Empty(t, records[0].RData)
+		assert.Nil(t, records[0].TTL)
+	}
+}
+
+func TestListRecordsTypes(t *testing.T) {
+	var typeQuery string
+	c, server := client.NewTestClient(nil, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		typeQuery = r.URL.Query().Get("type")
+		_, _ = w.Write([]byte(`[{"name":"www","Type":"A","rdata":"192.0.2.1"},{"name":"www","Type":"aaaa","rdata":"2001:db8::1"},{"name":"@","Type":"txt","rdata":"v=spf1 -all"}]`))
+	}))
+	defer server.Close()
+
+	records, err := zone.NewAPI(c).ListRecords(context.Background(), "example.com", zone.Types("a", zone.TypeTXT, ""))
+	assert.EqualValues(t, "A,TXT", typeQuery)
+	if assert.NoError(t, err) && assert.Len(t, records, 2) {
+		assert.EqualValues(t, "A", records[0].Type)
+		assert.EqualValues(t, "txt", records[1].Type)
+	}
+
+	records, err = zone.NewAPI(c).ListRecords(context.Background(), "example.com", zone.Types(""))
+	assert.Empty(t, typeQuery)
+	assert.NoError(t, err)
+	assert.Len(t, records, 3)
+}
+
+func TestGetRecord(t *testing.T) {
+	const id = "6ba7b810-9dad-11d1-80b4-00c04fd430c8"
+	c, server := client.NewTestClient(nil, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/clouddns/v1/zone.json/example.com/records/"+id {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":{"code":404,"message":"not found"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"identifier":"` + id + `","name":"www","Type":"A","rdata":"192.0.2.1"}`))
+	}))
+	defer server.Close()
+
+	api := zone.NewAPI(c)
+	record, err := api.GetRecord(context.Background(), "example.com", uuid.FromStringOrNil(id))
+	if assert.NoError(t, err) {
+		assert.EqualValues(t, "www", record.Name)
+		assert.EqualValues(t, "192.0.2.1", record.RData)
+	}
+
+	_, err = api.GetRecord(context.Background(), "example.com", uuid.NewV4())
+	assert.True(t, errors.Is(err, zone.ErrRecordNotFound), "expected ErrRecordNotFound but got %v", err)
+}
+
+// rawClient sends requests without turning error responses into errors, like custom client.Client implementations may.
+type rawClient struct {
+	baseURL string
+}
+
+func (c rawClient) BaseURL() string {
+	return c.baseURL
+}
+
+func (c rawClient) Do(req *http.Request) (*http.Response, error) {
+	return http.DefaultClient.Do(req)
+}
+
+func TestZoneErrorResponses(t *testing.T) {
+	_, server := client.NewTestClient(nil, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":{"code":400,"message":"invalid zone"}}`))
+	}))
+	defer server.Close()
+
+	a := zone.NewAPI(rawClient{server.URL})
+	ctx := context.Background()
+
+	_, listErr := a.List(ctx)
+	_, getErr := a.Get(ctx, "example.com")
+	_, createErr := a.Create(ctx, zone.Definition{ZoneName: "example.com"})
+	_, updateErr := a.Update(ctx, "example.com", zone.Definition{ZoneName: "example.com"})
+	deleteErr := a.Delete(ctx, "example.com")
+
+	for _, err := range []error{listErr, getErr, createErr, updateErr, deleteErr} {
+		var responseErr *client.ResponseError
+		if assert.True(t, errors.As(err, &responseErr), "expected ResponseError but got %v", err) {
+			assert.EqualValues(t, "invalid zone", responseErr.ErrorData.Message)
+		}
+	}
+}
+
+func TestDeleteUnreachable(t *testing.T) {
+	_, server := client.NewTestClient(nil, http.NotFoundHandler())
+	server.Close()
+
+	assert.Error(t, zone.NewAPI(rawClient{server.URL}).Delete(context.Background(), "example.com"))
+}
+
+func TestRecordErrorResponses(t *testing.T) {
+	for _, status := range []int{http.StatusBadRequest, http.StatusNotFound} {
+		_, server := client.NewTestClient(nil, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(`{"error":{"code":` + strconv.Itoa(status) + `,"message":"rejected"}}`))
+		}))
+
+		a := zone.NewAPI(rawClient{server.URL})
+		ctx := context.Background()
+		id := uuid.NewV4()
+		record := zone.RecordRequest{Name: "www", Type: "A", RData: "192.0.2.1"}
+
+		_, listErr := a.ListRecords(ctx, "example.com")
+		_, newErr := a.NewRecord(ctx, "example.com", record)
+		_, updateErr := a.UpdateRecord(ctx, "example.com", id, record)
+		deleteErr := a.DeleteRecord(ctx, "example.com", id)
+		_, pageErr := a.RecordPages("example.com").GetPage(ctx, 1, 10)
+
+		for _, err := range []error{listErr, newErr, updateErr, deleteErr, pageErr} {
+			var responseErr *client.ResponseError
+			if assert.True(t, errors.As(err, &responseErr), "expected ResponseError but got %v", err) {
+				assert.EqualValues(t, status, responseErr.Response.StatusCode)
+				assert.EqualValues(t, "rejected", responseErr.ErrorData.Message)
+			}
+		}
+
+		_, err := a.GetRecord(ctx, "example.com", id)
+		if status == http.StatusNotFound {
+			assert.True(t, errors.Is(err, zone.ErrRecordNotFound), "expected ErrRecordNotFound but got %v", err)
+		} else {
+			assert.Error(t, err)
+		}
+
+		server.Close()
+	}
+}
+
+func TestNewRecordUnique(t *testing.T) {
+	ctx := context.Background()
+	store := newRecordStore(t, "example.com")
+	store.add("example.com", zone.Record{Name: "www", Type: "A", RData: "192.0.2.1"})
+	existing := store.records["example.com"][0]
+	c, server := client.NewTestClient(nil, store)
+	defer server.Close()
+	api := zone.NewAPI(c)
+
+	_, err := api.NewRecord(ctx, "example.com", zone.RecordRequest{Name: "www", Type: "a", RData: "192.0.2.1", EnsureUnique: true})
+	var existsErr *zone.AlreadyExistsError
+	if assert.True(t, errors.As(err, &existsErr), "expected AlreadyExistsError but got %v", err) {
+		assert.Equal(t, existing.Identifier, existsErr.Record.Identifier)
+		assert.True(t, errors.Is(err, zone.ErrAlreadyExists))
+	}
+
+	for _, request := range []zone.RecordRequest{
+		{Name: "www", Type: "A", RData: "192.0.2.2", EnsureUnique: true},
+		{Name: "www", Type: "TXT", RData: "192.0.2.1", EnsureUnique: true},
+		{Name: "www", Type: "A", RData: "192.0.2.1"},
+	} {
+		_, err = api.NewRecord(ctx, "example.com", request)
+		assert.NoError(t, err, "%+v", request)
+	}
+	assert.Len(t, store.records["example.com"], 4, "only the conflicting record must be rejected")
+
+	_, err = api.NewRecord(ctx, "example.org", zone.RecordRequest{Name: "www", Type: "A", RData: "192.0.2.1", EnsureUnique: true})
+	var responseErr *client.ResponseError
+	assert.True(t, errors.As(err, &responseErr), "expected ResponseError but got %v", err)
+}
+
+func TestListRecordsByType(t *testing.T) {
+	c, server := client.NewTestClient(nil, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/clouddns/v1/zone.json/example.com/records" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":{"code":404,"message":"not found"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`[
+			{"name":"www","Type":"A","rdata":"192.0.2.1"},
+			{"name":"www","Type":"a","rdata":"192.0.2.2"},
+			{"name":"www","Type":" aaaa ","rdata":"2001:db8::1"},
+			{"name":"@","Type":"TXT","rdata":"v=spf1 -all"},
+			{"name":"@","Type":"svcb","rdata":"1 ."}
+		]`))
+	}))
+	defer server.Close()
+	api := zone.NewAPI(c)
+
+	grouped, err := api.ListRecordsByType(context.Background(), "example.com")
+	if assert.NoError(t, err) {
+		assert.Len(t, grouped, 4)
+		if assert.Len(t, grouped[zone.TypeA], 2) {
+			assert.EqualValues(t, "192.0.2.1", grouped[zone.TypeA][0].RData, "the listing order must be kept")
+			assert.EqualValues(t, "192.0.2.2", grouped[zone.TypeA][1].RData)
+		}
+		assert.Len(t, grouped[zone.TypeAAAA], 1)
+		assert.Len(t, grouped[zone.TypeTXT], 1)
+		assert.Len(t, grouped[zone.RecordType("SVCB")], 1, "types without constant must be grouped as well")
+		assert.Empty(t, grouped[zone.TypeMX])
+	}
+
+	_, err = api.ListRecordsByType(context.Background(), "example.org")
+	var responseErr *client.ResponseError
+	assert.True(t, errors.As(err, &responseErr), "expected ResponseError but got %v", err)
+}
@@ -3,6 +3,7 @@ package core
 
 import (
 	"github.com/anexia-it/go-anxcloud/pkg/client"
+	"github.com/anexia-it/go-anxcloud/pkg/core/quota"
 	"github.com/anexia-it/go-anxcloud/pkg/core/resource"
 	"github.com/anexia-it/go-anxcloud/pkg/core/service"
 	"github.com/anexia-it/go-anxcloud/pkg/core/tags"
@@ -15,6 +16,7 @@ type API interface {
 	Service() service.API
 	Tags() tags.API
 	Location() location.API
+	Quota() quota.API
 }
 
 type api struct {
@@ -22,6 +24,7 @@ type api struct {
 	service  service.API
 	tags     tags.API
 	location location.API
+	quota    quota.API
 }
 
 func (a api) Resource() resource.API {
@@ -40,6 +43,10 @@ func (a api) Location() location.API {
 	return a.location
 }
 
+func (a api) Quota() quota.API {
+	return a.quota
+}
+
 // NewAPI creates a new API instance with the given client.
 func NewAPI(c client.Client) API {
 	return &api{
@@ -47,5 +54,6 @@ func NewAPI(c client.Client) API {
 		service.NewAPI(c),
 		tags.NewAPI(c),
 		location.NewAPI(c),
+		quota.NewAPI(c),
 	}
 }
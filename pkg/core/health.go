@@ -0,0 +1,122 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/anexia-it/go-anxcloud/pkg/client"
+	"github.com/anexia-it/go-anxcloud/pkg/clouddns/zone"
+	"github.com/anexia-it/go-anxcloud/pkg/core/location"
+	"github.com/anexia-it/go-anxcloud/pkg/lbaas/loadbalancer"
+	"github.com/anexia-it/go-anxcloud/pkg/vsphere/vmlist"
+)
+
+// DefaultHealthCheckTimeout limits the time of the check of a single service.
+const DefaultHealthCheckTimeout = 5 * time.Second
+
+// ErrUnhealthy is raised by HealthCheck if at least one service is not healthy.
+var ErrUnhealthy = errors.New("services are not healthy")
+
+// Service is a product whose API can be checked by HealthCheck.
+type Service string
+
+const (
+	ServiceCore     Service = "core"
+	ServiceCloudDNS Service = "clouddns"
+	ServiceLBaaS    Service = "lbaas"
+	ServiceVSphere  Service = "vsphere"
+)
+
+// AllServices are all services known to HealthCheck, which are checked if no services are given.
+var AllServices = []Service{ServiceCore, ServiceCloudDNS, ServiceLBaaS, ServiceVSphere}
+
+// healthChecks list the smallest possible amount of data of each service, which requires
+// connectivity, valid credentials and access to the service.
+var healthChecks = map[Service]func(ctx context.Context, c client.Client) error{
+	ServiceCore: func(ctx context.Context, c client.Client) error {
+		_, err := location.NewAPI(c).List(ctx, 1, 1, "")
+		return err
+	},
+	ServiceCloudDNS: func(ctx context.Context, c client.Client) error {
+		_, err := zone.NewAPI(c).List(ctx)
+		return err
+	},
+	ServiceLBaaS: func(ctx context.Context, c client.Client) error {
+		_, err := loadbalancer.NewAPI(c).Get(ctx, 1, 1)
+		return err
+	},
+	ServiceVSphere: func(ctx context.Context, c client.Client) error {
+		_, err := vmlist.NewAPI(c).Get(ctx, 1, 1)
+		return err
+	},
+}
+
+// ServiceHealth is the result of checking a single service.
+type ServiceHealth struct {
+	Service Service
+	Healthy bool
+	// Latency is the time the check took.
+	Latency time.Duration
+	// Err is the reason the service is not healthy.
+	Err error
+}
+
+// HealthReport is the result of HealthCheck.
+type HealthReport struct {
+	// Healthy is true if all checked services are healthy.
+	Healthy   bool
+	Services  []ServiceHealth
+	CheckedAt time.Time
+}
+
+// HealthCheck checks the connectivity and authentication against the given services concurrently,
+// or against AllServices if none are given. It is meant for readiness probes and dashboards.
+//
+// Each check lists a single entry of the service and is limited to DefaultHealthCheckTimeout.
+// The report is always returned with one entry per service, in the given order. If a service is not
+// healthy, the error wraps ErrUnhealthy and names the affected services.
+func HealthCheck(ctx context.Context, c client.Client, services ...Service) (HealthReport, error) {
+	if len(services) == 0 {
+		services = AllServices
+	}
+	for _, service := range services {
+		if _, ok := healthChecks[service]; !ok {
+			return HealthReport{}, fmt.Errorf("unknown service '%s'", service)
+		}
+	}
+
+	report := HealthReport{Healthy: true, Services: make([]ServiceHealth, len(services)), CheckedAt: time.Now()}
+	var wg sync.WaitGroup
+	for i, service := range services {
+		wg.Add(1)
+		go func(i int, service Service) {
+			defer wg.Done()
+
+			checkCtx, cancel := context.WithTimeout(ctx, DefaultHealthCheckTimeout)
+			defer cancel()
+			start := time.Now()
+			err := healthChecks[service](checkCtx, c)
+			report.Services[i] = ServiceHealth{Service: service, Healthy: err == nil, Latency: time.Since(start), Err: err}
+		}(i, service)
+	}
+	wg.Wait()
+
+	var unhealthy []string
+	for _, health := range report.Services {
+		if !health.Healthy {
+			unhealthy = append(unhealthy, string(health.Service))
+		}
+	}
+	if len(unhealthy) > 0 {
+		report.Healthy = false
+		sort.Strings(unhealthy)
+		return report, fmt.Errorf("%w: %s", ErrUnhealthy, strings.Join(unhealthy, ", "))
+	}
+
+	return report, nil
+}
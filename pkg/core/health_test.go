@@ -0,0 +1,43 @@
+package core_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/anexia-it/go-anxcloud/pkg/client"
+	"github.com/anexia-it/go-anxcloud/pkg/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthCheck(t *testing.T) {
+	c, server := client.NewTestClient(nil, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "LBaaS") {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error":{"code":401,"message":"invalid token"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"results":[]}`))
+	}))
+	defer server.Close()
+
+	report, err := core.HealthCheck(context.Background(), c, core.ServiceCloudDNS, core.ServiceLBaaS)
+	assert.True(t, errors.Is(err, core.ErrUnhealthy), "expected ErrUnhealthy but got %v", err)
+	assert.False(t, report.Healthy)
+	if assert.Len(t, report.Services, 2) {
+		assert.Equal(t, core.ServiceCloudDNS, report.Services[0].Service)
+		assert.True(t, report.Services[0].Healthy, "%v", report.Services[0].Err)
+		assert.Equal(t, core.ServiceLBaaS, report.Services[1].Service)
+		assert.False(t, report.Services[1].Healthy)
+		assert.Error(t, report.Services[1].Err)
+	}
+
+	report, err = core.HealthCheck(context.Background(), c, core.ServiceCloudDNS)
+	assert.NoError(t, err)
+	assert.True(t, report.Healthy)
+
+	_, err = core.HealthCheck(context.Background(), c, "unknown")
+	assert.Error(t, err)
+}
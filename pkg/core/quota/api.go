@@ -0,0 +1,22 @@
+package quota
+
+import (
+	"context"
+
+	"github.com/anexia-it/go-anxcloud/pkg/client"
+)
+
+// API contains methods for querying quotas.
+type API interface {
+	List(ctx context.Context) ([]Quota, error)
+	Check(ctx context.Context, resource Resource, amount int) error
+}
+
+type api struct {
+	client client.Client
+}
+
+// NewAPI creates a new quota API instance with the given client.
+func NewAPI(c client.Client) API {
+	return api{c}
+}
@@ -0,0 +1,111 @@
+// Package quota implements API functions residing under /core/quota.
+// This path contains methods for querying the resource limits and usage of an account.
+package quota
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/anexia-it/go-anxcloud/pkg/client"
+)
+
+const pathPrefix = "/api/core/v1/quota.json"
+
+// Resource is the kind of resource a quota applies to.
+type Resource string
+
+const (
+	// VMs is the quota for the number of virtual machines.
+	VMs Resource = "vm"
+	// IPs is the quota for the number of IP addresses.
+	IPs Resource = "ip"
+	// LoadBalancers is the quota for the number of load balancers.
+	LoadBalancers Resource = "load_balancer"
+)
+
+// ErrQuotaExceeded is raised if a quota does not allow the requested amount of resources.
+var ErrQuotaExceeded = errors.New("quota exceeded")
+
+// ErrUnknownQuota is raised if no quota is known for a resource.
+var ErrUnknownQuota = errors.New("unknown quota")
+
+// Quota is the limit and current usage of a resource.
+type Quota struct {
+	Resource Resource `json:"resource"`
+	// Limit is the maximum amount of the resource, a negative value means unlimited.
+	Limit int `json:"limit"`
+	// Usage is the amount of the resource currently in use.
+	Usage int `json:"usage"`
+}
+
+// Remaining returns the amount of the resource that can still be used, or -1 if it is unlimited.
+func (q Quota) Remaining() int {
+	if q.Limit < 0 {
+		return -1
+	}
+	if q.Usage >= q.Limit {
+		return 0
+	}
+
+	return q.Limit - q.Usage
+}
+
+type listResponse struct {
+	Data []Quota `json:"data"`
+}
+
+// List returns the quotas of all resources.
+//
+// ctx is attached to the request and will cancel it on cancelation.
+func (a api) List(ctx context.Context) ([]Quota, error) {
+	url := fmt.Sprintf("%s%s", a.client.BaseURL(), pathPrefix)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not create quota list request: %w", err)
+	}
+
+	httpResponse, err := a.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("could not execute quota list request: %w", err)
+	}
+	if err := client.CheckResponse(httpResponse, "execute quota list request"); err != nil {
+		return nil, err
+	}
+
+	var responsePayload listResponse
+	err = json.NewDecoder(httpResponse.Body).Decode(&responsePayload)
+	_ = httpResponse.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("could not decode quota list response: %w", err)
+	}
+
+	return responsePayload.Data, nil
+}
+
+// Check returns ErrQuotaExceeded if less than amount of the given resource can still be used.
+//
+// This allows to fail fast with a clear message before provisioning resources.
+func (a api) Check(ctx context.Context, resource Resource, amount int) error {
+	quotas, err := a.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, quota := range quotas {
+		if quota.Resource != resource {
+			continue
+		}
+		if remaining := quota.Remaining(); remaining >= 0 && remaining < amount {
+			return fmt.Errorf("%w: %d %s requested, %d of %d remaining", ErrQuotaExceeded, amount, resource,
+				remaining, quota.Limit)
+		}
+
+		return nil
+	}
+
+	return fmt.Errorf("%w: %s", ErrUnknownQuota, resource)
+}
@@ -0,0 +1,88 @@
+package quota_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/anexia-it/go-anxcloud/pkg/client"
+	"github.com/anexia-it/go-anxcloud/pkg/core/quota"
+	"github.com/stretchr/testify/assert"
+)
+
+const quotas = `{"data":[
+	{"resource":"vm","limit":10,"usage":8},
+	{"resource":"ip","limit":-1,"usage":120},
+	{"resource":"load_balancer","limit":2,"usage":3}
+]}`
+
+func newQuotaMock(t *testing.T) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.EqualValues(t, http.MethodGet, r.Method)
+		if r.URL.Path != "/api/core/v1/quota.json" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":{"code":404,"message":"not found"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(quotas))
+	})
+}
+
+func TestList(t *testing.T) {
+	c, server := client.NewTestClient(nil, newQuotaMock(t))
+	defer server.Close()
+
+	list, err := quota.NewAPI(c).List(context.Background())
+	if assert.NoError(t, err) && assert.Len(t, list, 3) {
+		assert.EqualValues(t, quota.VMs, list[0].Resource)
+		assert.EqualValues(t, 2, list[0].Remaining())
+		assert.EqualValues(t, -1, list[1].Remaining(), "negative limits are unlimited")
+		assert.EqualValues(t, 0, list[2].Remaining(), "usage above the limit leaves nothing")
+	}
+}
+
+func TestCheck(t *testing.T) {
+	c, server := client.NewTestClient(nil, newQuotaMock(t))
+	defer server.Close()
+	api := quota.NewAPI(c)
+	ctx := context.Background()
+
+	assert.NoError(t, api.Check(ctx, quota.VMs, 2))
+	assert.NoError(t, api.Check(ctx, quota.IPs, 1000))
+
+	err := api.Check(ctx, quota.VMs, 3)
+	assert.True(t, errors.Is(err, quota.ErrQuotaExceeded), "expected ErrQuotaExceeded but got %v", err)
+	err = api.Check(ctx, quota.LoadBalancers, 1)
+	assert.True(t, errors.Is(err, quota.ErrQuotaExceeded), "expected ErrQuotaExceeded but got %v", err)
+	err = api.Check(ctx, quota.Resource("gpu"), 1)
+	assert.True(t, errors.Is(err, quota.ErrUnknownQuota), "expected ErrUnknownQuota but got %v", err)
+}
+
+type rawClient struct {
+	baseURL string
+}
+
+func (c rawClient) BaseURL() string {
+	return c.baseURL
+}
+
+func (c rawClient) Do(req *http.Request) (*http.Response, error) {
+	return http.DefaultClient.Do(req)
+}
+
+func TestErrorResponse(t *testing.T) {
+	c, server := client.NewTestClient(nil, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"error":{"code":403,"message":"forbidden"}}`))
+	}))
+	defer server.Close()
+
+	for name, c := range map[string]client.Client{"TestClient": c, "RawClient": rawClient{server.URL}} {
+		err := quota.NewAPI(c).Check(context.Background(), quota.VMs, 1)
+		var responseErr *client.ResponseError
+		if assert.True(t, errors.As(err, &responseErr), "%s: expected ResponseError but got %v", name, err) {
+			assert.EqualValues(t, 403, responseErr.ErrorData.Code, name)
+		}
+	}
+}
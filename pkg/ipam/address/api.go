@@ -14,6 +14,8 @@ type API interface {
 	Create(ctx context.Context, create Create) (Summary, error)
 	Update(ctx context.Context, id string, update Update) (Summary, error)
 	ReserveRandom(ctx context.Context, reserve ReserveRandom) (ReserveRandomSummary, error)
+	ReserveIP(ctx context.Context, location, vlan string) (ReservedIP, error)
+	ReleaseIP(ctx context.Context, id string) error
 }
 
 type api struct {
@@ -0,0 +1,40 @@
+package address
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/anexia-it/go-anxcloud/pkg/client"
+)
+
+// ErrPoolExhausted is raised by ReserveIP if the VLAN has no free address left.
+var ErrPoolExhausted = errors.New("no free IP address left")
+
+// ReserveIP reserves a single free address of the given VLAN, so concurrent provisioners can not pick the same one.
+//
+// If location is empty, the default location of the client is used. If the VLAN has no free address left,
+// an error wrapping ErrPoolExhausted is returned.
+// The identifier or address of the returned ReservedIP can be passed in the IPs of a vm.Network to provision
+// a VM with it. Addresses not used after all should be freed with ReleaseIP.
+func (a api) ReserveIP(ctx context.Context, location, vlan string) (ReservedIP, error) {
+	location, err := client.ResolveLocation(a.client, location)
+	if err != nil {
+		return ReservedIP{}, fmt.Errorf("could not reserve IP: %w", err)
+	}
+
+	summary, err := a.ReserveRandom(ctx, ReserveRandom{LocationID: location, VlanID: vlan, Count: 1})
+	if err != nil {
+		return ReservedIP{}, err
+	}
+	if len(summary.Data) == 0 {
+		return ReservedIP{}, fmt.Errorf("%w in VLAN '%s'", ErrPoolExhausted, vlan)
+	}
+
+	return summary.Data[0], nil
+}
+
+// ReleaseIP frees an address reserved with ReserveIP.
+func (a api) ReleaseIP(ctx context.Context, id string) error {
+	return a.Delete(ctx, id)
+}
@@ -0,0 +1,46 @@
+package address_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/anexia-it/go-anxcloud/pkg/client"
+	"github.com/anexia-it/go-anxcloud/pkg/ipam/address"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReserveIP(t *testing.T) {
+	free := []address.ReservedIP{{ID: "ip-1", Address: "10.0.0.1", Prefix: "prefix"}}
+	released := ""
+	c, server := client.NewTestClient(nil, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			var reserve address.ReserveRandom
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&reserve))
+			assert.EqualValues(t, address.ReserveRandom{LocationID: "location", VlanID: "vlan", Count: 1}, reserve)
+
+			summary := address.ReserveRandomSummary{Data: free}
+			free = nil
+			assert.NoError(t, json.NewEncoder(w).Encode(summary))
+		case http.MethodDelete:
+			released = r.URL.Path
+		}
+	}))
+	defer server.Close()
+
+	a := address.NewAPI(c)
+	ctx := context.Background()
+	reserved, err := a.ReserveIP(ctx, "location", "vlan")
+	if assert.NoError(t, err) {
+		assert.EqualValues(t, "10.0.0.1", reserved.Address)
+	}
+
+	_, err = a.ReserveIP(ctx, "location", "vlan")
+	assert.True(t, errors.Is(err, address.ErrPoolExhausted), "expected ErrPoolExhausted but got %v", err)
+
+	assert.NoError(t, a.ReleaseIP(ctx, reserved.ID))
+	assert.EqualValues(t, "/api/ipam/v1/address.json/ip-1", released)
+}
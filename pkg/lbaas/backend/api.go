@@ -3,6 +3,8 @@ package backend
 import (
 	"context"
 	"github.com/anexia-it/go-anxcloud/pkg/client"
+	"github.com/anexia-it/go-anxcloud/pkg/lbaas/common"
+	"github.com/anexia-it/go-anxcloud/pkg/pagination"
 )
 
 // API contains methods for load balancer backend management.
@@ -10,7 +12,11 @@ type API interface {
 	Get(ctx context.Context, page, limit int) ([]BackendInfo, error)
 	GetByID(ctx context.Context, identifier string) (Backend, error)
 	Create(ctx context.Context, definition Definition) (Backend, error)
+	Update(ctx context.Context, identifier string, definition Definition) (Backend, error)
 	DeleteByID(ctx context.Context, identifier string) error
+	Pages(opts ...common.ListOption) pagination.Pageable
+
+	pagination.Pageable
 }
 
 type api struct {
@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"github.com/anexia-it/go-anxcloud/pkg/client"
 	"github.com/anexia-it/go-anxcloud/pkg/lbaas/common"
 	"github.com/anexia-it/go-anxcloud/pkg/lbaas/loadbalancer"
 	"net/http"
@@ -32,6 +33,21 @@ type Backend struct {
 	HealthCheck        string                        `json:"health_check"`
 	Mode               common.Mode                   `json:"mode"`
 	ServerTimeout      int                           `json:"server_timeout"`
+	StickySession      *StickySession                `json:"sticky_session,omitempty"`
+}
+
+// ConfigHash returns a stable hash of the configuration of the backend.
+//
+// Included are, in this order, the name, the load balancer identifier, the health check, the mode,
+// the server timeout and, if configured, the sticky session. Identifiers of the backend itself and its
+// owners are not included.
+func (b Backend) ConfigHash() string {
+	fields := []interface{}{b.Name, b.LoadBalancer.Identifier, b.HealthCheck, b.Mode, b.ServerTimeout}
+	if b.StickySession != nil {
+		fields = append(fields, *b.StickySession)
+	}
+
+	return common.ConfigHash(fields...)
 }
 
 func (a api) Get(ctx context.Context, page, limit int) ([]BackendInfo, error) {
@@ -55,9 +71,10 @@ func (a api) Get(ctx context.Context, page, limit int) ([]BackendInfo, error) {
 	if err != nil {
 		return nil, fmt.Errorf("error when executing request: %w", err)
 	}
+	defer func() { _ = response.Body.Close() }()
 
-	if response.StatusCode >= 500 && response.StatusCode < 600 {
-		return nil, fmt.Errorf("could not get load balancer backends %s", response.Status)
+	if err := client.CheckResponse(response, "get load balancer backends"); err != nil {
+		return nil, err
 	}
 
 	payload := struct {
@@ -91,10 +108,11 @@ func (a api) GetByID(ctx context.Context, identifier string) (Backend, error) {
 	if err != nil {
 		return Backend{}, fmt.Errorf("error when executing request for '%s': %w", identifier, err)
 	}
+	defer func() { _ = response.Body.Close() }()
 
-	if response.StatusCode >= 500 && response.StatusCode < 600 {
-		return Backend{}, fmt.Errorf("could not execute get load balancer backend request for '%s': %s", identifier,
-			response.Status)
+	request := fmt.Sprintf("execute get load balancer backend request for '%s'", identifier)
+	if err := client.CheckResponse(response, request); err != nil {
+		return Backend{}, err
 	}
 
 	var payload Backend
@@ -115,6 +133,20 @@ func (a api) Create(ctx context.Context, definition Definition) (Backend, error)
 
 	endpoint.Path = path
 
+	if err := definition.Validate(); err != nil {
+		return Backend{}, err
+	}
+
+	if definition.EnsureUniqueName {
+		identifier, exists, err := a.findByName(ctx, definition.Name)
+		if err != nil {
+			return Backend{}, err
+		}
+		if exists {
+			return Backend{}, &common.AlreadyExistsError{Name: definition.Name, Identifier: identifier}
+		}
+	}
+
 	requestBody := bytes.Buffer{}
 	if err := json.NewEncoder(&requestBody).Encode(definition); err != nil {
 		return Backend{}, err
@@ -129,10 +161,10 @@ func (a api) Create(ctx context.Context, definition Definition) (Backend, error)
 	if err != nil {
 		return Backend{}, fmt.Errorf("error when creating backend '%s': %w", definition.Name, err)
 	}
+	defer func() { _ = response.Body.Close() }()
 
-	if response.StatusCode >= 500 && response.StatusCode < 600 {
-		return Backend{}, fmt.Errorf("could not create load balancer backend '%s': %s", definition.Name,
-			response.Status)
+	if err := client.CheckResponse(response, fmt.Sprintf("create load balancer backend '%s'", definition.Name)); err != nil {
+		return Backend{}, err
 	}
 
 	var payload Backend
@@ -146,6 +178,47 @@ func (a api) Create(ctx context.Context, definition Definition) (Backend, error)
 	return payload, nil
 }
 
+func (a api) Update(ctx context.Context, identifier string, definition Definition) (Backend, error) {
+	endpoint, err := url.Parse(a.client.BaseURL())
+	if err != nil {
+		return Backend{}, fmt.Errorf("could not parse URL: %w", err)
+	}
+
+	endpoint.Path = utils.Join(path, identifier)
+
+	if err := definition.Validate(); err != nil {
+		return Backend{}, err
+	}
+
+	requestBody := bytes.Buffer{}
+	if err := json.NewEncoder(&requestBody).Encode(definition); err != nil {
+		return Backend{}, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, endpoint.String(), &requestBody)
+	if err != nil {
+		return Backend{}, fmt.Errorf("could not create request object: %w", err)
+	}
+
+	response, err := a.client.Do(req)
+	if err != nil {
+		return Backend{}, fmt.Errorf("error when updating LBaaS backend '%s': %w", identifier, err)
+	}
+	defer func() { _ = response.Body.Close() }()
+
+	if err := client.CheckResponse(response, fmt.Sprintf("update LBaaS backend '%s'", identifier)); err != nil {
+		return Backend{}, err
+	}
+
+	var payload Backend
+	err = json.NewDecoder(response.Body).Decode(&payload)
+	if err != nil {
+		return Backend{}, fmt.Errorf("could not parse load balancer backend update response for '%s': %w", identifier, err)
+	}
+
+	return payload, nil
+}
+
 func (a api) DeleteByID(ctx context.Context, identifier string) error {
 	endpoint, err := url.Parse(a.client.BaseURL())
 	if err != nil {
@@ -163,10 +236,10 @@ func (a api) DeleteByID(ctx context.Context, identifier string) error {
 		return fmt.Errorf("error when deleting a LBaaS backend '%s': %w",
 			identifier, err)
 	}
+	defer func() { _ = response.Body.Close() }()
 
-	if response.StatusCode >= 500 && response.StatusCode < 600 {
-		return fmt.Errorf("could not delete LBaaS backend '%s': %s",
-			identifier, response.Status)
+	if err := client.CheckResponse(response, fmt.Sprintf("delete LBaaS backend '%s'", identifier)); err != nil {
+		return err
 	}
 	return nil
 }
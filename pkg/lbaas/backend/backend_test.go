@@ -0,0 +1,260 @@
+package backend_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/anexia-it/go-anxcloud/pkg/client"
+	"github.com/anexia-it/go-anxcloud/pkg/lbaas/backend"
+	"github.com/anexia-it/go-anxcloud/pkg/lbaas/common"
+	"github.com/anexia-it/go-anxcloud/pkg/pagination"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdate(t *testing.T) {
+	c, server := client.NewTestClient(nil, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.EqualValues(t, http.MethodPut, r.Method)
+		assert.EqualValues(t, "/api/LBaaS/v1/backend.json/backend-id", r.URL.Path)
+
+		var definition backend.Definition
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&definition))
+		_ = json.NewEncoder(w).Encode(backend.Backend{Identifier: "backend-id", Name: definition.Name, Mode: definition.Mode})
+	}))
+	defer server.Close()
+
+	api := backend.NewAPI(c)
+	updated, err := api.Update(context.Background(), "backend-id", backend.Definition{Name: "web", Mode: common.HTTP})
+	if assert.NoError(t, err) {
+		assert.EqualValues(t, "web", updated.Name)
+		assert.EqualValues(t, common.HTTP, updated.Mode)
+	}
+
+	_, err = api.Update(context.Background(), "backend-id", backend.Definition{Name: "web", Mode: common.TCP, HealthCheck: "httpchk GET /"})
+	assert.True(t, errors.Is(err, common.ErrIncompatibleMode), "expected ErrIncompatibleMode but got %v", err)
+}
+
+func TestHealthCheck(t *testing.T) {
+	var sent string
+	c, server := client.NewTestClient(nil, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var definition backend.Definition
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&definition))
+		sent = definition.HealthCheck
+		_ = json.NewEncoder(w).Encode(backend.Backend{Identifier: "backend-id", Name: definition.Name, HealthCheck: sent})
+	}))
+	defer server.Close()
+
+	check := backend.HealthCheck{Protocol: common.HTTP, Path: "/health", Interval: 5 * time.Second, Timeout: 2 * time.Second, Rise: 2, Fall: 3}
+	created, err := backend.NewAPI(c).Create(context.Background(), backend.Definition{Name: "web", Mode: common.HTTP, Check: &check})
+	if assert.NoError(t, err) {
+		assert.EqualValues(t, "httpchk GET /health inter 5s timeout 2s rise 2 fall 3", sent)
+		parsed, err := created.Check()
+		assert.NoError(t, err)
+		assert.Equal(t, check, parsed)
+	}
+
+	_, err = backend.NewAPI(c).Create(context.Background(), backend.Definition{Name: "web", Mode: common.TCP, Check: &check})
+	assert.True(t, errors.Is(err, common.ErrIncompatibleMode), "expected ErrIncompatibleMode but got %v", err)
+
+	for _, invalid := range []backend.HealthCheck{
+		{Protocol: common.HTTP},
+		{Protocol: common.TCP, Path: "/health"},
+		{Protocol: common.TCP, Rise: -1},
+		{Protocol: "udp"},
+	} {
+		err := backend.Definition{Name: "web", Mode: common.HTTP, Check: &invalid}.Validate()
+		assert.True(t, errors.Is(err, backend.ErrInvalidHealthCheck), "expected ErrInvalidHealthCheck for %+v but got %v", invalid, err)
+	}
+
+	parsed, err := backend.ParseHealthCheck("tcp-check fall 5")
+	if assert.NoError(t, err) {
+		assert.Equal(t, backend.HealthCheck{Protocol: common.TCP, Fall: 5}, parsed)
+	}
+	for _, invalid := range []string{"", "ping", "httpchk GET", "tcp-check rise", "tcp-check rise many", "tcp-check weight 2"} {
+		_, err := backend.ParseHealthCheck(invalid)
+		assert.True(t, errors.Is(err, backend.ErrInvalidHealthCheck), "expected ErrInvalidHealthCheck for %q but got %v", invalid, err)
+	}
+}
+
+func TestStickySession(t *testing.T) {
+	var sent *backend.StickySession
+	c, server := client.NewTestClient(nil, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var definition backend.Definition
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&definition))
+		sent = definition.StickySession
+		_ = json.NewEncoder(w).Encode(backend.Backend{Identifier: "backend-id", Name: definition.Name,
+			StickySession: definition.StickySession})
+	}))
+	defer server.Close()
+	api := backend.NewAPI(c)
+
+	sticky := backend.StickySession{Mode: backend.StickyCookie, CookieName: "SRV", Timeout: 600}
+	created, err := api.Create(context.Background(), backend.Definition{Name: "web", Mode: common.HTTP, StickySession: &sticky})
+	if assert.NoError(t, err) && assert.NotNil(t, sent) && assert.NotNil(t, created.StickySession) {
+		assert.EqualValues(t, sticky, *sent)
+		assert.EqualValues(t, sticky, *created.StickySession)
+	}
+	assert.NotEqual(t, backend.Backend{Name: "web"}.ConfigHash(), created.ConfigHash())
+
+	sticky = backend.StickySession{Mode: backend.StickySourceIP}
+	_, err = api.Update(context.Background(), "backend-id", backend.Definition{Name: "db", Mode: common.TCP, StickySession: &sticky})
+	assert.NoError(t, err)
+
+	_, err = api.Create(context.Background(), backend.Definition{Name: "db", Mode: common.TCP,
+		StickySession: &backend.StickySession{Mode: backend.StickyCookie}})
+	assert.True(t, errors.Is(err, common.ErrIncompatibleMode), "expected ErrIncompatibleMode but got %v", err)
+
+	for _, invalid := range []backend.StickySession{
+		{Mode: backend.StickySourceIP, CookieName: "SRV"},
+		{Mode: backend.StickyCookie, Timeout: -1},
+		{Mode: "url_param"},
+	} {
+		err := backend.Definition{Name: "web", Mode: common.HTTP, StickySession: &invalid}.Validate()
+		assert.True(t, errors.Is(err, backend.ErrInvalidStickySession), "expected ErrInvalidStickySession for %+v but got %v", invalid, err)
+	}
+}
+
+func TestPages(t *testing.T) {
+	var queries []url.Values
+	c, server := client.NewTestClient(nil, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.Query())
+		_ = json.NewEncoder(w).Encode(map[string]backend.BackendPage{"data": {Page: 1, TotalPages: 1, TotalItems: 1, Limit: 10,
+			Data: []backend.BackendInfo{{Identifier: "backend-id", Name: "web"}}}})
+	}))
+	defer server.Close()
+	api := backend.NewAPI(c)
+
+	items, err := pagination.Collect(context.Background(), api.Pages(common.Search("we"), common.StateFilter(common.Deployed)))
+	if assert.NoError(t, err) {
+		assert.Len(t, items, 1)
+	}
+	_, err = api.GetPage(context.Background(), 1, 10)
+	assert.NoError(t, err)
+
+	if assert.Len(t, queries, 2) {
+		assert.EqualValues(t, "we", queries[0].Get(common.OptNameSearch))
+		assert.EqualValues(t, []string{"state:3"}, queries[0][common.OptNameFilter])
+		assert.EqualValues(t, "1", queries[0].Get("page"))
+		assert.EqualValues(t, url.Values{"page": {"1"}, "limit": {"10"}}, queries[1])
+	}
+}
+
+// rawClient sends requests without turning error responses into errors, like custom client.Client implementations may.
+type rawClient struct {
+	baseURL string
+}
+
+func (c rawClient) BaseURL() string {
+	return c.baseURL
+}
+
+func (c rawClient) Do(req *http.Request) (*http.Response, error) {
+	return http.DefaultClient.Do(req)
+}
+
+func TestErrorResponses(t *testing.T) {
+	for _, status := range []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict} {
+		_, server := client.NewTestClient(nil, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(`{"error":{"code":` + strconv.Itoa(status) + `,"message":"rejected"}}`))
+		}))
+
+		api := backend.NewAPI(rawClient{server.URL})
+		ctx := context.Background()
+		definition := backend.Definition{Name: "web", Mode: common.HTTP}
+
+		_, getErr := api.Get(ctx, 1, 10)
+		_, getByIDErr := api.GetByID(ctx, "backend-id")
+		_, createErr := api.Create(ctx, definition)
+		_, updateErr := api.Update(ctx, "backend-id", definition)
+		deleteErr := api.DeleteByID(ctx, "backend-id")
+		_, pageErr := api.Pages().GetPage(ctx, 1, 10)
+
+		for _, err := range []error{getErr, getByIDErr, createErr, updateErr, deleteErr, pageErr} {
+			var responseErr *client.ResponseError
+			if assert.True(t, errors.As(err, &responseErr), "expected ResponseError for %d but got %v", status, err) {
+				assert.EqualValues(t, status, responseErr.ErrorData.Code)
+				assert.EqualValues(t, "rejected", responseErr.ErrorData.Message)
+			}
+		}
+		server.Close()
+	}
+}
+
+func TestBackendConfigHash(t *testing.T) {
+	c, server := client.NewTestClient(nil, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"identifier":"backend-id","customer_identifier":"customer","name":"web",` +
+			`"load_balancer":{"identifier":"lb-id"},"health_check":"GET /health","mode":"http","server_timeout":10}`))
+	}))
+	defer server.Close()
+
+	fetched, err := backend.NewAPI(c).GetByID(context.Background(), "backend-id")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	same := fetched
+	same.Identifier, same.CustomerIdentifier, same.ResellerIdentifier = "other-id", "other", "reseller"
+	assert.Equal(t, fetched.ConfigHash(), same.ConfigHash(), "identifiers must not be hashed")
+
+	for name, change := range map[string]func(b *backend.Backend){
+		"Name":          func(b *backend.Backend) { b.Name = "db" },
+		"LoadBalancer":  func(b *backend.Backend) { b.LoadBalancer.Identifier = "other-lb" },
+		"HealthCheck":   func(b *backend.Backend) { b.HealthCheck = "" },
+		"Mode":          func(b *backend.Backend) { b.Mode = common.TCP },
+		"ServerTimeout": func(b *backend.Backend) { b.ServerTimeout = 20 },
+		"StickySession": func(b *backend.Backend) { b.StickySession = &backend.StickySession{Mode: backend.StickySourceIP} },
+	} {
+		changed := fetched
+		change(&changed)
+		assert.NotEqual(t, fetched.ConfigHash(), changed.ConfigHash(), name)
+	}
+}
+
+func TestCreateUniqueName(t *testing.T) {
+	created := 0
+	c, server := client.NewTestClient(nil, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.EqualValues(t, "/api/LBaaS/v1/backend.json", r.URL.Path)
+		switch r.Method {
+		case http.MethodGet:
+			search := r.URL.Query().Get(common.OptNameSearch)
+			assert.NotEmpty(t, search, "existing backends must be searched by name")
+			page := backend.BackendPage{Page: 1, TotalPages: 1, Limit: 10}
+			for _, info := range []backend.BackendInfo{{Identifier: "other-id", Name: "web-2"}, {Identifier: "backend-id", Name: "web"}} {
+				if strings.Contains(info.Name, search) {
+					page.Data = append(page.Data, info)
+				}
+			}
+			page.TotalItems = len(page.Data)
+			_ = json.NewEncoder(w).Encode(map[string]backend.BackendPage{"data": page})
+		case http.MethodPost:
+			created++
+			var definition backend.Definition
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&definition))
+			_ = json.NewEncoder(w).Encode(backend.Backend{Identifier: "new-id", Name: definition.Name})
+		}
+	}))
+	defer server.Close()
+	api := backend.NewAPI(c)
+	ctx := context.Background()
+
+	_, err := api.Create(ctx, backend.Definition{Name: "web", Mode: common.HTTP, EnsureUniqueName: true})
+	var existsErr *common.AlreadyExistsError
+	if assert.True(t, errors.As(err, &existsErr), "expected AlreadyExistsError but got %v", err) {
+		assert.EqualValues(t, "backend-id", existsErr.Identifier)
+		assert.True(t, errors.Is(err, common.ErrAlreadyExists))
+	}
+	assert.Equal(t, 0, created, "conflicting backends must not be created")
+
+	_, err = api.Create(ctx, backend.Definition{Name: "db", Mode: common.TCP, EnsureUniqueName: true})
+	assert.NoError(t, err)
+	_, err = api.Create(ctx, backend.Definition{Name: "web", Mode: common.HTTP})
+	assert.NoError(t, err, "names are only checked on request")
+	assert.Equal(t, 2, created)
+}
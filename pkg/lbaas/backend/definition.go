@@ -1,10 +1,72 @@
 package backend
 
-import "github.com/anexia-it/go-anxcloud/pkg/lbaas/common"
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/anexia-it/go-anxcloud/pkg/lbaas/common"
+)
 
 type Definition struct {
 	Name         string       `json:"name"`
 	State        common.State `json:"state"`
 	LoadBalancer string       `json:"load_balancer"`
 	Mode         common.Mode  `json:"mode"`
+	// HealthCheck is the health check of the backend servers. HTTP health checks require the HTTP mode.
+	HealthCheck string `json:"health_check,omitempty"`
+	// Check configures the health check in structured form. If set, it replaces HealthCheck.
+	Check *HealthCheck `json:"-"`
+	// StickySession configures the session affinity of the backend, nil disables it.
+	StickySession *StickySession `json:"sticky_session,omitempty"`
+
+	// EnsureUniqueName lets Create look for an existing backend with the same name first and
+	// fail with a common.AlreadyExistsError instead of sending the create request.
+	EnsureUniqueName bool `json:"-"`
+}
+
+// HealthCheckMode returns the mode required by the given health check: HTTP for HTTP checks
+// like "httpchk GET /health" or "http-check", TCP for all others.
+func HealthCheckMode(healthCheck string) common.Mode {
+	if strings.HasPrefix(strings.ToLower(strings.TrimSpace(healthCheck)), "http") {
+		return common.HTTP
+	}
+
+	return common.TCP
+}
+
+// Validate checks that the health check and the sticky session are valid and supported by the mode of the backend.
+func (d Definition) Validate() error {
+	if d.Check != nil {
+		if err := d.Check.Validate(); err != nil {
+			return err
+		}
+		d.HealthCheck = d.Check.String()
+	}
+
+	resource := fmt.Sprintf("backend '%s'", d.Name)
+	if err := common.CheckMode(resource, d.Mode, fmt.Sprintf("health check '%s'", d.HealthCheck),
+		HealthCheckMode(d.HealthCheck)); err != nil {
+		return err
+	}
+
+	if d.StickySession == nil {
+		return nil
+	}
+	if err := d.StickySession.Validate(); err != nil {
+		return err
+	}
+
+	return common.CheckMode(resource, d.Mode, fmt.Sprintf("%s sticky sessions", d.StickySession.Mode),
+		d.StickySession.requiredMode())
+}
+
+// MarshalJSON encodes the definition, rendering Check into the health check if set.
+func (d Definition) MarshalJSON() ([]byte, error) {
+	type definition Definition
+	if d.Check != nil {
+		d.HealthCheck = d.Check.String()
+	}
+
+	return json.Marshal(definition(d))
 }
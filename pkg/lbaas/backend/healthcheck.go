@@ -0,0 +1,130 @@
+package backend
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/anexia-it/go-anxcloud/pkg/lbaas/common"
+)
+
+// ErrInvalidHealthCheck is raised if a health check configuration is incomplete or can not be parsed.
+var ErrInvalidHealthCheck = errors.New("invalid health check")
+
+// HealthCheck is the structured form of the health check of a backend.
+//
+// The API stores health checks in HAProxy syntax, e.g. "httpchk GET /health inter 5s timeout 2s rise 2 fall 3".
+// String renders a HealthCheck in this form and ParseHealthCheck reads it back.
+type HealthCheck struct {
+	// Protocol of the check, HTTP checks require the backend to use the HTTP mode.
+	Protocol common.Mode
+	// Path requested by HTTP checks. It must be empty for TCP checks.
+	Path string
+	// Interval between two checks, 0 uses the default of the load balancer.
+	Interval time.Duration
+	// Timeout of a single check, 0 uses the default of the load balancer.
+	Timeout time.Duration
+	// Rise is the number of consecutive successful checks after which a server is considered up.
+	Rise int
+	// Fall is the number of consecutive failed checks after which a server is considered down.
+	Fall int
+}
+
+// Validate checks that HTTP checks have a path, TCP checks have none and all thresholds are not negative.
+func (h HealthCheck) Validate() error {
+	switch h.Protocol {
+	case common.HTTP:
+		if !strings.HasPrefix(h.Path, "/") {
+			return fmt.Errorf("%w: HTTP check requires an absolute path, got '%s'", ErrInvalidHealthCheck, h.Path)
+		}
+	case common.TCP:
+		if h.Path != "" {
+			return fmt.Errorf("%w: TCP check must not have a path, got '%s'", ErrInvalidHealthCheck, h.Path)
+		}
+	default:
+		return fmt.Errorf("%w: unknown protocol '%s'", ErrInvalidHealthCheck, h.Protocol)
+	}
+
+	if h.Interval < 0 || h.Timeout < 0 || h.Rise < 0 || h.Fall < 0 {
+		return fmt.Errorf("%w: interval, timeout and thresholds must not be negative", ErrInvalidHealthCheck)
+	}
+
+	return nil
+}
+
+// String renders the health check in the HAProxy syntax used by the API.
+func (h HealthCheck) String() string {
+	parts := []string{"tcp-check"}
+	if h.Protocol == common.HTTP {
+		parts = []string{"httpchk", "GET", h.Path}
+	}
+	if h.Interval > 0 {
+		parts = append(parts, "inter", h.Interval.String())
+	}
+	if h.Timeout > 0 {
+		parts = append(parts, "timeout", h.Timeout.String())
+	}
+	if h.Rise > 0 {
+		parts = append(parts, "rise", strconv.Itoa(h.Rise))
+	}
+	if h.Fall > 0 {
+		parts = append(parts, "fall", strconv.Itoa(h.Fall))
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// ParseHealthCheck parses a health check in the HAProxy syntax used by the API, as rendered by HealthCheck.String.
+func ParseHealthCheck(healthCheck string) (HealthCheck, error) {
+	fields := strings.Fields(healthCheck)
+	if len(fields) == 0 {
+		return HealthCheck{}, fmt.Errorf("%w: empty health check", ErrInvalidHealthCheck)
+	}
+
+	var h HealthCheck
+	switch strings.ToLower(fields[0]) {
+	case "tcp-check":
+		h.Protocol = common.TCP
+		fields = fields[1:]
+	case "httpchk":
+		if len(fields) < 3 {
+			return HealthCheck{}, fmt.Errorf("%w: '%s' lacks method and path", ErrInvalidHealthCheck, healthCheck)
+		}
+		h.Protocol = common.HTTP
+		h.Path = fields[2]
+		fields = fields[3:]
+	default:
+		return HealthCheck{}, fmt.Errorf("%w: unknown check '%s'", ErrInvalidHealthCheck, fields[0])
+	}
+
+	if len(fields)%2 != 0 {
+		return HealthCheck{}, fmt.Errorf("%w: '%s' has a parameter without value", ErrInvalidHealthCheck, healthCheck)
+	}
+	for i := 0; i < len(fields); i += 2 {
+		var err error
+		switch name, value := fields[i], fields[i+1]; name {
+		case "inter":
+			h.Interval, err = time.ParseDuration(value)
+		case "timeout":
+			h.Timeout, err = time.ParseDuration(value)
+		case "rise":
+			h.Rise, err = strconv.Atoi(value)
+		case "fall":
+			h.Fall, err = strconv.Atoi(value)
+		default:
+			err = fmt.Errorf("unknown parameter '%s'", name)
+		}
+		if err != nil {
+			return HealthCheck{}, fmt.Errorf("%w: %v", ErrInvalidHealthCheck, err)
+		}
+	}
+
+	return h, nil
+}
+
+// Check returns the health check of the backend in structured form.
+func (b Backend) Check() (HealthCheck, error) {
+	return ParseHealthCheck(b.HealthCheck)
+}
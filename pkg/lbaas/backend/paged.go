@@ -0,0 +1,132 @@
+package backend
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/anexia-it/go-anxcloud/pkg/client"
+	"github.com/anexia-it/go-anxcloud/pkg/lbaas/common"
+	"github.com/anexia-it/go-anxcloud/pkg/pagination"
+)
+
+// BackendPage is a single page of the load balancer backend listing.
+type BackendPage struct {
+	Page       int           `json:"page"`
+	TotalItems int           `json:"total_items"`
+	TotalPages int           `json:"total_pages"`
+	Limit      int           `json:"limit"`
+	Data       []BackendInfo `json:"data"`
+}
+
+// Num returns the number of this page.
+func (p BackendPage) Num() int {
+	return p.Page
+}
+
+// Size returns the maximum number of entries of this page.
+func (p BackendPage) Size() int {
+	return p.Limit
+}
+
+// Total returns the total number of pages.
+func (p BackendPage) Total() int {
+	return p.TotalPages
+}
+
+// TotalCount returns the total number of entries over all pages.
+func (p BackendPage) TotalCount() int {
+	return p.TotalItems
+}
+
+// Content returns the entries of this page as []BackendInfo.
+func (p BackendPage) Content() interface{} {
+	return p.Data
+}
+
+func (a api) GetPage(ctx context.Context, page, limit int) (pagination.Page, error) {
+	return a.getPage(ctx, page, limit)
+}
+
+func (a api) NextPage(ctx context.Context, page pagination.Page) (pagination.Page, error) {
+	return a.GetPage(ctx, page.Num()+1, page.Size())
+}
+
+// Pages returns the paged listing of the backends matching all given options, e.g. common.Search.
+// Without options it is the same as the listing of the API itself.
+func (a api) Pages(opts ...common.ListOption) pagination.Pageable {
+	return filteredPager{a, opts}
+}
+
+type filteredPager struct {
+	api  api
+	opts []common.ListOption
+}
+
+func (f filteredPager) GetPage(ctx context.Context, page, limit int) (pagination.Page, error) {
+	return f.api.getPage(ctx, page, limit, f.opts...)
+}
+
+func (f filteredPager) NextPage(ctx context.Context, page pagination.Page) (pagination.Page, error) {
+	return f.GetPage(ctx, page.Num()+1, page.Size())
+}
+
+func (a api) getPage(ctx context.Context, page, limit int, opts ...common.ListOption) (pagination.Page, error) {
+	endpoint, err := url.Parse(a.client.BaseURL())
+	if err != nil {
+		return nil, fmt.Errorf("could not parse URL: %w", err)
+	}
+
+	endpoint.Path = path
+	query := endpoint.Query()
+	common.ApplyListOptions(query, opts...)
+	query.Set("page", strconv.Itoa(page))
+	query.Set("limit", strconv.Itoa(limit))
+	endpoint.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not create request object: %w", err)
+	}
+
+	response, err := a.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error when executing request: %w", err)
+	}
+
+	if err := client.CheckResponse(response, "get load balancer backends"); err != nil {
+		return nil, err
+	}
+
+	payload := struct {
+		Data BackendPage `json:"data"`
+	}{}
+
+	err = json.NewDecoder(response.Body).Decode(&payload)
+	_ = response.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("could not parse load balancer backend page response: %w", err)
+	}
+
+	return payload.Data, nil
+}
+
+// findByName returns the identifier of the first backend with the given name. The name search of the API also
+// matches partial names, so the results are checked for an exact match.
+func (a api) findByName(ctx context.Context, name string) (string, bool, error) {
+	items, err := pagination.Collect(ctx, a.Pages(common.Search(name)))
+	if err != nil {
+		return "", false, fmt.Errorf("could not list load balancer backends: %w", err)
+	}
+
+	for _, item := range items {
+		if info := item.(BackendInfo); info.Name == name {
+			return info.Identifier, true, nil
+		}
+	}
+
+	return "", false, nil
+}
@@ -0,0 +1,60 @@
+package backend
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/anexia-it/go-anxcloud/pkg/lbaas/common"
+)
+
+// ErrInvalidStickySession is raised if a sticky session configuration is incomplete or inconsistent.
+var ErrInvalidStickySession = errors.New("invalid sticky session")
+
+// StickyMode is the way a backend binds clients to the server they were sent to first.
+type StickyMode string
+
+const (
+	// StickyCookie binds clients by a cookie set by the load balancer, it requires the HTTP mode.
+	StickyCookie StickyMode = "cookie"
+	// StickySourceIP binds clients by their source IP address.
+	StickySourceIP StickyMode = "source_ip"
+)
+
+// StickySession configures the session affinity of a backend.
+type StickySession struct {
+	Mode StickyMode `json:"mode"`
+	// CookieName is the name of the cookie used by StickyCookie, empty uses the default of the load balancer.
+	// It must be empty for other modes.
+	CookieName string `json:"cookie_name,omitempty"`
+	// Timeout in seconds after which idle sessions expire, 0 uses the default of the load balancer.
+	Timeout int `json:"timeout,omitempty"`
+}
+
+// Validate checks that the mode is known, cookie options are only set for StickyCookie and the
+// timeout is not negative.
+func (s StickySession) Validate() error {
+	switch s.Mode {
+	case StickyCookie:
+	case StickySourceIP:
+		if s.CookieName != "" {
+			return fmt.Errorf("%w: cookie name '%s' requires the cookie mode", ErrInvalidStickySession, s.CookieName)
+		}
+	default:
+		return fmt.Errorf("%w: unknown mode '%s'", ErrInvalidStickySession, s.Mode)
+	}
+
+	if s.Timeout < 0 {
+		return fmt.Errorf("%w: timeout must not be negative", ErrInvalidStickySession)
+	}
+
+	return nil
+}
+
+// requiredMode returns the backend mode required by the sticky session.
+func (s StickySession) requiredMode() common.Mode {
+	if s.Mode == StickyCookie {
+		return common.HTTP
+	}
+
+	return common.TCP
+}
@@ -10,6 +10,7 @@ import (
 	utils "path"
 	"strconv"
 
+	"github.com/anexia-it/go-anxcloud/pkg/lbaas/common"
 	"github.com/anexia-it/go-anxcloud/pkg/lbaas/frontend"
 )
 
@@ -35,6 +36,14 @@ type Bind struct {
 	SslCertificatePath string                `json:"ssl_certificate_path"`
 }
 
+// ConfigHash returns a stable hash of the configuration of the bind.
+//
+// Included are, in this order, the name, the frontend identifier, the address, the port, the SSL flag
+// and the SSL certificate path. Identifiers of the bind itself and its owners are not included.
+func (b Bind) ConfigHash() string {
+	return common.ConfigHash(b.Name, b.Frontend.Identifier, b.Address, b.Port, b.SSL, b.SslCertificatePath)
+}
+
 func (a api) Get(ctx context.Context, page, limit int) ([]BindInfo, error) {
 	endpoint, err := url.Parse(a.client.BaseURL())
 	if err != nil {
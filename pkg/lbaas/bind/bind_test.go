@@ -0,0 +1,42 @@
+package bind_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/anexia-it/go-anxcloud/pkg/client"
+	"github.com/anexia-it/go-anxcloud/pkg/lbaas/bind"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBindConfigHash(t *testing.T) {
+	c, server := client.NewTestClient(nil, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.EqualValues(t, "/api/LBaaS/v1/bind.json/bind-id", r.URL.Path)
+		_, _ = w.Write([]byte(`{"identifier":"bind-id","customer_identifier":"customer","name":"https",` +
+			`"frontend":{"identifier":"frontend-id"},"address":"192.0.2.1","port":443,"ssl":true,"ssl_certificate_path":"/cert.pem"}`))
+	}))
+	defer server.Close()
+
+	fetched, err := bind.NewAPI(c).GetByID(context.Background(), "bind-id")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	same := fetched
+	same.Identifier, same.CustomerIdentifier, same.Frontend.Name = "other-id", "other", "renamed"
+	assert.Equal(t, fetched.ConfigHash(), same.ConfigHash(), "identifiers and names of owners must not be hashed")
+
+	for name, change := range map[string]func(b *bind.Bind){
+		"Name":     func(b *bind.Bind) { b.Name = "http" },
+		"Frontend": func(b *bind.Bind) { b.Frontend.Identifier = "other-frontend" },
+		"Address":  func(b *bind.Bind) { b.Address = "192.0.2.2" },
+		"Port":     func(b *bind.Bind) { b.Port = 8443 },
+		"SSL":      func(b *bind.Bind) { b.SSL = false },
+		"SSLPath":  func(b *bind.Bind) { b.SslCertificatePath = "" },
+	} {
+		changed := fetched
+		change(&changed)
+		assert.NotEqual(t, fetched.ConfigHash(), changed.ConfigHash(), name)
+	}
+}
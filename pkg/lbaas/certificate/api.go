@@ -0,0 +1,24 @@
+package certificate
+
+import (
+	"context"
+
+	"github.com/anexia-it/go-anxcloud/pkg/client"
+)
+
+// API contains methods for load balancer certificate management.
+type API interface {
+	Get(ctx context.Context, page, limit int) ([]CertificateInfo, error)
+	GetByID(ctx context.Context, identifier string) (Certificate, error)
+	Upload(ctx context.Context, definition Definition) (Certificate, error)
+	DeleteByID(ctx context.Context, identifier string) error
+}
+
+type api struct {
+	client client.Client
+}
+
+// NewAPI creates a new load balancer certificate API instance with the given client.
+func NewAPI(c client.Client) API {
+	return &api{c}
+}
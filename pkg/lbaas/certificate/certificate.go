@@ -0,0 +1,176 @@
+// Package certificate implements API functions residing under /LBaaS/v1/certificate.
+// Certificates are referenced by frontends terminating TLS.
+package certificate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	utils "path"
+	"strconv"
+	"time"
+
+	"github.com/anexia-it/go-anxcloud/pkg/client"
+)
+
+const (
+	path = "api/LBaaS/v1/certificate.json"
+)
+
+// CertificateInfo holds the identifier and the name of a load balancer certificate.
+type CertificateInfo struct {
+	Identifier string `json:"identifier"`
+	Name       string `json:"name"`
+}
+
+// Certificate holds the information of an uploaded certificate. Its private key is never returned.
+type Certificate struct {
+	CustomerIdentifier string    `json:"customer_identifier"`
+	ResellerIdentifier string    `json:"reseller_identifier"`
+	Identifier         string    `json:"identifier"`
+	Name               string    `json:"name"`
+	CommonName         string    `json:"common_name"`
+	ValidUntil         time.Time `json:"valid_until"`
+}
+
+func (a api) Get(ctx context.Context, page, limit int) ([]CertificateInfo, error) {
+	endpoint, err := url.Parse(a.client.BaseURL())
+	if err != nil {
+		return nil, fmt.Errorf("could not parse URL: %w", err)
+	}
+
+	endpoint.Path = path
+	query := endpoint.Query()
+	query.Set("page", strconv.Itoa(page))
+	query.Set("limit", strconv.Itoa(limit))
+	endpoint.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not create request object: %w", err)
+	}
+
+	response, err := a.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error when executing request: %w", err)
+	}
+	defer func() { _ = response.Body.Close() }()
+
+	if err := client.CheckResponse(response, "get load balancer certificates"); err != nil {
+		return nil, err
+	}
+
+	payload := struct {
+		Data struct {
+			Data []CertificateInfo `json:"data"`
+		} `json:"data"`
+	}{}
+
+	err = json.NewDecoder(response.Body).Decode(&payload)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse load balancer certificate list response: %w", err)
+	}
+
+	return payload.Data.Data, nil
+}
+
+func (a api) GetByID(ctx context.Context, identifier string) (Certificate, error) {
+	endpoint, err := url.Parse(a.client.BaseURL())
+	if err != nil {
+		return Certificate{}, fmt.Errorf("could not parse URL: %w", err)
+	}
+
+	endpoint.Path = utils.Join(path, identifier)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint.String(), nil)
+	if err != nil {
+		return Certificate{}, fmt.Errorf("could not create request object: %w", err)
+	}
+
+	response, err := a.client.Do(req)
+	if err != nil {
+		return Certificate{}, fmt.Errorf("error when executing request for '%s': %w", identifier, err)
+	}
+	defer func() { _ = response.Body.Close() }()
+
+	request := fmt.Sprintf("execute get load balancer certificate request for '%s'", identifier)
+	if err := client.CheckResponse(response, request); err != nil {
+		return Certificate{}, err
+	}
+
+	var payload Certificate
+	err = json.NewDecoder(response.Body).Decode(&payload)
+	if err != nil {
+		return Certificate{}, fmt.Errorf("could not parse load balancer certificate response for '%s' : %w", identifier, err)
+	}
+
+	return payload, nil
+}
+
+// Upload validates the certificate and its private key and uploads them.
+//
+// Mismatching certificates and keys are rejected with ErrInvalidCertificate without sending a request.
+// The body of the request is redacted when it is logged, so the private key never ends up in the log.
+func (a api) Upload(ctx context.Context, definition Definition) (Certificate, error) {
+	if err := definition.Validate(); err != nil {
+		return Certificate{}, err
+	}
+
+	endpoint, err := url.Parse(a.client.BaseURL())
+	if err != nil {
+		return Certificate{}, fmt.Errorf("could not parse URL: %w", err)
+	}
+
+	endpoint.Path = path
+
+	req, err := client.NewJSONRequest(client.WithRedactedBody(ctx), http.MethodPost, endpoint.String(), definition)
+	if err != nil {
+		return Certificate{}, fmt.Errorf("could not create request object: %w", err)
+	}
+
+	response, err := a.client.Do(req)
+	if err != nil {
+		return Certificate{}, fmt.Errorf("error when uploading certificate '%s': %w", definition.Name, err)
+	}
+	defer func() { _ = response.Body.Close() }()
+
+	if err := client.CheckResponse(response, fmt.Sprintf("upload load balancer certificate '%s'", definition.Name)); err != nil {
+		return Certificate{}, err
+	}
+
+	var payload Certificate
+	err = json.NewDecoder(response.Body).Decode(&payload)
+	if err != nil {
+		return Certificate{}, fmt.Errorf("could not parse load balancer certificate upload response for '%s' : %w",
+			definition.Name, err)
+	}
+
+	return payload, nil
+}
+
+func (a api) DeleteByID(ctx context.Context, identifier string) error {
+	endpoint, err := url.Parse(a.client.BaseURL())
+	if err != nil {
+		return fmt.Errorf("could not parse URL: %w", err)
+	}
+
+	endpoint.Path = utils.Join(path, identifier)
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, endpoint.String(), nil)
+	if err != nil {
+		return fmt.Errorf("could not create request object: %w", err)
+	}
+
+	response, err := a.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("error when deleting a LBaaS certificate '%s': %w", identifier, err)
+	}
+	defer func() { _ = response.Body.Close() }()
+
+	if err := client.CheckResponse(response, fmt.Sprintf("delete LBaaS certificate '%s'", identifier)); err != nil {
+		return err
+	}
+
+	return nil
+}
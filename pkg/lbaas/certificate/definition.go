@@ -0,0 +1,67 @@
+package certificate
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidCertificate is raised if a certificate, its chain or its private key can not be parsed,
+// or if the certificate and the private key do not match.
+var ErrInvalidCertificate = errors.New("invalid certificate")
+
+// Definition describes a certificate to be uploaded.
+type Definition struct {
+	Name string `json:"name"`
+	// Certificate is the PEM encoded certificate.
+	Certificate string `json:"certificate"`
+	// PrivateKey is the PEM encoded private key of the certificate. It is never written to the log.
+	PrivateKey string `json:"private_key"`
+	// Chain optionally holds the PEM encoded intermediate certificates.
+	Chain string `json:"chain,omitempty"`
+}
+
+// Validate checks that the certificate and chain are PEM encoded certificates and that the private key
+// belongs to the certificate.
+func (d Definition) Validate() error {
+	if err := parseCertificates(d.Certificate); err != nil {
+		return fmt.Errorf("%w: certificate '%s': %v", ErrInvalidCertificate, d.Name, err)
+	}
+	if d.Chain != "" {
+		if err := parseCertificates(d.Chain); err != nil {
+			return fmt.Errorf("%w: chain of certificate '%s': %v", ErrInvalidCertificate, d.Name, err)
+		}
+	}
+	// The error of X509KeyPair never contains the private key.
+	if _, err := tls.X509KeyPair([]byte(d.Certificate), []byte(d.PrivateKey)); err != nil {
+		return fmt.Errorf("%w: private key of certificate '%s': %v", ErrInvalidCertificate, d.Name, err)
+	}
+
+	return nil
+}
+
+// parseCertificates parses all PEM encoded certificates in data, requiring at least one.
+func parseCertificates(data string) error {
+	found := false
+	rest := []byte(data)
+	for {
+		var block *pem.Block
+		if block, rest = pem.Decode(rest); block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			return fmt.Errorf("unexpected PEM block '%s'", block.Type)
+		}
+		if _, err := x509.ParseCertificate(block.Bytes); err != nil {
+			return err
+		}
+		found = true
+	}
+	if !found {
+		return errors.New("no PEM encoded certificate found")
+	}
+
+	return nil
+}
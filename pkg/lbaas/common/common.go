@@ -1,5 +1,10 @@
 package common
 
+import (
+	"errors"
+	"fmt"
+)
+
 type Mode string
 
 const (
@@ -16,3 +21,23 @@ const (
 	Deployed        = State("3")
 	NewlyCreated    = State("4")
 )
+
+// ErrAlreadyExists is raised if a resource to be created conflicts with an existing one.
+var ErrAlreadyExists = errors.New("resource already exists")
+
+// AlreadyExistsError is returned if a resource with the same name already exists.
+type AlreadyExistsError struct {
+	// Name of the conflicting resource.
+	Name string
+	// Identifier of the existing resource.
+	Identifier string
+}
+
+func (e *AlreadyExistsError) Error() string {
+	return fmt.Sprintf("%v: '%s' with identifier '%s'", ErrAlreadyExists, e.Name, e.Identifier)
+}
+
+// Is reports whether target is ErrAlreadyExists.
+func (e *AlreadyExistsError) Is(target error) bool {
+	return target == ErrAlreadyExists
+}
@@ -0,0 +1,23 @@
+package common
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// ConfigHash returns a stable hash over the given configuration fields.
+//
+// Fields are hashed in the given order, so callers have to pass them in a fixed order
+// for the hash to be comparable.
+func ConfigHash(fields ...interface{}) string {
+	parts := make([]string, 0, len(fields))
+	for _, field := range fields {
+		// %q keeps field boundaries unambiguous, so ("a b", "c") and ("a", "b c") hash differently.
+		parts = append(parts, fmt.Sprintf("%q", fmt.Sprint(field)))
+	}
+	sum := sha256.Sum256([]byte(strings.Join(parts, ",")))
+
+	return hex.EncodeToString(sum[:])
+}
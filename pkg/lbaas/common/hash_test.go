@@ -0,0 +1,19 @@
+package common_test
+
+import (
+	"testing"
+
+	"github.com/anexia-it/go-anxcloud/pkg/lbaas/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigHash(t *testing.T) {
+	hash := common.ConfigHash("web", "192.0.2.1", 80, true)
+	assert.Len(t, hash, 64)
+	assert.Equal(t, hash, common.ConfigHash("web", "192.0.2.1", 80, true), "the hash must be stable")
+
+	assert.NotEqual(t, hash, common.ConfigHash("192.0.2.1", "web", 80, true), "the order of fields must matter")
+	assert.NotEqual(t, hash, common.ConfigHash("web", "192.0.2.1", 81, true))
+	assert.NotEqual(t, common.ConfigHash("a b", "c"), common.ConfigHash("a", "b c"), "field boundaries must be kept")
+	assert.NotEqual(t, common.ConfigHash("a", ""), common.ConfigHash("a"), "empty fields must count")
+}
@@ -0,0 +1,52 @@
+package common
+
+import (
+	"fmt"
+	"net/url"
+)
+
+const (
+	// OptNameSearch is the query parameter to search listings by name.
+	OptNameSearch = "search"
+	// OptNameFilter is the query parameter to filter listings by the value of a field.
+	OptNameFilter = "filter"
+	// OptNameDetailed is the query parameter to list the full representation of the entries.
+	OptNameDetailed = "detailed"
+)
+
+// ListOption narrows down the entries of a listing.
+type ListOption func(query url.Values)
+
+// Search lets a listing only return the entries whose name contains term.
+func Search(term string) ListOption {
+	return func(query url.Values) {
+		query.Set(OptNameSearch, term)
+	}
+}
+
+// Filter lets a listing only return the entries whose field has the given value, e.g. Filter("state", "3").
+// Multiple filters are combined, an entry has to match all of them.
+func Filter(field, value string) ListOption {
+	return func(query url.Values) {
+		query.Add(OptNameFilter, fmt.Sprintf("%s:%s", field, value))
+	}
+}
+
+// StateFilter lets a listing only return the entries in the given state.
+func StateFilter(state State) ListOption {
+	return Filter("state", string(state))
+}
+
+// Detailed lets a listing return the full representation of every entry instead of only its identifier and name.
+func Detailed() ListOption {
+	return func(query url.Values) {
+		query.Set(OptNameDetailed, "true")
+	}
+}
+
+// ApplyListOptions sets the query parameters of the given options.
+func ApplyListOptions(query url.Values, opts ...ListOption) {
+	for _, opt := range opts {
+		opt(query)
+	}
+}
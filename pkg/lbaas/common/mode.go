@@ -0,0 +1,52 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrIncompatibleMode is raised if a load balancer resource uses a feature its mode does not support.
+var ErrIncompatibleMode = errors.New("incompatible load balancer mode")
+
+// IsCompatibleWith returns true if a resource in mode m supports features requiring the given mode.
+//
+// TCP features are supported by every mode, HTTP features only by HTTP resources. An empty mode is left
+// to the API to default and treated as compatible.
+func (m Mode) IsCompatibleWith(required Mode) bool {
+	if m == "" || required == "" || required == TCP {
+		return true
+	}
+
+	return strings.EqualFold(string(m), string(required))
+}
+
+// ModeError is returned if a feature of a load balancer resource requires a mode the resource does not have.
+type ModeError struct {
+	// Resource describes the resource, e.g. "backend 'web'".
+	Resource string
+	// Feature describes the feature requiring another mode, e.g. "health check 'httpchk GET /'".
+	Feature string
+	// Mode is the mode of the resource.
+	Mode Mode
+	// Required is the mode required by the feature.
+	Required Mode
+}
+
+func (e *ModeError) Error() string {
+	return fmt.Sprintf("%v: %s requires mode %s, but %s has mode %s", ErrIncompatibleMode, e.Feature, e.Required, e.Resource, e.Mode)
+}
+
+// Is reports whether target is ErrIncompatibleMode.
+func (e *ModeError) Is(target error) bool {
+	return target == ErrIncompatibleMode
+}
+
+// CheckMode returns a ModeError if a resource in the given mode does not support a feature requiring the other mode.
+func CheckMode(resource string, mode Mode, feature string, required Mode) error {
+	if mode.IsCompatibleWith(required) {
+		return nil
+	}
+
+	return &ModeError{Resource: resource, Feature: feature, Mode: mode, Required: required}
+}
@@ -0,0 +1,35 @@
+package common_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/anexia-it/go-anxcloud/pkg/lbaas/backend"
+	"github.com/anexia-it/go-anxcloud/pkg/lbaas/common"
+	"github.com/anexia-it/go-anxcloud/pkg/lbaas/rule"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestModeIsCompatibleWith(t *testing.T) {
+	assert.True(t, common.TCP.IsCompatibleWith(common.TCP))
+	assert.True(t, common.HTTP.IsCompatibleWith(common.TCP))
+	assert.True(t, common.HTTP.IsCompatibleWith(common.HTTP))
+	assert.False(t, common.TCP.IsCompatibleWith(common.HTTP))
+	assert.True(t, common.Mode("").IsCompatibleWith(common.HTTP))
+}
+
+func TestModeValidation(t *testing.T) {
+	err := backend.Definition{Name: "web", Mode: common.TCP, HealthCheck: "httpchk GET /health"}.Validate()
+	assert.True(t, errors.Is(err, common.ErrIncompatibleMode), "expected ErrIncompatibleMode but got %v", err)
+	var modeErr *common.ModeError
+	if assert.True(t, errors.As(err, &modeErr)) {
+		assert.Equal(t, common.HTTP, modeErr.Required)
+	}
+
+	assert.NoError(t, backend.Definition{Name: "web", Mode: common.TCP, HealthCheck: "tcp-check"}.Validate())
+	assert.NoError(t, backend.Definition{Name: "web", Mode: common.HTTP, HealthCheck: "httpchk GET /health"}.Validate())
+
+	err = rule.Condition{PathPrefix: "/api"}.Validate(common.TCP)
+	assert.True(t, errors.Is(err, common.ErrIncompatibleMode), "expected ErrIncompatibleMode but got %v", err)
+	assert.NoError(t, rule.Condition{}.Validate(common.TCP))
+}
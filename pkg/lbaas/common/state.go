@@ -0,0 +1,49 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// maxWaitIntervalFactor limits how far WaitForState backs off, relative to the initial interval.
+const maxWaitIntervalFactor = 8
+
+// ErrDeploymentFailed is raised by WaitForState if the resource reached the DeploymentError state.
+var ErrDeploymentFailed = errors.New("deployment of LBaaS resource failed")
+
+// WaitForState polls the state of a resource via getter until it reaches target.
+//
+// The first poll happens immediately. The delay between polls starts at interval and grows by half
+// with every poll, up to eight times interval, so long deployments do not hammer the API.
+// An error wrapping ErrDeploymentFailed is returned if the resource reaches DeploymentError while waiting
+// for another state. Errors of getter are returned as they are. ctx limits the total time to wait.
+func WaitForState(ctx context.Context, getter func() (State, error), target State, interval time.Duration) error {
+	delay := interval
+	for {
+		state, err := getter()
+		if err != nil {
+			return err
+		}
+		if state == target {
+			return nil
+		}
+		if state == DeploymentError {
+			return fmt.Errorf("%w while waiting for state %q", ErrDeploymentFailed, target)
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("state %q was not reached, last state was %q: %w", target, state, ctx.Err())
+		}
+
+		delay += delay / 2
+		if maxDelay := interval * maxWaitIntervalFactor; delay > maxDelay {
+			delay = maxDelay
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package common_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/anexia-it/go-anxcloud/pkg/lbaas/common"
+	"github.com/stretchr/testify/assert"
+)
+
+// stateSequence returns a getter returning the given states in order, repeating the last one.
+func stateSequence(states ...common.State) (func() (common.State, error), *int) {
+	calls := 0
+	return func() (common.State, error) {
+		state := states[len(states)-1]
+		if calls < len(states) {
+			state = states[calls]
+		}
+		calls++
+		return state, nil
+	}, &calls
+}
+
+func TestWaitForState(t *testing.T) {
+	t.Run("Reached", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		getter, calls := stateSequence(common.Updating, common.Updating, common.Deployed)
+		assert.NoError(t, common.WaitForState(ctx, getter, common.Deployed, time.Millisecond))
+		assert.Equal(t, 3, *calls)
+	})
+
+	t.Run("DeploymentError", func(t *testing.T) {
+		getter, _ := stateSequence(common.Updating, common.DeploymentError)
+		err := common.WaitForState(context.Background(), getter, common.Deployed, time.Millisecond)
+		assert.True(t, errors.Is(err, common.ErrDeploymentFailed), "expected ErrDeploymentFailed but got %v", err)
+	})
+
+	t.Run("Timeout", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		getter, _ := stateSequence(common.Updating)
+		err := common.WaitForState(ctx, getter, common.Deployed, time.Millisecond)
+		assert.True(t, errors.Is(err, context.DeadlineExceeded), "expected context.DeadlineExceeded but got %v", err)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/anexia-it/go-anxcloud/pkg/client"
+	"github.com/anexia-it/go-anxcloud/pkg/pagination"
 )
 
 // API contains methods for load balancer frontend management.
@@ -11,7 +12,10 @@ type API interface {
 	Get(ctx context.Context, page, limit int) ([]FrontendInfo, error)
 	GetByID(ctx context.Context, identifier string) (Frontend, error)
 	Create(ctx context.Context, definition Definition) (Frontend, error)
+	Update(ctx context.Context, identifier string, definition Definition) (Frontend, error)
 	DeleteByID(ctx context.Context, identifier string) error
+
+	pagination.Pageable
 }
 
 type api struct {
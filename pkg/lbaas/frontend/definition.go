@@ -8,4 +8,7 @@ type Definition struct {
 	DefaultBackend string       `json:"default_backend"`
 	Mode           common.Mode  `json:"mode"`
 	State          common.State `json:"state"`
+	// Certificate is the identifier of the certificate used to terminate TLS, see package certificate.
+	// Frontends without certificate do not terminate TLS.
+	Certificate string `json:"certificate,omitempty"`
 }
@@ -5,7 +5,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"github.com/anexia-it/go-anxcloud/pkg/client"
 	"github.com/anexia-it/go-anxcloud/pkg/lbaas/backend"
+	"github.com/anexia-it/go-anxcloud/pkg/lbaas/certificate"
+	"github.com/anexia-it/go-anxcloud/pkg/lbaas/common"
 	"github.com/anexia-it/go-anxcloud/pkg/lbaas/loadbalancer"
 	"net/http"
 	"net/url"
@@ -29,10 +32,27 @@ type Frontend struct {
 	Name               string                         `json:"name"`
 	LoadBalancer       *loadbalancer.LoadBalancerInfo `json:"load_balancer,omitempty"`
 	DefaultBackend     *backend.BackendInfo           `json:"default_backend,omitempty"`
+	Certificate        *certificate.CertificateInfo   `json:"certificate,omitempty"`
 	Mode               string                         `json:"mode"`
 	ClientTimeout      string                         `json:"client_timeout"`
 }
 
+// ConfigHash returns a stable hash of the configuration of the frontend.
+//
+// Included are, in this order, the name, the load balancer identifier, the default backend identifier,
+// the mode and the client timeout. Identifiers of the frontend itself and its owners are not included.
+func (f Frontend) ConfigHash() string {
+	loadBalancer, defaultBackend := "", ""
+	if f.LoadBalancer != nil {
+		loadBalancer = f.LoadBalancer.Identifier
+	}
+	if f.DefaultBackend != nil {
+		defaultBackend = f.DefaultBackend.Identifier
+	}
+
+	return common.ConfigHash(f.Name, loadBalancer, defaultBackend, f.Mode, f.ClientTimeout)
+}
+
 func (a api) Get(ctx context.Context, page, limit int) ([]FrontendInfo, error) {
 	endpoint, err := url.Parse(a.client.BaseURL())
 	if err != nil {
@@ -143,6 +163,42 @@ func (a api) Create(ctx context.Context, definition Definition) (Frontend, error
 	return payload, nil
 }
 
+func (a api) Update(ctx context.Context, identifier string, definition Definition) (Frontend, error) {
+	endpoint, err := url.Parse(a.client.BaseURL())
+	if err != nil {
+		return Frontend{}, fmt.Errorf("could not parse URL: %w", err)
+	}
+
+	endpoint.Path = utils.Join(path, identifier)
+
+	buf := bytes.Buffer{}
+	if err := json.NewEncoder(&buf).Encode(definition); err != nil {
+		return Frontend{}, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, endpoint.String(), &buf)
+	if err != nil {
+		return Frontend{}, fmt.Errorf("could not create request object: %w", err)
+	}
+
+	response, err := a.client.Do(req)
+	if err != nil {
+		return Frontend{}, fmt.Errorf("error when updating LBaaS frontend '%s': %w", identifier, err)
+	}
+	defer func() { _ = response.Body.Close() }()
+
+	if err := client.CheckResponse(response, fmt.Sprintf("update LBaaS frontend '%s'", identifier)); err != nil {
+		return Frontend{}, err
+	}
+
+	var payload Frontend
+	err = json.NewDecoder(response.Body).Decode(&payload)
+	if err != nil {
+		return Frontend{}, fmt.Errorf("could not parse loadbalancer frontend update response for '%s': %w", identifier, err)
+	}
+
+	return payload, nil
+}
+
 func (a api) DeleteByID(ctx context.Context, identifier string) error {
 	endpoint, err := url.Parse(a.client.BaseURL())
 	if err != nil {
@@ -0,0 +1,84 @@
+package frontend_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/anexia-it/go-anxcloud/pkg/client"
+	"github.com/anexia-it/go-anxcloud/pkg/lbaas/backend"
+	"github.com/anexia-it/go-anxcloud/pkg/lbaas/frontend"
+	"github.com/anexia-it/go-anxcloud/pkg/lbaas/loadbalancer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFrontendConfigHash(t *testing.T) {
+	c, server := client.NewTestClient(nil, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.EqualValues(t, "/api/LBaaS/v1/frontend.json/frontend-id", r.URL.Path)
+		_, _ = w.Write([]byte(`{"identifier":"frontend-id","customer_identifier":"customer","name":"web",` +
+			`"load_balancer":{"identifier":"lb-id"},"default_backend":{"identifier":"backend-id"},"mode":"http","client_timeout":"10"}`))
+	}))
+	defer server.Close()
+
+	fetched, err := frontend.NewAPI(c).GetByID(context.Background(), "frontend-id")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	same := fetched
+	same.Identifier, same.CustomerIdentifier = "other-id", "other"
+	same.DefaultBackend = &backend.BackendInfo{Identifier: "backend-id", Name: "renamed"}
+	assert.Equal(t, fetched.ConfigHash(), same.ConfigHash(), "only identifiers of referenced resources must be hashed")
+
+	for name, change := range map[string]func(f *frontend.Frontend){
+		"Name":             func(f *frontend.Frontend) { f.Name = "api" },
+		"LoadBalancer":     func(f *frontend.Frontend) { f.LoadBalancer = &loadbalancer.LoadBalancerInfo{Identifier: "other-lb"} },
+		"NoLoadBalancer":   func(f *frontend.Frontend) { f.LoadBalancer = nil },
+		"DefaultBackend":   func(f *frontend.Frontend) { f.DefaultBackend = &backend.BackendInfo{Identifier: "other"} },
+		"NoDefaultBackend": func(f *frontend.Frontend) { f.DefaultBackend = nil },
+		"Mode":             func(f *frontend.Frontend) { f.Mode = "tcp" },
+		"ClientTimeout":    func(f *frontend.Frontend) { f.ClientTimeout = "20" },
+	} {
+		changed := fetched
+		change(&changed)
+		assert.NotEqual(t, fetched.ConfigHash(), changed.ConfigHash(), name)
+	}
+}
+
+type rawClient struct {
+	baseURL string
+}
+
+func (c rawClient) BaseURL() string {
+	return c.baseURL
+}
+
+func (c rawClient) Do(req *http.Request) (*http.Response, error) {
+	return http.DefaultClient.Do(req)
+}
+
+func TestErrorResponses(t *testing.T) {
+	for _, status := range []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict} {
+		_, server := client.NewTestClient(nil, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(`{"error":{"code":` + strconv.Itoa(status) + `,"message":"rejected"}}`))
+		}))
+
+		api := frontend.NewAPI(rawClient{server.URL})
+		ctx := context.Background()
+
+		_, updateErr := api.Update(ctx, "frontend-id", frontend.Definition{Name: "www"})
+		_, pageErr := api.GetPage(ctx, 1, 10)
+
+		for _, err := range []error{updateErr, pageErr} {
+			var responseErr *client.ResponseError
+			if assert.True(t, errors.As(err, &responseErr), "expected ResponseError for %d but got %v", status, err) {
+				assert.EqualValues(t, status, responseErr.ErrorData.Code)
+				assert.EqualValues(t, "rejected", responseErr.ErrorData.Message)
+			}
+		}
+		server.Close()
+	}
+}
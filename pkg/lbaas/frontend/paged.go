@@ -0,0 +1,90 @@
+package frontend
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/anexia-it/go-anxcloud/pkg/client"
+	"github.com/anexia-it/go-anxcloud/pkg/pagination"
+)
+
+// FrontendPage is a single page of the load balancer frontend listing.
+type FrontendPage struct {
+	Page       int            `json:"page"`
+	TotalItems int            `json:"total_items"`
+	TotalPages int            `json:"total_pages"`
+	Limit      int            `json:"limit"`
+	Data       []FrontendInfo `json:"data"`
+}
+
+// Num returns the number of this page.
+func (p FrontendPage) Num() int {
+	return p.Page
+}
+
+// Size returns the maximum number of entries of this page.
+func (p FrontendPage) Size() int {
+	return p.Limit
+}
+
+// Total returns the total number of pages.
+func (p FrontendPage) Total() int {
+	return p.TotalPages
+}
+
+// TotalCount returns the total number of entries over all pages.
+func (p FrontendPage) TotalCount() int {
+	return p.TotalItems
+}
+
+// Content returns the entries of this page as []FrontendInfo.
+func (p FrontendPage) Content() interface{} {
+	return p.Data
+}
+
+func (a api) GetPage(ctx context.Context, page, limit int) (pagination.Page, error) {
+	endpoint, err := url.Parse(a.client.BaseURL())
+	if err != nil {
+		return nil, fmt.Errorf("could not parse URL: %w", err)
+	}
+
+	endpoint.Path = path
+	query := endpoint.Query()
+	query.Set("page", strconv.Itoa(page))
+	query.Set("limit", strconv.Itoa(limit))
+	endpoint.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not create request object: %w", err)
+	}
+
+	response, err := a.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error when executing request: %w", err)
+	}
+
+	if err := client.CheckResponse(response, "get load balancer frontends"); err != nil {
+		return nil, err
+	}
+
+	payload := struct {
+		Data FrontendPage `json:"data"`
+	}{}
+
+	err = json.NewDecoder(response.Body).Decode(&payload)
+	_ = response.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("could not parse load balancer frontend page response: %w", err)
+	}
+
+	return payload.Data, nil
+}
+
+func (a api) NextPage(ctx context.Context, page pagination.Page) (pagination.Page, error) {
+	return a.GetPage(ctx, page.Num()+1, page.Size())
+}
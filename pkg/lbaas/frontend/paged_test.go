@@ -0,0 +1,43 @@
+package frontend_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/anexia-it/go-anxcloud/pkg/client"
+	"github.com/anexia-it/go-anxcloud/pkg/lbaas/frontend"
+	"github.com/anexia-it/go-anxcloud/pkg/pagination"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFrontendPages(t *testing.T) {
+	c, server := client.NewTestClient(nil, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.EqualValues(t, "/api/LBaaS/v1/frontend.json", r.URL.Path)
+		assert.EqualValues(t, "2", r.URL.Query().Get("limit"))
+
+		data := `{"identifier":"1","name":"first"},{"identifier":"2","name":"second"}`
+		if r.URL.Query().Get("page") == "2" {
+			data = `{"identifier":"3","name":"third"}`
+		}
+		fmt.Fprintf(w, `{"data":{"page":%s,"total_items":3,"total_pages":2,"limit":2,"data":[%s]}}`,
+			r.URL.Query().Get("page"), data)
+	}))
+	defer server.Close()
+
+	api := frontend.NewAPI(c)
+	page, err := api.GetPage(context.Background(), 1, 2)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, 1, page.Num())
+	assert.Equal(t, 2, page.Total())
+	assert.Equal(t, 3, page.TotalCount())
+	assert.True(t, pagination.HasNext(page))
+
+	items, err := pagination.Collect(context.Background(), api, pagination.PageSize(2))
+	if assert.NoError(t, err) && assert.Len(t, items, 3) {
+		assert.Equal(t, frontend.FrontendInfo{Identifier: "3", Name: "third"}, items[2])
+	}
+}
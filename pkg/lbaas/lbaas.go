@@ -1,20 +1,26 @@
+// Package lbaas contains API functionality for load balancer as a service.
 package lbaas
 
 import (
 	"github.com/anexia-it/go-anxcloud/pkg/client"
 	"github.com/anexia-it/go-anxcloud/pkg/lbaas/backend"
 	"github.com/anexia-it/go-anxcloud/pkg/lbaas/bind"
+	"github.com/anexia-it/go-anxcloud/pkg/lbaas/certificate"
 	"github.com/anexia-it/go-anxcloud/pkg/lbaas/frontend"
 	"github.com/anexia-it/go-anxcloud/pkg/lbaas/loadbalancer"
+	"github.com/anexia-it/go-anxcloud/pkg/lbaas/rule"
 	"github.com/anexia-it/go-anxcloud/pkg/lbaas/server"
 )
 
+// API contains methods for load balancer as a service management.
 type API interface {
 	LoadBalancer() loadbalancer.API
 	Frontend() frontend.API
 	Backend() backend.API
 	Server() server.API
 	Bind() bind.API
+	Rule() rule.API
+	Certificate() certificate.API
 }
 
 type api struct {
@@ -23,12 +29,22 @@ type api struct {
 	backend      backend.API
 	server       server.API
 	bind         bind.API
+	rule         rule.API
+	certificate  certificate.API
 }
 
 func (a api) Bind() bind.API {
 	return a.bind
 }
 
+func (a api) Rule() rule.API {
+	return a.rule
+}
+
+func (a api) Certificate() certificate.API {
+	return a.certificate
+}
+
 func (a api) Backend() backend.API {
 	return a.backend
 }
@@ -45,14 +61,15 @@ func (a api) Frontend() frontend.API {
 	return a.frontend
 }
 
-// NewAPI creates a new vmlist API instance with the given client.
+// NewAPI creates a new lbaas API instance with the given client.
 func NewAPI(c client.Client) API {
-
 	return &api{
 		loadBalancer: loadbalancer.NewAPI(c),
 		frontend:     frontend.NewAPI(c),
 		backend:      backend.NewAPI(c),
 		server:       server.NewAPI(c),
 		bind:         bind.NewAPI(c),
+		rule:         rule.NewAPI(c),
+		certificate:  certificate.NewAPI(c),
 	}
 }
@@ -0,0 +1,29 @@
+package rule
+
+import (
+	"context"
+
+	"github.com/anexia-it/go-anxcloud/pkg/client"
+	"github.com/anexia-it/go-anxcloud/pkg/pagination"
+)
+
+// API contains methods for load balancer rule management.
+type API interface {
+	Get(ctx context.Context, page, limit int) ([]RuleInfo, error)
+	GetByID(ctx context.Context, identifier string) (Rule, error)
+	Create(ctx context.Context, definition Definition) (Rule, error)
+	Update(ctx context.Context, identifier string, definition Definition) (Rule, error)
+	DeleteByID(ctx context.Context, identifier string) error
+	DetailedPages() pagination.Pageable
+
+	pagination.Pageable
+}
+
+type api struct {
+	client client.Client
+}
+
+// NewAPI creates a new load balancer rule API instance with the given client.
+func NewAPI(c client.Client) API {
+	return &api{c}
+}
@@ -0,0 +1,40 @@
+package rule
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/anexia-it/go-anxcloud/pkg/lbaas/common"
+)
+
+// ErrInvalidDefinition is raised if a rule definition is incomplete.
+var ErrInvalidDefinition = errors.New("invalid rule definition")
+
+// Definition describes a load balancer rule to be created or updated.
+type Definition struct {
+	Name     string       `json:"name"`
+	State    common.State `json:"state"`
+	Frontend string       `json:"frontend"`
+	// Backend receives the requests matching Condition.
+	Backend   string    `json:"backend"`
+	Condition Condition `json:"condition"`
+	// Priority determines the order rules are evaluated in, lower values first.
+	Priority int `json:"priority"`
+}
+
+// Validate checks that the definition references a frontend and a backend and has no negative priority.
+//
+// The condition can only be checked against the mode of the frontend, which Create and Update do before
+// sending the request, see Condition.Validate.
+func (d Definition) Validate() error {
+	switch {
+	case d.Frontend == "":
+		return fmt.Errorf("%w: rule '%s' has no frontend", ErrInvalidDefinition, d.Name)
+	case d.Backend == "":
+		return fmt.Errorf("%w: rule '%s' has no backend", ErrInvalidDefinition, d.Name)
+	case d.Priority < 0:
+		return fmt.Errorf("%w: rule '%s' has negative priority %d", ErrInvalidDefinition, d.Name, d.Priority)
+	}
+
+	return nil
+}
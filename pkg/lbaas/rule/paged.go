@@ -0,0 +1,153 @@
+package rule
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/anexia-it/go-anxcloud/pkg/client"
+	"github.com/anexia-it/go-anxcloud/pkg/lbaas/common"
+	"github.com/anexia-it/go-anxcloud/pkg/pagination"
+)
+
+// RulePage is a single page of the load balancer rule listing.
+type RulePage struct {
+	Page       int        `json:"page"`
+	TotalItems int        `json:"total_items"`
+	TotalPages int        `json:"total_pages"`
+	Limit      int        `json:"limit"`
+	Data       []RuleInfo `json:"data"`
+}
+
+// Num returns the number of this page.
+func (p RulePage) Num() int {
+	return p.Page
+}
+
+// Size returns the maximum number of entries of this page.
+func (p RulePage) Size() int {
+	return p.Limit
+}
+
+// Total returns the total number of pages.
+func (p RulePage) Total() int {
+	return p.TotalPages
+}
+
+// TotalCount returns the total number of entries over all pages.
+func (p RulePage) TotalCount() int {
+	return p.TotalItems
+}
+
+// Content returns the entries of this page as []RuleInfo.
+func (p RulePage) Content() interface{} {
+	return p.Data
+}
+
+func (a api) GetPage(ctx context.Context, page, limit int) (pagination.Page, error) {
+	payload := struct {
+		Data RulePage `json:"data"`
+	}{}
+	if err := a.fetchPage(ctx, page, limit, &payload); err != nil {
+		return nil, err
+	}
+
+	return payload.Data, nil
+}
+
+func (a api) NextPage(ctx context.Context, page pagination.Page) (pagination.Page, error) {
+	return a.GetPage(ctx, page.Num()+1, page.Size())
+}
+
+// detailedPage is a single page of the rule listing holding the full representation of every rule.
+type detailedPage struct {
+	Page       int    `json:"page"`
+	TotalItems int    `json:"total_items"`
+	TotalPages int    `json:"total_pages"`
+	Limit      int    `json:"limit"`
+	Data       []Rule `json:"data"`
+}
+
+func (p detailedPage) Num() int {
+	return p.Page
+}
+
+func (p detailedPage) Size() int {
+	return p.Limit
+}
+
+func (p detailedPage) Total() int {
+	return p.TotalPages
+}
+
+func (p detailedPage) TotalCount() int {
+	return p.TotalItems
+}
+
+// Content returns the entries of this page as []Rule.
+func (p detailedPage) Content() interface{} {
+	return p.Data
+}
+
+// DetailedPages returns the paged listing of the full rules, its pages contain []Rule.
+func (a api) DetailedPages() pagination.Pageable {
+	return detailedPager{a}
+}
+
+type detailedPager struct {
+	api api
+}
+
+func (d detailedPager) GetPage(ctx context.Context, page, limit int) (pagination.Page, error) {
+	payload := struct {
+		Data detailedPage `json:"data"`
+	}{}
+	if err := d.api.fetchPage(ctx, page, limit, &payload, common.Detailed()); err != nil {
+		return nil, err
+	}
+
+	return payload.Data, nil
+}
+
+func (d detailedPager) NextPage(ctx context.Context, page pagination.Page) (pagination.Page, error) {
+	return d.GetPage(ctx, page.Num()+1, page.Size())
+}
+
+// fetchPage requests a page of the rule listing and decodes the response into payload.
+func (a api) fetchPage(ctx context.Context, page, limit int, payload interface{}, opts ...common.ListOption) error {
+	endpoint, err := url.Parse(a.client.BaseURL())
+	if err != nil {
+		return fmt.Errorf("could not parse URL: %w", err)
+	}
+
+	endpoint.Path = path
+	query := endpoint.Query()
+	common.ApplyListOptions(query, opts...)
+	query.Set("page", strconv.Itoa(page))
+	query.Set("limit", strconv.Itoa(limit))
+	endpoint.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint.String(), nil)
+	if err != nil {
+		return fmt.Errorf("could not create request object: %w", err)
+	}
+
+	response, err := a.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("error when executing request: %w", err)
+	}
+	defer func() { _ = response.Body.Close() }()
+
+	if err := client.CheckResponse(response, "get load balancer rules"); err != nil {
+		return err
+	}
+
+	if err := json.NewDecoder(response.Body).Decode(payload); err != nil {
+		return fmt.Errorf("could not parse load balancer rule page response: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,136 @@
+package rule
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/anexia-it/go-anxcloud/pkg/client"
+	"github.com/anexia-it/go-anxcloud/pkg/lbaas/backend"
+	"github.com/anexia-it/go-anxcloud/pkg/lbaas/frontend"
+	"github.com/anexia-it/go-anxcloud/pkg/pagination"
+)
+
+// ErrNoRoute is raised if no rule of a frontend matches and the frontend has no default backend.
+var ErrNoRoute = errors.New("no backend serves the request")
+
+// Matches returns true if a request to the given host and path fulfills the condition.
+// Host names are compared case-insensitively, a port of the host is ignored.
+func (c Condition) Matches(host, path string) bool {
+	return matchesHost(c.Host, host) && matchesPath(c.PathPrefix, path)
+}
+
+func matchesHost(pattern, host string) bool {
+	if pattern == "" {
+		return true
+	}
+	if hostname, _, err := net.SplitHostPort(host); err == nil {
+		host = hostname
+	}
+	pattern, host = strings.ToLower(pattern), strings.ToLower(strings.TrimSuffix(host, "."))
+
+	if strings.HasPrefix(pattern, "*.") {
+		return strings.HasSuffix(host, pattern[1:])
+	}
+
+	return pattern == host
+}
+
+func matchesPath(prefix, path string) bool {
+	if prefix == "" || prefix == "/" {
+		return true
+	}
+	prefix = strings.TrimSuffix(prefix, "/")
+
+	return path == prefix || strings.HasPrefix(path, prefix+"/")
+}
+
+// Evaluate returns the first of the given rules matching a request to the given host and path.
+//
+// Rules are evaluated by ascending priority. Rules with the same priority are evaluated by their identifier,
+// so the result does not depend on the order the rules are passed in.
+func Evaluate(rules []Rule, host, path string) (Rule, bool) {
+	sorted := make([]Rule, len(rules))
+	copy(sorted, rules)
+	SortByPriority(sorted)
+
+	for _, rule := range sorted {
+		if rule.Condition.Matches(host, path) {
+			return rule, true
+		}
+	}
+
+	return Rule{}, false
+}
+
+// SortByPriority sorts rules into the order they are evaluated in: by ascending priority, rules with the same
+// priority by their identifier.
+func SortByPriority(rules []Rule) {
+	sort.SliceStable(rules, func(i, j int) bool {
+		if rules[i].Priority != rules[j].Priority {
+			return rules[i].Priority < rules[j].Priority
+		}
+
+		return rules[i].Identifier < rules[j].Identifier
+	})
+}
+
+// ListByFrontend returns the rules of a frontend in the order they are evaluated in.
+//
+// This lists every rule, as the rule listing can not be filtered by frontend.
+func ListByFrontend(ctx context.Context, a API, frontendID string) ([]Rule, error) {
+	rules, err := frontendRules(ctx, a, frontendID)
+	if err != nil {
+		return nil, err
+	}
+	SortByPriority(rules)
+
+	return rules, nil
+}
+
+// ResolveRoute returns the backend serving a request to the given host and path on a frontend.
+//
+// ctx is attached to all requests and will cancel them on cancelation.
+//
+// The rules of the frontend are evaluated as documented at Evaluate. If none matches, the default backend
+// of the frontend is returned. If the frontend has no default backend either, ErrNoRoute is returned.
+// This lists every rule, as the rule listing can not be filtered by frontend.
+func ResolveRoute(ctx context.Context, c client.Client, frontendID, host, path string) (backend.BackendInfo, error) {
+	f, err := frontend.NewAPI(c).GetByID(ctx, frontendID)
+	if err != nil {
+		return backend.BackendInfo{}, fmt.Errorf("could not get LBaaS frontend '%s': %w", frontendID, err)
+	}
+
+	rules, err := frontendRules(ctx, NewAPI(c), frontendID)
+	if err != nil {
+		return backend.BackendInfo{}, err
+	}
+
+	if rule, ok := Evaluate(rules, host, path); ok {
+		return rule.Backend, nil
+	}
+	if f.DefaultBackend != nil {
+		return *f.DefaultBackend, nil
+	}
+
+	return backend.BackendInfo{}, fmt.Errorf("%w: frontend '%s', host '%s', path '%s'", ErrNoRoute, frontendID, host, path)
+}
+
+func frontendRules(ctx context.Context, a API, frontendID string) ([]Rule, error) {
+	items, err := pagination.CollectTyped[Rule](ctx, a.DetailedPages())
+	if err != nil {
+		return nil, fmt.Errorf("could not list LBaaS rules: %w", err)
+	}
+
+	var rules []Rule
+	for _, rule := range items {
+		if rule.Frontend.Identifier == frontendID {
+			rules = append(rules, rule)
+		}
+	}
+
+	return rules, nil
+}
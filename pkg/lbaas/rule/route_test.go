@@ -0,0 +1,102 @@
+package rule_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/anexia-it/go-anxcloud/pkg/client"
+	"github.com/anexia-it/go-anxcloud/pkg/lbaas/backend"
+	"github.com/anexia-it/go-anxcloud/pkg/lbaas/frontend"
+	"github.com/anexia-it/go-anxcloud/pkg/lbaas/rule"
+	"github.com/stretchr/testify/assert"
+)
+
+func newRule(identifier string, priority int, host, pathPrefix, backendID string) rule.Rule {
+	return rule.Rule{
+		Identifier: identifier,
+		Frontend:   frontend.FrontendInfo{Identifier: "frontend"},
+		Backend:    backend.BackendInfo{Identifier: backendID},
+		Condition:  rule.Condition{Host: host, PathPrefix: pathPrefix},
+		Priority:   priority,
+	}
+}
+
+var overlappingRules = []rule.Rule{
+	newRule("catch-all-host", 30, "*.example.com", "", "wildcard"),
+	newRule("api", 10, "www.example.com", "/api", "api"),
+	newRule("api-v2", 5, "www.example.com", "/api/v2", "api-v2"),
+	newRule("www", 20, "www.example.com", "", "www"),
+	newRule("www-same-priority", 20, "www.example.com", "", "www-same-priority"),
+}
+
+func TestEvaluate(t *testing.T) {
+	for _, testCase := range []struct {
+		host, path string
+		expected   string
+	}{
+		{"www.example.com", "/api/v2/users", "api-v2"},
+		{"www.example.com", "/api/v1/users", "api"},
+		{"www.example.com", "/api", "api"},
+		{"www.example.com", "/apidocs", "www"},
+		{"WWW.Example.com:443", "/", "www"},
+		{"shop.example.com", "/api", "wildcard"},
+	} {
+		matched, ok := rule.Evaluate(overlappingRules, testCase.host, testCase.path)
+		if assert.True(t, ok, "no rule matched %s%s", testCase.host, testCase.path) {
+			assert.EqualValues(t, testCase.expected, matched.Backend.Identifier, "%s%s", testCase.host, testCase.path)
+		}
+	}
+
+	_, ok := rule.Evaluate(overlappingRules, "example.org", "/")
+	assert.False(t, ok)
+}
+
+func TestResolveRoute(t *testing.T) {
+	rules := map[string]rule.Rule{}
+	for _, r := range overlappingRules {
+		rules[r.Identifier] = r
+	}
+	rules["other-frontend"] = rule.Rule{Identifier: "other-frontend", Frontend: frontend.FrontendInfo{Identifier: "other"},
+		Backend: backend.BackendInfo{Identifier: "other"}}
+
+	c, server := client.NewTestClient(nil, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload interface{}
+		switch {
+		case r.URL.Path == "/api/LBaaS/v1/frontend.json/frontend":
+			payload = frontend.Frontend{Identifier: "frontend", DefaultBackend: &backend.BackendInfo{Identifier: "default"}}
+		case r.URL.Path == "/api/LBaaS/v1/rule.json":
+			payload = rulePage(rules, r.URL.Query().Get("detailed") == "true")
+		default:
+			t.Errorf("rules must be taken from the listing, but %s was requested", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		assert.NoError(t, json.NewEncoder(w).Encode(payload))
+	}))
+	defer server.Close()
+
+	info, err := rule.ResolveRoute(context.Background(), c, "frontend", "www.example.com", "/api/v2")
+	assert.NoError(t, err)
+	assert.EqualValues(t, "api-v2", info.Identifier)
+
+	info, err = rule.ResolveRoute(context.Background(), c, "frontend", "example.org", "/")
+	assert.NoError(t, err)
+	assert.EqualValues(t, "default", info.Identifier)
+}
+
+func TestResolveRouteNoDefault(t *testing.T) {
+	c, server := client.NewTestClient(nil, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/LBaaS/v1/rule.json" {
+			_, _ = w.Write([]byte(`{"data":{"page":1,"total_pages":1,"total_items":0,"limit":20,"data":[]}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"identifier":"frontend"}`))
+	}))
+	defer server.Close()
+
+	_, err := rule.ResolveRoute(context.Background(), c, "frontend", "www.example.com", "/")
+	assert.True(t, errors.Is(err, rule.ErrNoRoute), "expected ErrNoRoute but got %v", err)
+}
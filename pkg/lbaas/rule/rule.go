@@ -0,0 +1,270 @@
+// Package rule implements API functions residing under /LBaaS/v1/rule.
+// Rules route requests of a frontend matching their condition to a backend.
+package rule
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	utils "path"
+	"strconv"
+
+	"github.com/anexia-it/go-anxcloud/pkg/client"
+	"github.com/anexia-it/go-anxcloud/pkg/lbaas/backend"
+	"github.com/anexia-it/go-anxcloud/pkg/lbaas/common"
+	"github.com/anexia-it/go-anxcloud/pkg/lbaas/frontend"
+)
+
+const (
+	path = "api/LBaaS/v1/rule.json"
+)
+
+// RuleInfo holds the identifier and the name of a load balancer rule.
+type RuleInfo struct {
+	Identifier string `json:"identifier"`
+	Name       string `json:"name"`
+}
+
+// Condition is the condition a request has to match for a rule to apply. Empty fields match every request.
+type Condition struct {
+	// Host is the host name of the request. A leading "*." matches all subdomains.
+	Host string `json:"host,omitempty"`
+	// PathPrefix is a prefix of the request path, matched at path segment boundaries.
+	PathPrefix string `json:"path_prefix,omitempty"`
+}
+
+// RequiredMode returns the mode a frontend needs to evaluate the condition:
+// HTTP if it matches on host or path, TCP otherwise.
+func (c Condition) RequiredMode() common.Mode {
+	if c.Host != "" || c.PathPrefix != "" {
+		return common.HTTP
+	}
+
+	return common.TCP
+}
+
+// Validate checks that a frontend in the given mode can evaluate the condition.
+func (c Condition) Validate(frontendMode common.Mode) error {
+	return common.CheckMode("frontend", frontendMode, "HTTP routing rule", c.RequiredMode())
+}
+
+// Rule holds the information of a load balancer rule.
+type Rule struct {
+	CustomerIdentifier string                `json:"customer_identifier"`
+	ResellerIdentifier string                `json:"reseller_identifier"`
+	Identifier         string                `json:"identifier"`
+	Name               string                `json:"name"`
+	Frontend           frontend.FrontendInfo `json:"frontend"`
+	Backend            backend.BackendInfo   `json:"backend"`
+	Condition          Condition             `json:"condition"`
+	// Priority determines the order rules are evaluated in, lower values first.
+	Priority int          `json:"priority"`
+	State    common.State `json:"state"`
+}
+
+func (a api) Get(ctx context.Context, page, limit int) ([]RuleInfo, error) {
+	endpoint, err := url.Parse(a.client.BaseURL())
+	if err != nil {
+		return nil, fmt.Errorf("could not parse URL: %w", err)
+	}
+
+	endpoint.Path = path
+	query := endpoint.Query()
+	query.Set("page", strconv.Itoa(page))
+	query.Set("limit", strconv.Itoa(limit))
+	endpoint.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not create request object: %w", err)
+	}
+
+	response, err := a.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error when executing request: %w", err)
+	}
+	defer func() { _ = response.Body.Close() }()
+
+	if err := client.CheckResponse(response, "get load balancer rules"); err != nil {
+		return nil, err
+	}
+
+	payload := struct {
+		Data struct {
+			Data []RuleInfo `json:"data"`
+		} `json:"data"`
+	}{}
+
+	err = json.NewDecoder(response.Body).Decode(&payload)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse load balancer rule list response: %w", err)
+	}
+
+	return payload.Data.Data, nil
+}
+
+func (a api) GetByID(ctx context.Context, identifier string) (Rule, error) {
+	endpoint, err := url.Parse(a.client.BaseURL())
+	if err != nil {
+		return Rule{}, fmt.Errorf("could not parse URL: %w", err)
+	}
+
+	endpoint.Path = utils.Join(path, identifier)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint.String(), nil)
+	if err != nil {
+		return Rule{}, fmt.Errorf("could not create request object: %w", err)
+	}
+
+	response, err := a.client.Do(req)
+	if err != nil {
+		return Rule{}, fmt.Errorf("error when executing request for '%s': %w", identifier, err)
+	}
+	defer func() { _ = response.Body.Close() }()
+
+	request := fmt.Sprintf("execute get load balancer rule request for '%s'", identifier)
+	if err := client.CheckResponse(response, request); err != nil {
+		return Rule{}, err
+	}
+
+	var payload Rule
+
+	err = json.NewDecoder(response.Body).Decode(&payload)
+	if err != nil {
+		return Rule{}, fmt.Errorf("could not parse load balancer rule response for '%s' : %w", identifier, err)
+	}
+
+	return payload, nil
+}
+
+// checkFrontendMode checks that the frontend of the definition is able to evaluate its condition.
+// The frontend is only requested if the condition requires the HTTP mode.
+func (a api) checkFrontendMode(ctx context.Context, definition Definition) error {
+	if definition.Condition.RequiredMode() != common.HTTP {
+		return nil
+	}
+
+	target, err := frontend.NewAPI(a.client).GetByID(ctx, definition.Frontend)
+	if err != nil {
+		return fmt.Errorf("could not get frontend '%s' of rule '%s': %w", definition.Frontend, definition.Name, err)
+	}
+
+	return definition.Condition.Validate(common.Mode(target.Mode))
+}
+
+func (a api) Create(ctx context.Context, definition Definition) (Rule, error) {
+	if err := definition.Validate(); err != nil {
+		return Rule{}, err
+	}
+	if err := a.checkFrontendMode(ctx, definition); err != nil {
+		return Rule{}, err
+	}
+
+	endpoint, err := url.Parse(a.client.BaseURL())
+	if err != nil {
+		return Rule{}, fmt.Errorf("could not parse URL: %w", err)
+	}
+
+	endpoint.Path = path
+
+	requestBody := bytes.Buffer{}
+	if err := json.NewEncoder(&requestBody).Encode(definition); err != nil {
+		return Rule{}, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.String(), &requestBody)
+	if err != nil {
+		return Rule{}, fmt.Errorf("could not create request object: %w", err)
+	}
+
+	response, err := a.client.Do(req)
+	if err != nil {
+		return Rule{}, fmt.Errorf("error when creating rule '%s': %w", definition.Name, err)
+	}
+	defer func() { _ = response.Body.Close() }()
+
+	if err := client.CheckResponse(response, fmt.Sprintf("create load balancer rule '%s'", definition.Name)); err != nil {
+		return Rule{}, err
+	}
+
+	var payload Rule
+	err = json.NewDecoder(response.Body).Decode(&payload)
+	if err != nil {
+		return Rule{}, fmt.Errorf("could not parse load balancer rule creation response for '%s' : %w",
+			definition.Name, err)
+	}
+
+	return payload, nil
+}
+
+func (a api) Update(ctx context.Context, identifier string, definition Definition) (Rule, error) {
+	if err := definition.Validate(); err != nil {
+		return Rule{}, err
+	}
+	if err := a.checkFrontendMode(ctx, definition); err != nil {
+		return Rule{}, err
+	}
+
+	endpoint, err := url.Parse(a.client.BaseURL())
+	if err != nil {
+		return Rule{}, fmt.Errorf("could not parse URL: %w", err)
+	}
+
+	endpoint.Path = utils.Join(path, identifier)
+
+	requestBody := bytes.Buffer{}
+	if err := json.NewEncoder(&requestBody).Encode(definition); err != nil {
+		return Rule{}, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, endpoint.String(), &requestBody)
+	if err != nil {
+		return Rule{}, fmt.Errorf("could not create request object: %w", err)
+	}
+
+	response, err := a.client.Do(req)
+	if err != nil {
+		return Rule{}, fmt.Errorf("error when updating LBaaS rule '%s': %w", identifier, err)
+	}
+	defer func() { _ = response.Body.Close() }()
+
+	if err := client.CheckResponse(response, fmt.Sprintf("update LBaaS rule '%s'", identifier)); err != nil {
+		return Rule{}, err
+	}
+
+	var payload Rule
+	err = json.NewDecoder(response.Body).Decode(&payload)
+	if err != nil {
+		return Rule{}, fmt.Errorf("could not parse load balancer rule update response for '%s': %w", identifier, err)
+	}
+
+	return payload, nil
+}
+
+func (a api) DeleteByID(ctx context.Context, identifier string) error {
+	endpoint, err := url.Parse(a.client.BaseURL())
+	if err != nil {
+		return fmt.Errorf("could not parse URL: %w", err)
+	}
+
+	endpoint.Path = utils.Join(path, identifier)
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, endpoint.String(), nil)
+	if err != nil {
+		return fmt.Errorf("could not create request object: %w", err)
+	}
+
+	response, err := a.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("error when deleting a LBaaS rule '%s': %w", identifier, err)
+	}
+	defer func() { _ = response.Body.Close() }()
+
+	if err := client.CheckResponse(response, fmt.Sprintf("delete LBaaS rule '%s'", identifier)); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,198 @@
+package rule_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/anexia-it/go-anxcloud/pkg/client"
+	"github.com/anexia-it/go-anxcloud/pkg/lbaas/backend"
+	"github.com/anexia-it/go-anxcloud/pkg/lbaas/common"
+	"github.com/anexia-it/go-anxcloud/pkg/lbaas/frontend"
+	"github.com/anexia-it/go-anxcloud/pkg/lbaas/rule"
+	"github.com/stretchr/testify/assert"
+)
+
+// ruleServer stores the rules created, updated and deleted through the rule API.
+// Frontends are served with the modes given in frontends.
+type ruleServer struct {
+	t         *testing.T
+	mu        sync.Mutex
+	rules     map[string]rule.Rule
+	frontends map[string]string
+	next      int
+	writes    int
+}
+
+func (s *ruleServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if frontendID := strings.TrimPrefix(r.URL.Path, "/api/LBaaS/v1/frontend.json/"); frontendID != r.URL.Path {
+		assert.NoError(s.t, json.NewEncoder(w).Encode(frontend.Frontend{Identifier: frontendID, Mode: s.frontends[frontendID]}))
+		return
+	}
+
+	identifier := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, "/api/LBaaS/v1/rule.json"), "/")
+	var payload interface{}
+	switch r.Method {
+	case http.MethodGet:
+		if identifier != "" {
+			payload = s.rules[identifier]
+			break
+		}
+		payload = rulePage(s.rules, r.URL.Query().Get("detailed") == "true")
+	case http.MethodPost, http.MethodPut:
+		s.writes++
+		var definition rule.Definition
+		assert.NoError(s.t, json.NewDecoder(r.Body).Decode(&definition))
+		if identifier == "" {
+			s.next++
+			identifier = fmt.Sprintf("rule-%d", s.next)
+		}
+		s.rules[identifier] = rule.Rule{
+			Identifier: identifier,
+			Name:       definition.Name,
+			Frontend:   frontend.FrontendInfo{Identifier: definition.Frontend},
+			Backend:    backend.BackendInfo{Identifier: definition.Backend},
+			Condition:  definition.Condition,
+			Priority:   definition.Priority,
+		}
+		payload = s.rules[identifier]
+	case http.MethodDelete:
+		delete(s.rules, identifier)
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	assert.NoError(s.t, json.NewEncoder(w).Encode(payload))
+}
+
+// rulePage returns a listing response with all rules, detailed pages contain the full rules.
+func rulePage(rules map[string]rule.Rule, detailed bool) interface{} {
+	if detailed {
+		full := make([]rule.Rule, 0, len(rules))
+		for _, stored := range rules {
+			full = append(full, stored)
+		}
+		return map[string]interface{}{"data": map[string]interface{}{"page": 1, "total_pages": 1, "total_items": len(full),
+			"limit": 20, "data": full}}
+	}
+
+	infos := make([]rule.RuleInfo, 0, len(rules))
+	for _, stored := range rules {
+		infos = append(infos, rule.RuleInfo{Identifier: stored.Identifier, Name: stored.Name})
+	}
+	return map[string]rule.RulePage{"data": {Page: 1, TotalPages: 1, TotalItems: len(infos), Limit: 20, Data: infos}}
+}
+
+func TestRuleManagement(t *testing.T) {
+	server := &ruleServer{t: t, rules: map[string]rule.Rule{}, frontends: map[string]string{"frontend": "http", "other": "tcp"}}
+	c, httpServer := client.NewTestClient(nil, server)
+	defer httpServer.Close()
+	api := rule.NewAPI(c)
+	ctx := context.Background()
+
+	api30, err := api.Create(ctx, rule.Definition{Name: "api", Frontend: "frontend", Backend: "api", Priority: 30,
+		Condition: rule.Condition{Host: "www.example.com", PathPrefix: "/api"}})
+	if !assert.NoError(t, err) {
+		return
+	}
+	_, err = api.Create(ctx, rule.Definition{Name: "www", Frontend: "frontend", Backend: "www", Priority: 20,
+		Condition: rule.Condition{Host: "www.example.com"}})
+	assert.NoError(t, err)
+	other, err := api.Create(ctx, rule.Definition{Name: "other", Frontend: "other", Backend: "other"})
+	assert.NoError(t, err)
+
+	updated, err := api.Update(ctx, api30.Identifier, rule.Definition{Name: "api", Frontend: "frontend", Backend: "api",
+		Priority: 10, Condition: api30.Condition})
+	if assert.NoError(t, err) {
+		assert.Equal(t, 10, updated.Priority)
+	}
+	assert.NoError(t, api.DeleteByID(ctx, other.Identifier))
+
+	rules, err := rule.ListByFrontend(ctx, api, "frontend")
+	if assert.NoError(t, err) && assert.Len(t, rules, 2) {
+		assert.EqualValues(t, "api", rules[0].Name)
+		assert.EqualValues(t, "www", rules[1].Name)
+	}
+
+	for _, invalid := range []rule.Definition{
+		{Name: "no-frontend", Backend: "www"},
+		{Name: "no-backend", Frontend: "frontend"},
+		{Name: "negative", Frontend: "frontend", Backend: "www", Priority: -1},
+	} {
+		_, err := api.Create(ctx, invalid)
+		assert.True(t, errors.Is(err, rule.ErrInvalidDefinition), "%s: expected ErrInvalidDefinition but got %v", invalid.Name, err)
+	}
+	assert.Len(t, server.rules, 2)
+}
+
+func TestRuleFrontendMode(t *testing.T) {
+	server := &ruleServer{t: t, rules: map[string]rule.Rule{}, frontends: map[string]string{"http": "http", "tcp": "tcp"}}
+	c, httpServer := client.NewTestClient(nil, server)
+	defer httpServer.Close()
+	api := rule.NewAPI(c)
+	ctx := context.Background()
+
+	routed := rule.Definition{Name: "api", Frontend: "tcp", Backend: "api", Condition: rule.Condition{PathPrefix: "/api"}}
+	_, err := api.Create(ctx, routed)
+	assert.True(t, errors.Is(err, common.ErrIncompatibleMode), "expected ErrIncompatibleMode but got %v", err)
+	_, err = api.Update(ctx, "rule-1", routed)
+	assert.True(t, errors.Is(err, common.ErrIncompatibleMode), "expected ErrIncompatibleMode but got %v", err)
+	assert.Zero(t, server.writes, "no rule must be sent for an incompatible frontend")
+
+	routed.Frontend = "http"
+	_, err = api.Create(ctx, routed)
+	assert.NoError(t, err)
+	_, err = api.Create(ctx, rule.Definition{Name: "tcp", Frontend: "tcp", Backend: "db"})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, server.writes)
+}
+
+type rawClient struct {
+	baseURL string
+}
+
+func (c rawClient) BaseURL() string {
+	return c.baseURL
+}
+
+func (c rawClient) Do(req *http.Request) (*http.Response, error) {
+	return http.DefaultClient.Do(req)
+}
+
+func TestErrorResponses(t *testing.T) {
+	for _, status := range []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict} {
+		_, server := client.NewTestClient(nil, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(`{"error":{"code":` + strconv.Itoa(status) + `,"message":"rejected"}}`))
+		}))
+
+		api := rule.NewAPI(rawClient{server.URL})
+		ctx := context.Background()
+		definition := rule.Definition{Name: "other", Frontend: "frontend", Backend: "backend"}
+
+		_, getErr := api.Get(ctx, 1, 10)
+		_, getByIDErr := api.GetByID(ctx, "rule-id")
+		_, createErr := api.Create(ctx, definition)
+		_, updateErr := api.Update(ctx, "rule-id", definition)
+		deleteErr := api.DeleteByID(ctx, "rule-id")
+		_, pageErr := api.GetPage(ctx, 1, 10)
+		_, detailedPageErr := api.DetailedPages().GetPage(ctx, 1, 10)
+
+		for _, err := range []error{getErr, getByIDErr, createErr, updateErr, deleteErr, pageErr, detailedPageErr} {
+			var responseErr *client.ResponseError
+			if assert.True(t, errors.As(err, &responseErr), "expected ResponseError for %d but got %v", status, err) {
+				assert.EqualValues(t, status, responseErr.ErrorData.Code)
+				assert.EqualValues(t, "rejected", responseErr.ErrorData.Message)
+			}
+		}
+		server.Close()
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 
 	"github.com/anexia-it/go-anxcloud/pkg/client"
+	"github.com/anexia-it/go-anxcloud/pkg/lbaas/common"
+	"github.com/anexia-it/go-anxcloud/pkg/pagination"
 )
 
 // API contains methods for load balancer backend server management.
@@ -11,7 +13,11 @@ type API interface {
 	Get(ctx context.Context, page, limit int) ([]ServerInfo, error)
 	GetByID(ctx context.Context, identifier string) (Server, error)
 	Create(ctx context.Context, definition Definition) (Server, error)
+	Update(ctx context.Context, identifier string, definition Definition) (Server, error)
 	DeleteByID(ctx context.Context, identifier string) error
+	Pages(opts ...common.ListOption) pagination.Pageable
+
+	pagination.Pageable
 }
 
 type api struct {
@@ -9,4 +9,11 @@ type Definition struct {
 	IP      string       `json:"ip"`
 	Port    int          `json:"port"`
 	Backend string       `json:"backend"`
+	Weight  *int         `json:"weight,omitempty"`
+	// Check enables or disables health checks of the server, e.g. "enabled". The backend default is used if empty.
+	Check string `json:"check,omitempty"`
+
+	// EnsureUniqueName lets Create look for an existing server with the same name first and
+	// fail with a common.AlreadyExistsError instead of sending the create request.
+	EnsureUniqueName bool `json:"-"`
 }
@@ -0,0 +1,97 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/anexia-it/go-anxcloud/pkg/client"
+	"github.com/anexia-it/go-anxcloud/pkg/lbaas/common"
+)
+
+const drainPollInterval = 5 * time.Second
+
+// ErrDrainTimeout is raised if a server could not be drained within the given timeout.
+// The server keeps its weight of 0, the caller can decide to remove it anyway.
+var ErrDrainTimeout = errors.New("server was not drained in time")
+
+// ErrDrainDeployment is raised if the load balancer reported an error while deploying the weight change.
+var ErrDrainDeployment = errors.New("server weight change could not be deployed")
+
+type drainOptions struct {
+	remove bool
+}
+
+// DrainOption is an optional parameter for DrainServer.
+type DrainOption func(o *drainOptions)
+
+// RemoveAfterDrain lets DrainServer delete the server once it was drained.
+func RemoveAfterDrain() DrainOption {
+	return func(o *drainOptions) {
+		o.remove = true
+	}
+}
+
+// DrainServer takes a load balancer backend server out of rotation.
+//
+// The weight of the server is set to 0 so the load balancer stops sending new connections to it.
+// The API does not expose the number of active connections, so the server is considered drained
+// once the weight change is deployed. Its state is polled right after the change and then every five
+// seconds. Afterwards the server is removed if RemoveAfterDrain was passed.
+//
+// ctx is attached to all requests and will cancel them on cancelation.
+// serverID is the identifier of the server to drain.
+// drainTimeout limits how long to wait for the server to be drained.
+//
+// If the server is not drained within drainTimeout, ErrDrainTimeout is returned and the server is not removed.
+func DrainServer(ctx context.Context, c client.Client, serverID string, drainTimeout time.Duration, options ...DrainOption) error {
+	opts := drainOptions{}
+	for _, option := range options {
+		option(&opts)
+	}
+
+	a := NewAPI(c)
+	if err := setWeight(ctx, a, serverID, 0); err != nil {
+		return err
+	}
+
+	if err := awaitDrained(ctx, a, serverID, drainTimeout); err != nil {
+		return err
+	}
+
+	if opts.remove {
+		return a.DeleteByID(ctx, serverID)
+	}
+
+	return nil
+}
+
+func awaitDrained(ctx context.Context, a API, serverID string, drainTimeout time.Duration) error {
+	timeout := time.NewTimer(drainTimeout)
+	defer timeout.Stop()
+	poll := time.NewTimer(0)
+	defer poll.Stop()
+	for {
+		select {
+		case <-poll.C:
+			server, err := a.GetByID(ctx, serverID)
+			if err != nil {
+				return fmt.Errorf("could not poll state of LBaaS server '%s': %w", serverID, err)
+			}
+			switch server.State {
+			case common.Deployed:
+				if server.Weight == 0 {
+					return nil
+				}
+			case common.DeploymentError:
+				return fmt.Errorf("%w: LBaaS server '%s'", ErrDrainDeployment, serverID)
+			}
+			poll.Reset(drainPollInterval)
+		case <-timeout.C:
+			return fmt.Errorf("%w: LBaaS server '%s' after %v", ErrDrainTimeout, serverID, drainTimeout)
+		case <-ctx.Done():
+			return fmt.Errorf("LBaaS server '%s' was not drained: %w", serverID, ctx.Err())
+		}
+	}
+}
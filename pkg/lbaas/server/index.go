@@ -0,0 +1,92 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/anexia-it/go-anxcloud/pkg/client"
+	"github.com/anexia-it/go-anxcloud/pkg/lbaas/common"
+)
+
+// indexPageSize is the page size used when walking all servers for an index.
+// It is chosen large to keep the number of list requests low. Neither the LBaaS API nor the pagination
+// package offer an automatic page size, and a smaller size enforced by the API is handled by following
+// the total_pages of every response.
+const indexPageSize = 100
+
+// ServerIndex holds all load balancer backend servers of an account for repeated lookups.
+//
+// The index keeps the full Server representation of every server in memory, so its size grows
+// linearly with the number of servers. Building it issues one detailed list request per page of
+// indexPageSize servers. For accounts with many thousands of servers, build the index once
+// and call Refresh only when the data is expected to have changed.
+//
+// A ServerIndex is not safe for concurrent use while Refresh is running.
+type ServerIndex struct {
+	// ByID maps the identifier of a server to the server.
+	ByID map[string]Server
+	// ByName maps a server name to all servers with that name.
+	ByName map[string][]Server
+	// ByIP maps an IP address to all servers using it.
+	ByIP map[string][]Server
+
+	api api
+}
+
+// BuildServerIndex reads all load balancer backend servers and indexes them by identifier, name and IP.
+//
+// ctx is attached to all requests and will cancel them on cancelation.
+// c is the client used to query the API.
+func BuildServerIndex(ctx context.Context, c client.Client) (ServerIndex, error) {
+	index := ServerIndex{api: api{c}}
+	if err := index.Refresh(ctx); err != nil {
+		return ServerIndex{}, err
+	}
+
+	return index, nil
+}
+
+// Refresh reads all servers again and replaces the content of the index.
+//
+// If an error occurs the index is left unchanged.
+func (i *ServerIndex) Refresh(ctx context.Context) error {
+	servers, err := i.api.listDetailed(ctx)
+	if err != nil {
+		return err
+	}
+
+	byID := make(map[string]Server, len(servers))
+	byName := make(map[string][]Server, len(servers))
+	byIP := make(map[string][]Server, len(servers))
+	for _, server := range servers {
+		byID[server.Identifier] = server
+		byName[server.Name] = append(byName[server.Name], server)
+		byIP[server.IP] = append(byIP[server.IP], server)
+	}
+
+	i.ByID, i.ByName, i.ByIP = byID, byName, byIP
+
+	return nil
+}
+
+// listDetailed pages through the full representation of all servers until the last page reported by the
+// listing or an empty page was read.
+func (a api) listDetailed(ctx context.Context) ([]Server, error) {
+	var servers []Server
+	for page := 1; ; page++ {
+		payload := struct {
+			Data struct {
+				TotalPages int      `json:"total_pages"`
+				Data       []Server `json:"data"`
+			} `json:"data"`
+		}{}
+		if err := a.fetchPage(ctx, page, indexPageSize, &payload, common.Detailed()); err != nil {
+			return nil, fmt.Errorf("could not list LBaaS servers on page %d: %w", page, err)
+		}
+
+		servers = append(servers, payload.Data.Data...)
+		if len(payload.Data.Data) == 0 || page >= payload.Data.TotalPages {
+			return servers, nil
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/anexia-it/go-anxcloud/pkg/client"
+	"github.com/anexia-it/go-anxcloud/pkg/lbaas/common"
+	"github.com/anexia-it/go-anxcloud/pkg/pagination"
+)
+
+// ServerPage is a single page of the load balancer server listing.
+type ServerPage struct {
+	Page       int          `json:"page"`
+	TotalItems int          `json:"total_items"`
+	TotalPages int          `json:"total_pages"`
+	Limit      int          `json:"limit"`
+	Data       []ServerInfo `json:"data"`
+}
+
+// Num returns the number of this page.
+func (p ServerPage) Num() int {
+	return p.Page
+}
+
+// Size returns the maximum number of entries of this page.
+func (p ServerPage) Size() int {
+	return p.Limit
+}
+
+// Total returns the total number of pages.
+func (p ServerPage) Total() int {
+	return p.TotalPages
+}
+
+// TotalCount returns the total number of entries over all pages.
+func (p ServerPage) TotalCount() int {
+	return p.TotalItems
+}
+
+// Content returns the entries of this page as []ServerInfo.
+func (p ServerPage) Content() interface{} {
+	return p.Data
+}
+
+func (a api) GetPage(ctx context.Context, page, limit int) (pagination.Page, error) {
+	return a.getPage(ctx, page, limit)
+}
+
+func (a api) NextPage(ctx context.Context, page pagination.Page) (pagination.Page, error) {
+	return a.GetPage(ctx, page.Num()+1, page.Size())
+}
+
+// Pages returns the paged listing of the servers matching all given options, e.g. common.Search.
+// Without options it is the same as the listing of the API itself.
+func (a api) Pages(opts ...common.ListOption) pagination.Pageable {
+	return filteredPager{a, opts}
+}
+
+type filteredPager struct {
+	api  api
+	opts []common.ListOption
+}
+
+func (f filteredPager) GetPage(ctx context.Context, page, limit int) (pagination.Page, error) {
+	return f.api.getPage(ctx, page, limit, f.opts...)
+}
+
+func (f filteredPager) NextPage(ctx context.Context, page pagination.Page) (pagination.Page, error) {
+	return f.GetPage(ctx, page.Num()+1, page.Size())
+}
+
+func (a api) getPage(ctx context.Context, page, limit int, opts ...common.ListOption) (pagination.Page, error) {
+	payload := struct {
+		Data ServerPage `json:"data"`
+	}{}
+	if err := a.fetchPage(ctx, page, limit, &payload, opts...); err != nil {
+		return nil, err
+	}
+
+	return payload.Data, nil
+}
+
+// fetchPage requests a page of the server listing and decodes the response into payload.
+func (a api) fetchPage(ctx context.Context, page, limit int, payload interface{}, opts ...common.ListOption) error {
+	endpoint, err := url.Parse(a.client.BaseURL())
+	if err != nil {
+		return fmt.Errorf("could not parse URL: %w", err)
+	}
+
+	endpoint.Path = path
+	query := endpoint.Query()
+	common.ApplyListOptions(query, opts...)
+	query.Set("page", strconv.Itoa(page))
+	query.Set("limit", strconv.Itoa(limit))
+	endpoint.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint.String(), nil)
+	if err != nil {
+		return fmt.Errorf("could not create request object: %w", err)
+	}
+
+	response, err := a.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("error when executing request: %w", err)
+	}
+
+	if err := client.CheckResponse(response, "get load balancer servers"); err != nil {
+		return err
+	}
+
+	err = json.NewDecoder(response.Body).Decode(payload)
+	_ = response.Body.Close()
+	if err != nil {
+		return fmt.Errorf("could not parse load balancer server page response: %w", err)
+	}
+
+	return nil
+}
+
+// findByName returns the identifier of the first server with the given name. The name search of the API also
+// matches partial names, so the results are checked for an exact match.
+func (a api) findByName(ctx context.Context, name string) (string, bool, error) {
+	items, err := pagination.Collect(ctx, a.Pages(common.Search(name)))
+	if err != nil {
+		return "", false, fmt.Errorf("could not list load balancer servers: %w", err)
+	}
+
+	for _, item := range items {
+		if info := item.(ServerInfo); info.Name == name {
+			return info.Identifier, true, nil
+		}
+	}
+
+	return "", false, nil
+}
@@ -10,7 +10,9 @@ import (
 	utils "path"
 	"strconv"
 
+	"github.com/anexia-it/go-anxcloud/pkg/client"
 	"github.com/anexia-it/go-anxcloud/pkg/lbaas/backend"
+	"github.com/anexia-it/go-anxcloud/pkg/lbaas/common"
 )
 
 const (
@@ -33,6 +35,16 @@ type Server struct {
 	Port               int                 `json:"port"`
 	Backend            backend.BackendInfo `json:"backend"`
 	Check              string              `json:"check"`
+	Weight             int                 `json:"weight"`
+	State              common.State        `json:"state"`
+}
+
+// ConfigHash returns a stable hash of the configuration of the server.
+//
+// Included are, in this order, the name, the IP, the port, the backend identifier, the check and the weight.
+// The state and identifiers of the server itself and its owners are not included.
+func (s Server) ConfigHash() string {
+	return common.ConfigHash(s.Name, s.IP, s.Port, s.Backend.Identifier, s.Check, s.Weight)
 }
 
 func (a api) Get(ctx context.Context, page, limit int) ([]ServerInfo, error) {
@@ -56,9 +68,10 @@ func (a api) Get(ctx context.Context, page, limit int) ([]ServerInfo, error) {
 	if err != nil {
 		return nil, fmt.Errorf("error when executing request: %w", err)
 	}
+	defer func() { _ = response.Body.Close() }()
 
-	if response.StatusCode >= 500 && response.StatusCode < 600 {
-		return nil, fmt.Errorf("could not get load balancer backend servers %s", response.Status)
+	if err := client.CheckResponse(response, "get load balancer backend servers"); err != nil {
+		return nil, err
 	}
 
 	payload := struct {
@@ -92,10 +105,11 @@ func (a api) GetByID(ctx context.Context, identifier string) (Server, error) {
 	if err != nil {
 		return Server{}, fmt.Errorf("error when executing request for '%s': %w", identifier, err)
 	}
+	defer func() { _ = response.Body.Close() }()
 
-	if response.StatusCode >= 500 && response.StatusCode < 600 {
-		return Server{}, fmt.Errorf("could not execute get load balancer backend server request for '%s': %s", identifier,
-			response.Status)
+	request := fmt.Sprintf("execute get load balancer backend server request for '%s'", identifier)
+	if err := client.CheckResponse(response, request); err != nil {
+		return Server{}, err
 	}
 
 	var payload Server
@@ -116,6 +130,16 @@ func (a api) Create(ctx context.Context, definition Definition) (Server, error)
 
 	endpoint.Path = path
 
+	if definition.EnsureUniqueName {
+		identifier, exists, err := a.findByName(ctx, definition.Name)
+		if err != nil {
+			return Server{}, err
+		}
+		if exists {
+			return Server{}, &common.AlreadyExistsError{Name: definition.Name, Identifier: identifier}
+		}
+	}
+
 	buf := bytes.Buffer{}
 	if err := json.NewEncoder(&buf).Encode(definition); err != nil {
 		return Server{}, err
@@ -130,10 +154,10 @@ func (a api) Create(ctx context.Context, definition Definition) (Server, error)
 		return Server{}, fmt.Errorf("error when creating a LBaaS server for backend '%s': %w",
 			definition.Backend, err)
 	}
+	defer func() { _ = response.Body.Close() }()
 
-	if response.StatusCode >= 500 && response.StatusCode < 600 {
-		return Server{}, fmt.Errorf("could not create LBaaS server for backend '%s': %s",
-			definition.Backend, response.Status)
+	if err := client.CheckResponse(response, fmt.Sprintf("create LBaaS server for backend '%s'", definition.Backend)); err != nil {
+		return Server{}, err
 	}
 
 	var payload Server
@@ -145,6 +169,42 @@ func (a api) Create(ctx context.Context, definition Definition) (Server, error)
 	return payload, nil
 }
 
+func (a api) Update(ctx context.Context, identifier string, definition Definition) (Server, error) {
+	endpoint, err := url.Parse(a.client.BaseURL())
+	if err != nil {
+		return Server{}, fmt.Errorf("could not parse URL: %w", err)
+	}
+
+	endpoint.Path = utils.Join(path, identifier)
+
+	buf := bytes.Buffer{}
+	if err := json.NewEncoder(&buf).Encode(definition); err != nil {
+		return Server{}, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, endpoint.String(), &buf)
+	if err != nil {
+		return Server{}, fmt.Errorf("could not create request object: %w", err)
+	}
+
+	response, err := a.client.Do(req)
+	if err != nil {
+		return Server{}, fmt.Errorf("error when updating LBaaS server '%s': %w", identifier, err)
+	}
+	defer func() { _ = response.Body.Close() }()
+
+	if err := client.CheckResponse(response, fmt.Sprintf("update LBaaS server '%s'", identifier)); err != nil {
+		return Server{}, err
+	}
+
+	var payload Server
+	err = json.NewDecoder(response.Body).Decode(&payload)
+	if err != nil {
+		return Server{}, fmt.Errorf("could not parse loadbalancer server update response for '%s': %w", identifier, err)
+	}
+
+	return payload, nil
+}
+
 func (a api) DeleteByID(ctx context.Context, identifier string) error {
 	endpoint, err := url.Parse(a.client.BaseURL())
 	if err != nil {
@@ -162,10 +222,10 @@ func (a api) DeleteByID(ctx context.Context, identifier string) error {
 		return fmt.Errorf("error when deleting a LBaaS server '%s': %w",
 			identifier, err)
 	}
+	defer func() { _ = response.Body.Close() }()
 
-	if response.StatusCode >= 500 && response.StatusCode < 600 {
-		return fmt.Errorf("could not delete LBaaS server '%s': %s",
-			identifier, response.Status)
+	if err := client.CheckResponse(response, fmt.Sprintf("delete LBaaS server '%s'", identifier)); err != nil {
+		return err
 	}
 
 	return nil
@@ -0,0 +1,449 @@
+package server_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/anexia-it/go-anxcloud/pkg/client"
+	"github.com/anexia-it/go-anxcloud/pkg/lbaas/backend"
+	"github.com/anexia-it/go-anxcloud/pkg/lbaas/common"
+	"github.com/anexia-it/go-anxcloud/pkg/lbaas/server"
+	"github.com/stretchr/testify/assert"
+)
+
+const serverPath = "/api/LBaaS/v1/server.json"
+
+// newServerMock serves a single server with identifier "server-id" and records the last received definition.
+func newServerMock(t *testing.T, received *server.Definition) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		respond := func(definition server.Definition) {
+			_ = json.NewEncoder(w).Encode(server.Server{
+				Identifier: "server-id",
+				Name:       definition.Name,
+				IP:         definition.IP,
+				Port:       definition.Port,
+				Backend:    backend.BackendInfo{Identifier: definition.Backend},
+				Check:      definition.Check,
+				State:      common.NewlyCreated,
+			})
+		}
+
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == serverPath:
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(received))
+			respond(*received)
+		case r.Method == http.MethodPut && r.URL.Path == serverPath+"/server-id":
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(received))
+			respond(*received)
+		case r.Method == http.MethodGet && r.URL.Path == serverPath+"/server-id":
+			respond(server.Definition{Name: "web-1", IP: "192.0.2.1", Port: 80, Backend: "backend-id"})
+		case r.Method == http.MethodDelete && r.URL.Path == serverPath+"/server-id":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":{"code":404,"message":"not found"}}`))
+		}
+	})
+}
+
+func TestServerCRUD(t *testing.T) {
+	var received server.Definition
+	c, mock := client.NewTestClient(nil, newServerMock(t, &received))
+	defer mock.Close()
+	api := server.NewAPI(c)
+	ctx := context.Background()
+
+	t.Run("Create", func(t *testing.T) {
+		created, err := api.Create(ctx, server.Definition{Name: "web-1", IP: "192.0.2.1", Port: 80, Backend: "backend-id", Check: "enabled"})
+		if assert.NoError(t, err) {
+			assert.EqualValues(t, "server-id", created.Identifier)
+			assert.EqualValues(t, common.NewlyCreated, created.State)
+			assert.EqualValues(t, "enabled", created.Check)
+		}
+		assert.EqualValues(t, "enabled", received.Check)
+	})
+
+	t.Run("GetByID", func(t *testing.T) {
+		fetched, err := api.GetByID(ctx, "server-id")
+		if assert.NoError(t, err) {
+			assert.EqualValues(t, "192.0.2.1", fetched.IP)
+			assert.EqualValues(t, "backend-id", fetched.Backend.Identifier)
+		}
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		updated, err := api.Update(ctx, "server-id", server.Definition{Name: "web-1", IP: "192.0.2.2", Port: 8080, Backend: "backend-id"})
+		if assert.NoError(t, err) {
+			assert.EqualValues(t, "192.0.2.2", updated.IP)
+			assert.EqualValues(t, 8080, updated.Port)
+		}
+	})
+
+	t.Run("DeleteByID", func(t *testing.T) {
+		assert.NoError(t, api.DeleteByID(ctx, "server-id"))
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := api.GetByID(ctx, "unknown")
+		var responseErr *client.ResponseError
+		assert.True(t, errors.As(err, &responseErr), "expected ResponseError but got %v", err)
+		assert.Error(t, api.DeleteByID(ctx, "unknown"))
+	})
+}
+
+func TestPages(t *testing.T) {
+	var query url.Values
+	c, httpServer := client.NewTestClient(nil, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		_ = json.NewEncoder(w).Encode(map[string]server.ServerPage{"data": {Page: 1, TotalPages: 1, Limit: 10}})
+	}))
+	defer httpServer.Close()
+
+	_, err := server.NewAPI(c).Pages(common.StateFilter(common.DeploymentError)).GetPage(context.Background(), 1, 10)
+	assert.NoError(t, err)
+	assert.EqualValues(t, url.Values{"filter": {"state:2"}, "page": {"1"}, "limit": {"10"}}, query)
+}
+
+// rawClient sends requests without turning error responses into errors, like custom client.Client implementations may.
+type rawClient struct {
+	baseURL string
+}
+
+func (c rawClient) BaseURL() string {
+	return c.baseURL
+}
+
+func (c rawClient) Do(req *http.Request) (*http.Response, error) {
+	return http.DefaultClient.Do(req)
+}
+
+func TestErrorResponses(t *testing.T) {
+	for _, status := range []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict} {
+		_, httpServer := client.NewTestClient(nil, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(`{"error":{"code":` + strconv.Itoa(status) + `,"message":"rejected"}}`))
+		}))
+
+		api := server.NewAPI(rawClient{httpServer.URL})
+		ctx := context.Background()
+		definition := server.Definition{Name: "web-1", IP: "192.0.2.1", Port: 80, Backend: "backend-id"}
+
+		_, getErr := api.Get(ctx, 1, 10)
+		_, getByIDErr := api.GetByID(ctx, "server-id")
+		_, createErr := api.Create(ctx, definition)
+		_, updateErr := api.Update(ctx, "server-id", definition)
+		deleteErr := api.DeleteByID(ctx, "server-id")
+		_, pageErr := api.Pages().GetPage(ctx, 1, 10)
+
+		for _, err := range []error{getErr, getByIDErr, createErr, updateErr, deleteErr, pageErr} {
+			var responseErr *client.ResponseError
+			if assert.True(t, errors.As(err, &responseErr), "expected ResponseError for %d but got %v", status, err) {
+				assert.EqualValues(t, status, responseErr.ErrorData.Code)
+				assert.EqualValues(t, "rejected", responseErr.ErrorData.Message)
+			}
+		}
+		httpServer.Close()
+	}
+}
+
+func TestSetWeightsKeepsSettings(t *testing.T) {
+	var received server.Definition
+	c, httpServer := client.NewTestClient(nil, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		}
+		_ = json.NewEncoder(w).Encode(server.Server{Identifier: "server-id", Name: "web-1", IP: "192.0.2.1", Port: 80,
+			Backend: backend.BackendInfo{Identifier: "backend-id"}, Check: "disabled", Weight: 10})
+	}))
+	defer httpServer.Close()
+
+	assert.NoError(t, server.SetWeights(context.Background(), c, map[string]int{"server-id": 0}))
+	assert.EqualValues(t, "disabled", received.Check)
+	assert.EqualValues(t, "web-1", received.Name)
+	assert.EqualValues(t, "backend-id", received.Backend)
+	if assert.NotNil(t, received.Weight) {
+		assert.EqualValues(t, 0, *received.Weight)
+	}
+}
+
+func TestBuildServerIndex(t *testing.T) {
+	// The listing caps the page size at 2 entries, so every page is shorter than requested.
+	servers := []server.Server{
+		{Identifier: "a", Name: "web", IP: "192.0.2.1"},
+		{Identifier: "b", Name: "web", IP: "192.0.2.2"},
+		{Identifier: "c", Name: "db", IP: "192.0.2.1"},
+	}
+	var pages []string
+	c, httpServer := client.NewTestClient(nil, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != serverPath {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		assert.EqualValues(t, "true", r.URL.Query().Get("detailed"))
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		pages = append(pages, r.URL.Query().Get("page"))
+
+		start, end := (page-1)*2, page*2
+		if start > len(servers) {
+			start = len(servers)
+		}
+		if end > len(servers) {
+			end = len(servers)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{
+			"page": page, "limit": 2, "total_pages": 2, "total_items": len(servers), "data": servers[start:end],
+		}})
+	}))
+	defer httpServer.Close()
+
+	index, err := server.BuildServerIndex(context.Background(), c)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, []string{"1", "2"}, pages)
+	assert.Len(t, index.ByID, 3)
+	assert.Len(t, index.ByName["web"], 2)
+	assert.Len(t, index.ByIP["192.0.2.1"], 2)
+	assert.EqualValues(t, "192.0.2.2", index.ByID["b"].IP)
+
+	// An empty page ends the walk, even if more pages were reported.
+	servers = nil
+	pages = nil
+	assert.NoError(t, index.Refresh(context.Background()))
+	assert.Equal(t, []string{"1"}, pages)
+	assert.Empty(t, index.ByID)
+}
+
+// serverStore serves the servers it holds and applies the updates it receives. Updated servers are reported
+// in stateAfterUpdate, unknown servers are answered with 404. Every request takes at least delay, maxInFlight
+// is the highest number of requests handled at the same time.
+type serverStore struct {
+	t                *testing.T
+	mu               sync.Mutex
+	servers          map[string]server.Server
+	stateAfterUpdate common.State
+	deleted          []string
+
+	delay       time.Duration
+	inFlight    int32
+	maxInFlight int32
+}
+
+func newServerStore(t *testing.T, identifiers ...string) *serverStore {
+	s := &serverStore{t: t, servers: map[string]server.Server{}, stateAfterUpdate: common.Deployed}
+	for i, identifier := range identifiers {
+		s.servers[identifier] = server.Server{Identifier: identifier, Name: identifier, IP: fmt.Sprintf("192.0.2.%d", i+1),
+			Port: 80, Backend: backend.BackendInfo{Identifier: "backend-id"}, Check: "enabled", Weight: 10, State: common.Deployed}
+	}
+
+	return s
+}
+
+func (s *serverStore) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	inFlight := atomic.AddInt32(&s.inFlight, 1)
+	defer atomic.AddInt32(&s.inFlight, -1)
+	for max := atomic.LoadInt32(&s.maxInFlight); inFlight > max; max = atomic.LoadInt32(&s.maxInFlight) {
+		if atomic.CompareAndSwapInt32(&s.maxInFlight, max, inFlight) {
+			break
+		}
+	}
+	time.Sleep(s.delay)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	identifier := strings.TrimPrefix(r.URL.Path, serverPath+"/")
+	stored, ok := s.servers[identifier]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error":{"code":404,"message":"not found"}}`))
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPut:
+		var definition server.Definition
+		assert.NoError(s.t, json.NewDecoder(r.Body).Decode(&definition))
+		if definition.Weight != nil {
+			stored.Weight = *definition.Weight
+		}
+		stored.State = s.stateAfterUpdate
+		s.servers[identifier] = stored
+	case http.MethodDelete:
+		delete(s.servers, identifier)
+		s.deleted = append(s.deleted, identifier)
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	assert.NoError(s.t, json.NewEncoder(w).Encode(stored))
+}
+
+func TestDrainServer(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Remove", func(t *testing.T) {
+		store := newServerStore(t, "server-id")
+		c, httpServer := client.NewTestClient(nil, store)
+		defer httpServer.Close()
+
+		assert.NoError(t, server.DrainServer(ctx, c, "server-id", time.Minute, server.RemoveAfterDrain()))
+		assert.Equal(t, []string{"server-id"}, store.deleted)
+	})
+
+	t.Run("Keep", func(t *testing.T) {
+		store := newServerStore(t, "server-id")
+		c, httpServer := client.NewTestClient(nil, store)
+		defer httpServer.Close()
+
+		assert.NoError(t, server.DrainServer(ctx, c, "server-id", time.Minute))
+		assert.Empty(t, store.deleted)
+		assert.EqualValues(t, 0, store.servers["server-id"].Weight)
+		assert.EqualValues(t, "enabled", store.servers["server-id"].Check)
+	})
+
+	t.Run("DeploymentError", func(t *testing.T) {
+		store := newServerStore(t, "server-id")
+		store.stateAfterUpdate = common.DeploymentError
+		c, httpServer := client.NewTestClient(nil, store)
+		defer httpServer.Close()
+
+		err := server.DrainServer(ctx, c, "server-id", time.Minute, server.RemoveAfterDrain())
+		assert.True(t, errors.Is(err, server.ErrDrainDeployment), "expected ErrDrainDeployment but got %v", err)
+		assert.Empty(t, store.deleted)
+	})
+
+	t.Run("Timeout", func(t *testing.T) {
+		store := newServerStore(t, "server-id")
+		store.stateAfterUpdate = common.Updating
+		c, httpServer := client.NewTestClient(nil, store)
+		defer httpServer.Close()
+
+		err := server.DrainServer(ctx, c, "server-id", 50*time.Millisecond, server.RemoveAfterDrain())
+		assert.True(t, errors.Is(err, server.ErrDrainTimeout), "expected ErrDrainTimeout but got %v", err)
+		assert.Empty(t, store.deleted)
+	})
+
+	t.Run("UnknownServer", func(t *testing.T) {
+		c, httpServer := client.NewTestClient(nil, newServerStore(t))
+		defer httpServer.Close()
+
+		var responseErr *client.ResponseError
+		err := server.DrainServer(ctx, c, "unknown", time.Minute)
+		assert.True(t, errors.As(err, &responseErr), "expected ResponseError but got %v", err)
+	})
+}
+
+func TestSetWeights(t *testing.T) {
+	identifiers := make([]string, 0, 10)
+	weights := make(map[string]int, 11)
+	for i := 0; i < 10; i++ {
+		identifier := fmt.Sprintf("server-%d", i)
+		identifiers = append(identifiers, identifier)
+		weights[identifier] = i
+	}
+	weights["unknown"] = 1
+
+	store := newServerStore(t, identifiers...)
+	store.delay = 10 * time.Millisecond
+	c, httpServer := client.NewTestClient(nil, store)
+	defer httpServer.Close()
+
+	err := server.SetWeights(context.Background(), c, weights)
+	var weightErr *server.WeightError
+	if assert.True(t, errors.As(err, &weightErr), "expected WeightError but got %v", err) {
+		assert.Len(t, weightErr.Failed, 1)
+		var responseErr *client.ResponseError
+		assert.True(t, errors.As(weightErr.Failed["unknown"], &responseErr), "expected ResponseError but got %v", weightErr.Failed["unknown"])
+		assert.Contains(t, err.Error(), "'unknown'")
+	}
+
+	for _, identifier := range identifiers {
+		assert.EqualValues(t, weights[identifier], store.servers[identifier].Weight, identifier)
+		assert.EqualValues(t, "enabled", store.servers[identifier].Check, identifier)
+	}
+	assert.Greater(t, atomic.LoadInt32(&store.maxInFlight), int32(1), "servers must be updated concurrently")
+	assert.LessOrEqual(t, atomic.LoadInt32(&store.maxInFlight), int32(4), "at most 4 servers may be updated at once")
+
+	assert.NoError(t, server.SetWeights(context.Background(), c, map[string]int{"server-1": 5}))
+	assert.EqualValues(t, 5, store.servers["server-1"].Weight)
+}
+
+func TestServerConfigHash(t *testing.T) {
+	c, httpServer := client.NewTestClient(nil, newServerStore(t, "server-id"))
+	defer httpServer.Close()
+
+	fetched, err := server.NewAPI(c).GetByID(context.Background(), "server-id")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	same := fetched
+	same.Identifier, same.CustomerIdentifier, same.State = "other-id", "customer", common.Updating
+	assert.Equal(t, fetched.ConfigHash(), same.ConfigHash(), "identifiers and state must not be hashed")
+
+	for name, change := range map[string]func(s *server.Server){
+		"Name":    func(s *server.Server) { s.Name = "web-2" },
+		"IP":      func(s *server.Server) { s.IP = "192.0.2.99" },
+		"Port":    func(s *server.Server) { s.Port = 8080 },
+		"Backend": func(s *server.Server) { s.Backend.Identifier = "other-backend" },
+		"Check":   func(s *server.Server) { s.Check = "disabled" },
+		"Weight":  func(s *server.Server) { s.Weight = 0 },
+	} {
+		changed := fetched
+		change(&changed)
+		assert.NotEqual(t, fetched.ConfigHash(), changed.ConfigHash(), name)
+	}
+}
+
+func TestCreateUniqueName(t *testing.T) {
+	created := 0
+	c, httpServer := client.NewTestClient(nil, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.EqualValues(t, serverPath, r.URL.Path)
+		switch r.Method {
+		case http.MethodGet:
+			search := r.URL.Query().Get(common.OptNameSearch)
+			assert.NotEmpty(t, search, "existing servers must be searched by name")
+			page := server.ServerPage{Page: 1, TotalPages: 1, Limit: 10}
+			for _, info := range []server.ServerInfo{{Identifier: "other-id", Name: "web-10"}, {Identifier: "server-id", Name: "web-1"}} {
+				if strings.Contains(info.Name, search) {
+					page.Data = append(page.Data, info)
+				}
+			}
+			page.TotalItems = len(page.Data)
+			_ = json.NewEncoder(w).Encode(map[string]server.ServerPage{"data": page})
+		case http.MethodPost:
+			created++
+			var definition server.Definition
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&definition))
+			_ = json.NewEncoder(w).Encode(server.Server{Identifier: "new-id", Name: definition.Name})
+		}
+	}))
+	defer httpServer.Close()
+	api := server.NewAPI(c)
+	ctx := context.Background()
+
+	_, err := api.Create(ctx, server.Definition{Name: "web-1", IP: "192.0.2.1", Port: 80, Backend: "backend-id", EnsureUniqueName: true})
+	var existsErr *common.AlreadyExistsError
+	if assert.True(t, errors.As(err, &existsErr), "expected AlreadyExistsError but got %v", err) {
+		assert.EqualValues(t, "server-id", existsErr.Identifier)
+		assert.True(t, errors.Is(err, common.ErrAlreadyExists))
+	}
+	assert.Equal(t, 0, created, "conflicting servers must not be created")
+
+	_, err = api.Create(ctx, server.Definition{Name: "web-2", IP: "192.0.2.2", Port: 80, Backend: "backend-id", EnsureUniqueName: true})
+	assert.NoError(t, err)
+	_, err = api.Create(ctx, server.Definition{Name: "web-1", IP: "192.0.2.1", Port: 80, Backend: "backend-id"})
+	assert.NoError(t, err, "names are only checked on request")
+	assert.Equal(t, 2, created)
+}
@@ -0,0 +1,97 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/anexia-it/go-anxcloud/pkg/client"
+	"github.com/anexia-it/go-anxcloud/pkg/lbaas/common"
+)
+
+// setWeightsConcurrency is the maximum number of servers updated concurrently by SetWeights.
+const setWeightsConcurrency = 4
+
+// WeightError is returned by SetWeights if the weight of some servers could not be set.
+type WeightError struct {
+	// Failed maps the identifiers of the servers that could not be updated to the error encountered.
+	// All other servers were updated successfully.
+	Failed map[string]error
+}
+
+func (e *WeightError) Error() string {
+	identifiers := make([]string, 0, len(e.Failed))
+	for identifier := range e.Failed {
+		identifiers = append(identifiers, identifier)
+	}
+	sort.Strings(identifiers)
+
+	messages := make([]string, 0, len(identifiers))
+	for _, identifier := range identifiers {
+		messages = append(messages, fmt.Sprintf("'%s': %v", identifier, e.Failed[identifier]))
+	}
+
+	return fmt.Sprintf("could not set weight of %d LBaaS servers: %s", len(e.Failed), strings.Join(messages, "; "))
+}
+
+// SetWeights sets the weights of multiple load balancer backend servers, e.g. to shift traffic between them.
+//
+// ctx is attached to all requests and will cancel them on cancelation.
+// weights maps server identifiers to their new weight.
+//
+// Servers are updated concurrently. If some updates fail, the others are still applied and a WeightError
+// is returned listing the failed servers, so the caller can roll back.
+func SetWeights(ctx context.Context, c client.Client, weights map[string]int) error {
+	a := NewAPI(c)
+
+	var mu sync.Mutex
+	failed := make(map[string]error)
+	slots := make(chan struct{}, setWeightsConcurrency)
+	wg := sync.WaitGroup{}
+	for identifier, weight := range weights {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func(identifier string, weight int) {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			if err := setWeight(ctx, a, identifier, weight); err != nil {
+				mu.Lock()
+				failed[identifier] = err
+				mu.Unlock()
+			}
+		}(identifier, weight)
+	}
+	wg.Wait()
+
+	if len(failed) != 0 {
+		return &WeightError{Failed: failed}
+	}
+
+	return nil
+}
+
+// setWeight updates the weight of a server, leaving its other settings unchanged.
+func setWeight(ctx context.Context, a API, identifier string, weight int) error {
+	server, err := a.GetByID(ctx, identifier)
+	if err != nil {
+		return err
+	}
+
+	_, err = a.Update(ctx, identifier, Definition{
+		Name:    server.Name,
+		State:   common.Updating,
+		IP:      server.IP,
+		Port:    server.Port,
+		Backend: server.Backend.Identifier,
+		Weight:  &weight,
+		Check:   server.Check,
+	})
+	if err != nil {
+		return fmt.Errorf("could not set weight of LBaaS server '%s' to %d: %w", identifier, weight, err)
+	}
+
+	return nil
+}
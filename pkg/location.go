@@ -0,0 +1,106 @@
+package pkg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/anexia-it/go-anxcloud/pkg/client"
+	"github.com/anexia-it/go-anxcloud/pkg/lbaas"
+	"github.com/anexia-it/go-anxcloud/pkg/vsphere/provisioning/disktype"
+	"github.com/anexia-it/go-anxcloud/pkg/vsphere/provisioning/ips"
+	"github.com/anexia-it/go-anxcloud/pkg/vsphere/provisioning/location"
+	"github.com/anexia-it/go-anxcloud/pkg/vsphere/provisioning/templates"
+	"github.com/anexia-it/go-anxcloud/pkg/vsphere/provisioning/vm"
+)
+
+const locationPageSize = 100
+
+var (
+	// ErrUnknownLocation is raised if the location to bind does not exist.
+	ErrUnknownLocation = errors.New("location does not exist")
+	// ErrLocationMismatch is raised if a location scoped call is passed a different location.
+	ErrLocationMismatch = errors.New("location differs from the bound location")
+)
+
+// LocationScopedClient contains the location scoped API calls bound to a single location.
+type LocationScopedClient interface {
+	// Location returns the identifier of the bound location.
+	Location() string
+	// Client returns a client using the bound location for calls passed an empty location.
+	Client() client.Client
+
+	// Provision provisions a VM in the bound location. The location of the definition must be empty or
+	// the bound one, otherwise ErrLocationMismatch is returned.
+	Provision(ctx context.Context, definition vm.Definition, scriptBase64Encoded bool) (vm.ProvisioningResponse, error)
+	// FreeIPs returns the free IPs of a VLAN in the bound location.
+	FreeIPs(ctx context.Context, vlan string) ([]ips.IP, error)
+	// Templates lists the templates of the bound location.
+	Templates(ctx context.Context, templateType string, page, limit int) ([]templates.Template, error)
+	// DiskTypes lists the disk types of the bound location.
+	DiskTypes(ctx context.Context, page, limit int) ([]disktype.DiskType, error)
+	// LBaaS returns the LBaaS API using the bound client.
+	LBaaS() lbaas.API
+}
+
+type locationScopedClient struct {
+	location string
+	client   client.Client
+}
+
+// ForLocation returns a facade for the location scoped API calls bound to the given location.
+//
+// ctx is attached to the request validating that the location exists and will cancel it on cancelation.
+// If the location does not exist, ErrUnknownLocation is returned.
+func ForLocation(ctx context.Context, c client.Client, id string) (LocationScopedClient, error) {
+	locationAPI := location.NewAPI(c)
+	for page := 1; ; page++ {
+		locations, err := locationAPI.List(ctx, page, locationPageSize, "", "")
+		if err != nil {
+			return nil, fmt.Errorf("could not validate location '%s': %w", id, err)
+		}
+
+		for _, l := range locations {
+			if l.ID == id {
+				return locationScopedClient{id, client.BindLocation(c, id)}, nil
+			}
+		}
+
+		if len(locations) < locationPageSize {
+			return nil, fmt.Errorf("%w: '%s'", ErrUnknownLocation, id)
+		}
+	}
+}
+
+func (l locationScopedClient) Location() string {
+	return l.location
+}
+
+func (l locationScopedClient) Client() client.Client {
+	return l.client
+}
+
+func (l locationScopedClient) Provision(ctx context.Context, definition vm.Definition, scriptBase64Encoded bool) (vm.ProvisioningResponse, error) {
+	if definition.Location != "" && definition.Location != l.location {
+		return vm.ProvisioningResponse{}, fmt.Errorf("%w: '%s' instead of '%s'", ErrLocationMismatch, definition.Location, l.location)
+	}
+	definition.Location = l.location
+
+	return vm.NewAPI(l.client).Provision(ctx, definition, scriptBase64Encoded)
+}
+
+func (l locationScopedClient) FreeIPs(ctx context.Context, vlan string) ([]ips.IP, error) {
+	return ips.NewAPI(l.client).GetFree(ctx, l.location, vlan)
+}
+
+func (l locationScopedClient) Templates(ctx context.Context, templateType string, page, limit int) ([]templates.Template, error) {
+	return templates.NewAPI(l.client).List(ctx, l.location, templateType, page, limit)
+}
+
+func (l locationScopedClient) DiskTypes(ctx context.Context, page, limit int) ([]disktype.DiskType, error) {
+	return disktype.NewAPI(l.client).List(ctx, l.location, page, limit)
+}
+
+func (l locationScopedClient) LBaaS() lbaas.API {
+	return lbaas.NewAPI(l.client)
+}
@@ -0,0 +1,114 @@
+package pkg_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/anexia-it/go-anxcloud/pkg"
+	"github.com/anexia-it/go-anxcloud/pkg/client"
+	"github.com/anexia-it/go-anxcloud/pkg/vsphere/provisioning/location"
+	"github.com/anexia-it/go-anxcloud/pkg/vsphere/provisioning/vm"
+	"github.com/stretchr/testify/assert"
+)
+
+// newLocationMock lists 100 other locations before "location-id" and serves the location scoped calls of
+// "location-id". The paths of all other requests are recorded in requests.
+func newLocationMock(t *testing.T, requests *[]string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/vsphere/v1/provisioning/location.json":
+			page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+			locations := []location.Location{}
+			if page == 1 {
+				for i := 0; i < 100; i++ {
+					locations = append(locations, location.Location{ID: fmt.Sprintf("other-%d", i)})
+				}
+			} else if page == 2 {
+				locations = append(locations, location.Location{ID: "location-id", Code: "ANX04"})
+			}
+			assert.NoError(t, json.NewEncoder(w).Encode(map[string][]location.Location{"data": locations}))
+			return
+		case "/api/vsphere/v1/provisioning/vm.json/location-id/templates/template-id":
+			_, _ = w.Write([]byte(`{"identifier":"task-id","queued":true}`))
+		case "/api/vsphere/v1/provisioning/ips.json/location-id/vlan-id":
+			_, _ = w.Write([]byte(`{"data":[{"identifier":"ip-id","text":"192.0.2.10"}]}`))
+		case "/api/vsphere/v1/provisioning/templates.json/location-id/templates":
+			_, _ = w.Write([]byte(`[{"id":"template-id","name":"Debian 11"}]`))
+		case "/api/vsphere/v1/provisioning/disk_type.json/location-id":
+			_, _ = w.Write([]byte(`[{"id":"STD1"}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":{"code":404,"message":"not found"}}`))
+		}
+		*requests = append(*requests, r.URL.Path)
+	})
+}
+
+func TestForLocation(t *testing.T) {
+	var requests []string
+	c, server := client.NewTestClient(nil, newLocationMock(t, &requests))
+	defer server.Close()
+	ctx := context.Background()
+
+	scoped, err := pkg.ForLocation(ctx, c, "location-id")
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "location-id", scoped.Location())
+	bound, err := client.ResolveLocation(scoped.Client(), "")
+	assert.NoError(t, err)
+	assert.Equal(t, "location-id", bound)
+
+	definition := vm.NewAPI(c).NewDefinition("", "templates", "template-id", "web-001", 2, 2048, 20, nil)
+	response, err := scoped.Provision(ctx, definition, false)
+	if assert.NoError(t, err) {
+		assert.EqualValues(t, "task-id", response.Identifier)
+	}
+	definition.Location = "location-id"
+	_, err = scoped.Provision(ctx, definition, false)
+	assert.NoError(t, err)
+
+	freeIPs, err := scoped.FreeIPs(ctx, "vlan-id")
+	if assert.NoError(t, err) && assert.Len(t, freeIPs, 1) {
+		assert.EqualValues(t, "192.0.2.10", freeIPs[0].Text)
+	}
+	list, err := scoped.Templates(ctx, "templates", 1, 10)
+	if assert.NoError(t, err) && assert.Len(t, list, 1) {
+		assert.EqualValues(t, "template-id", list[0].ID)
+	}
+	diskTypes, err := scoped.DiskTypes(ctx, 1, 10)
+	if assert.NoError(t, err) && assert.Len(t, diskTypes, 1) {
+		assert.EqualValues(t, "STD1", diskTypes[0].ID)
+	}
+	assert.NotNil(t, scoped.LBaaS())
+
+	requests = nil
+	definition.Location = "other-1"
+	_, err = scoped.Provision(ctx, definition, false)
+	assert.True(t, errors.Is(err, pkg.ErrLocationMismatch), "expected ErrLocationMismatch but got %v", err)
+	assert.Empty(t, requests, "provisioning in another location must not be sent")
+}
+
+func TestForUnknownLocation(t *testing.T) {
+	var requests []string
+	c, server := client.NewTestClient(nil, newLocationMock(t, &requests))
+	defer server.Close()
+
+	_, err := pkg.ForLocation(context.Background(), c, "unknown")
+	assert.True(t, errors.Is(err, pkg.ErrUnknownLocation), "expected ErrUnknownLocation but got %v", err)
+
+	c, server = client.NewTestClient(nil, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"error":{"code":403,"message":"forbidden"}}`))
+	}))
+	defer server.Close()
+
+	_, err = pkg.ForLocation(context.Background(), c, "location-id")
+	var responseErr *client.ResponseError
+	assert.True(t, errors.As(err, &responseErr), "expected ResponseError but got %v", err)
+}
@@ -0,0 +1,56 @@
+package pagination
+
+import (
+	"context"
+	"sync"
+)
+
+// CancelFunc stops a walk started by AsChan. It may be called more than once.
+type CancelFunc func()
+
+// AsChan walks all pages of the given Pageable in the background and sends their entries to the returned channel.
+//
+// The channel is closed once all entries were sent, fetching a page failed, ctx is done or the returned
+// CancelFunc was called. Only the walk closes the channel, so canceling it at any time is safe.
+// Consumers stopping early should call the CancelFunc to end the walk. Invalid options close the channel
+// without sending any entries.
+func AsChan(ctx context.Context, pageable Pageable, opts ...Option) (<-chan interface{}, CancelFunc) {
+	o, optErr := newOptions(opts)
+	items := make(chan interface{})
+	stop := make(chan struct{})
+	once := sync.Once{}
+	cancel := func() {
+		once.Do(func() { close(stop) })
+	}
+
+	go func() {
+		defer close(items)
+		if optErr != nil {
+			return
+		}
+
+		page, err := pageable.GetPage(ctx, 1, o.pageSize)
+		for err == nil {
+			content, contentErr := sliceOf(page)
+			if contentErr != nil {
+				return
+			}
+			for i := 0; i < content.Len(); i++ {
+				select {
+				case items <- content.Index(i).Interface():
+				case <-stop:
+					return
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			if !HasNext(page) {
+				return
+			}
+			page, err = pageable.NextPage(ctx, page)
+		}
+	}()
+
+	return items, cancel
+}
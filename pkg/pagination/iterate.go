@@ -0,0 +1,95 @@
+package pagination
+
+import (
+	"context"
+	"fmt"
+)
+
+// Iterate walks all pages of the given Pageable in the background and sends their entries as T.
+//
+// Errors fetching a page, entries not being of type T and ctx being done stop the walk; the error is sent
+// on the error channel, which receives at most one error. Both channels are closed once the walk ended,
+// so consumers can range over the items and check the error channel afterwards.
+func Iterate[T any](ctx context.Context, pageable Pageable, opts ...Option) (<-chan T, <-chan error) {
+	o, optErr := newOptions(opts)
+	items := make(chan T)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(errs)
+		defer close(items)
+
+		if optErr != nil {
+			errs <- optErr
+			return
+		}
+		if err := iterate(ctx, pageable, o, items); err != nil {
+			errs <- err
+		}
+	}()
+
+	return items, errs
+}
+
+func iterate[T any](ctx context.Context, pageable Pageable, o options, items chan<- T) error {
+	page, err := pageable.GetPage(ctx, 1, o.pageSize)
+	if err != nil {
+		return fmt.Errorf("could not fetch page 1: %w", err)
+	}
+
+	for {
+		content, err := sliceOf(page)
+		if err != nil {
+			return err
+		}
+		for i := 0; i < content.Len(); i++ {
+			item, ok := content.Index(i).Interface().(T)
+			if !ok {
+				var expected T
+				return fmt.Errorf("entry %d of page %d is %T, not %T", i, page.Num(), content.Index(i).Interface(), expected)
+			}
+
+			// Checked first, as select picks randomly if the consumer is ready as well.
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			select {
+			case items <- item:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		if !HasNext(page) {
+			return nil
+		}
+
+		next := page.Num() + 1
+		page, err = pageable.NextPage(ctx, page)
+		if err != nil {
+			return fmt.Errorf("could not fetch page %d: %w", next, err)
+		}
+	}
+}
+
+// CollectTyped works like Collect, but returns the entries as T.
+//
+// An error is returned if an entry is not of type T.
+func CollectTyped[T any](ctx context.Context, pageable Pageable, opts ...Option) ([]T, error) {
+	items, err := Collect(ctx, pageable, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	typed := make([]T, 0, len(items))
+	for i, item := range items {
+		entry, ok := item.(T)
+		if !ok {
+			var expected T
+			return nil, fmt.Errorf("entry %d is %T, not %T", i, item, expected)
+		}
+		typed = append(typed, entry)
+	}
+
+	return typed, nil
+}
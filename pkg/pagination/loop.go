@@ -0,0 +1,58 @@
+package pagination
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrConditionNeverMet is raised by LoopUntil if the condition returned false for all entries.
+var ErrConditionNeverMet = errors.New("condition was never met")
+
+// UntilTrueFunc is called with every entry of a listing until it returns true or an error.
+type UntilTrueFunc func(item interface{}) (bool, error)
+
+// LoopUntil calls f with the entries of all pages of the given Pageable, in order, until it returns true.
+//
+// Pages are fetched one after another, so pages after the one containing the wanted entry are not fetched.
+// An error returned by f stops the loop and is returned. If f returned false for all entries,
+// ErrConditionNeverMet is returned.
+func LoopUntil(ctx context.Context, pageable Pageable, f UntilTrueFunc, opts ...Option) error {
+	o, err := newOptions(opts)
+	if err != nil {
+		return err
+	}
+	page, err := pageable.GetPage(ctx, 1, o.pageSize)
+	if err != nil {
+		return fmt.Errorf("could not fetch page 1: %w", err)
+	}
+
+	for {
+		content, err := sliceOf(page)
+		if err != nil {
+			return err
+		}
+		for i := 0; i < content.Len(); i++ {
+			done, err := f(content.Index(i).Interface())
+			if err != nil {
+				return err
+			}
+			if done {
+				return nil
+			}
+		}
+
+		if !HasNext(page) {
+			return ErrConditionNeverMet
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		next := page.Num() + 1
+		page, err = pageable.NextPage(ctx, page)
+		if err != nil {
+			return fmt.Errorf("could not fetch page %d: %w", next, err)
+		}
+	}
+}
@@ -0,0 +1,229 @@
+// Package pagination contains helpers for walking paged API listings.
+package pagination
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// DefaultPageSize is the number of entries requested per page if no other size is given.
+const DefaultPageSize = 20
+
+// ErrPaginationInconsistent is raised if the listed data changed while walking its pages.
+var ErrPaginationInconsistent = errors.New("listing changed during pagination")
+
+// ErrInvalidPage is raised if a page number or page size below 1 is requested.
+var ErrInvalidPage = errors.New("invalid page number or size")
+
+// Page is a single page of a paged listing.
+type Page interface {
+	// Num returns the number of this page, starting at 1.
+	Num() int
+	// Size returns the maximum number of entries per page.
+	Size() int
+	// Total returns the total number of pages.
+	Total() int
+	// TotalCount returns the total number of entries over all pages.
+	TotalCount() int
+	// Content returns the entries of this page as slice.
+	Content() interface{}
+}
+
+// Pageable is implemented by APIs offering a paged listing.
+type Pageable interface {
+	// GetPage fetches the page with the given number and size.
+	GetPage(ctx context.Context, page, limit int) (Page, error)
+	// NextPage fetches the page following the given one, using the same size.
+	NextPage(ctx context.Context, page Page) (Page, error)
+}
+
+// HasNext returns true if there are pages after the given one.
+//
+// Page numbers start at 1, like the Num of a Page. If the page does not report its Total number of pages,
+// it is derived from TotalCount and Size. Without either, there are no further pages.
+func HasNext(page Page) bool {
+	if total := page.Total(); total > 0 {
+		return page.Num() < total
+	}
+	if page.Size() > 0 {
+		return page.Num()*page.Size() < page.TotalCount()
+	}
+
+	return false
+}
+
+type options struct {
+	pageSize          int
+	detectChanges     bool
+	restartsOnChanges int
+	checkpoint        func(token ResumeToken)
+	err               error
+}
+
+// Option is an optional parameter for walking pages.
+type Option func(o *options)
+
+// PageSize sets the number of entries requested per page.
+// Sizes below 1 are rejected with ErrInvalidPage when walking the pages.
+func PageSize(size int) Option {
+	return func(o *options) {
+		if size < 1 {
+			o.err = fmt.Errorf("%w: page size %d", ErrInvalidPage, size)
+			return
+		}
+		o.pageSize = size
+	}
+}
+
+// DetectChanges compares the total number of entries of every page with the one of the first page.
+// If it differs, the walk is aborted with ErrPaginationInconsistent.
+func DetectChanges() Option {
+	return func(o *options) {
+		o.detectChanges = true
+	}
+}
+
+// RestartOnChanges detects changes like DetectChanges, but restarts the walk from the first page
+// up to maxRestarts times before giving up with ErrPaginationInconsistent.
+func RestartOnChanges(maxRestarts int) Option {
+	return func(o *options) {
+		o.detectChanges = true
+		o.restartsOnChanges = maxRestarts
+	}
+}
+
+// Checkpoint calls f after every page but the last with a token pointing to the following page.
+// The token can be stored and passed to CollectFrom to continue an interrupted walk. The entries Collect
+// returns with the error of an interrupted walk are those of the pages before the last token.
+func Checkpoint(f func(token ResumeToken)) Option {
+	return func(o *options) {
+		o.checkpoint = f
+	}
+}
+
+func newOptions(opts []Option) (options, error) {
+	o := options{pageSize: DefaultPageSize}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o, o.err
+}
+
+// Collect walks all pages of the given Pageable and returns their concatenated content.
+//
+// Entries having an Identifier field are deduplicated by its value, so entries moving between
+// pages while walking them are not returned twice. Entries can still be missed in that case;
+// use DetectChanges or RestartOnChanges to guard against it.
+//
+// If a page can not be fetched or ctx is canceled, the entries of the pages read so far are returned
+// along with the error, so a walk interrupted after a Checkpoint does not have to fetch them again.
+func Collect(ctx context.Context, pageable Pageable, opts ...Option) ([]interface{}, error) {
+	o, err := newOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return collectFrom(ctx, pageable, position{Page: 1, Limit: o.pageSize}, o)
+}
+
+// CollectFrom continues a walk at the position of the given token and returns the content of the remaining pages.
+//
+// The token determines the page size, PageSize is ignored. Entries are only deduplicated
+// within the resumed part of the walk.
+func CollectFrom(ctx context.Context, pageable Pageable, token ResumeToken, opts ...Option) ([]interface{}, error) {
+	start, err := token.position()
+	if err != nil {
+		return nil, err
+	}
+	o, err := newOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return collectFrom(ctx, pageable, start, o)
+}
+
+func collectFrom(ctx context.Context, pageable Pageable, start position, o options) ([]interface{}, error) {
+	for restarts := 0; ; restarts++ {
+		items, err := collect(ctx, pageable, start, o)
+		if errors.Is(err, ErrPaginationInconsistent) && restarts < o.restartsOnChanges {
+			continue
+		}
+
+		return items, err
+	}
+}
+
+func collect(ctx context.Context, pageable Pageable, start position, o options) ([]interface{}, error) {
+	page, err := pageable.GetPage(ctx, start.Page, start.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch page %d: %w", start.Page, err)
+	}
+
+	totalCount := page.TotalCount()
+	items := make([]interface{}, 0, totalCount)
+	seen := make(map[string]struct{}, totalCount)
+	for {
+		content, err := sliceOf(page)
+		if err != nil {
+			return nil, err
+		}
+		for i := 0; i < content.Len(); i++ {
+			item := content.Index(i).Interface()
+			if id, ok := identifierOf(item); ok {
+				if _, duplicate := seen[id]; duplicate {
+					continue
+				}
+				seen[id] = struct{}{}
+			}
+			items = append(items, item)
+		}
+
+		if !HasNext(page) {
+			return items, nil
+		}
+		if o.checkpoint != nil {
+			o.checkpoint(newResumeToken(position{Page: page.Num() + 1, Limit: page.Size()}))
+		}
+		if err := ctx.Err(); err != nil {
+			return items, err
+		}
+
+		next := page.Num() + 1
+		page, err = pageable.NextPage(ctx, page)
+		if err != nil {
+			return items, fmt.Errorf("could not fetch page %d: %w", next, err)
+		}
+		if o.detectChanges && page.TotalCount() != totalCount {
+			return nil, fmt.Errorf("%w: %d entries on page %d, %d on page %d", ErrPaginationInconsistent,
+				totalCount, start.Page, page.TotalCount(), page.Num())
+		}
+	}
+}
+
+func sliceOf(page Page) (reflect.Value, error) {
+	content := reflect.ValueOf(page.Content())
+	if content.Kind() != reflect.Slice && content.Kind() != reflect.Array {
+		return reflect.Value{}, fmt.Errorf("content of page %d is not a slice but %T", page.Num(), page.Content())
+	}
+
+	return content, nil
+}
+
+// identifierOf returns the value of the Identifier field of the given struct or struct pointer.
+func identifierOf(item interface{}) (string, bool) {
+	value := reflect.Indirect(reflect.ValueOf(item))
+	if value.Kind() != reflect.Struct {
+		return "", false
+	}
+
+	field := value.FieldByName("Identifier")
+	if !field.IsValid() {
+		return "", false
+	}
+
+	return fmt.Sprint(field.Interface()), true
+}
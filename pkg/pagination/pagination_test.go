@@ -0,0 +1,496 @@
+package pagination_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/anexia-it/go-anxcloud/pkg/pagination"
+	"github.com/stretchr/testify/assert"
+)
+
+type entry struct {
+	Identifier string
+}
+
+type fakePage struct {
+	num, size, total, totalCount int
+	content                      []entry
+}
+
+func (p fakePage) Num() int             { return p.num }
+func (p fakePage) Size() int            { return p.size }
+func (p fakePage) Total() int           { return p.total }
+func (p fakePage) TotalCount() int      { return p.totalCount }
+func (p fakePage) Content() interface{} { return p.content }
+
+// fakePageable serves entries in pages. beforeFetch is called with the page number before each page is built,
+// which allows modifying entries during a walk. Fetching page failOn fails.
+type fakePageable struct {
+	entries     []entry
+	beforeFetch func(f *fakePageable, page int)
+	failOn      int
+	fetches     int
+}
+
+func newFakePageable(ids ...string) *fakePageable {
+	f := &fakePageable{}
+	for _, id := range ids {
+		f.entries = append(f.entries, entry{id})
+	}
+
+	return f
+}
+
+func (f *fakePageable) GetPage(_ context.Context, page, limit int) (pagination.Page, error) {
+	f.fetches++
+	if page == f.failOn {
+		return nil, errors.New("unavailable")
+	}
+	if f.beforeFetch != nil {
+		f.beforeFetch(f, page)
+	}
+
+	start, end := (page-1)*limit, page*limit
+	if start > len(f.entries) {
+		start = len(f.entries)
+	}
+	if end > len(f.entries) {
+		end = len(f.entries)
+	}
+
+	return fakePage{
+		num:        page,
+		size:       limit,
+		total:      (len(f.entries) + limit - 1) / limit,
+		totalCount: len(f.entries),
+		content:    f.entries[start:end],
+	}, nil
+}
+
+func (f *fakePageable) NextPage(ctx context.Context, page pagination.Page) (pagination.Page, error) {
+	return f.GetPage(ctx, page.Num()+1, page.Size())
+}
+
+func identifiers(items []interface{}) []string {
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.(entry).Identifier)
+	}
+
+	return ids
+}
+
+func TestCollect(t *testing.T) {
+	pageable := newFakePageable("a", "b", "c", "d", "e")
+
+	items, err := pagination.Collect(context.Background(), pageable, pagination.PageSize(2))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "c", "d", "e"}, identifiers(items))
+	assert.Equal(t, 3, pageable.fetches)
+}
+
+// prependOnSecondPage inserts an entry at the start while the second page is fetched,
+// shifting the last entry of the first page onto the second one.
+func prependOnSecondPage(times int) func(f *fakePageable, page int) {
+	return func(f *fakePageable, page int) {
+		if page == 2 && times > 0 {
+			times--
+			f.entries = append([]entry{{"new"}}, f.entries...)
+		}
+	}
+}
+
+func TestCollectDeduplicatesShiftedEntries(t *testing.T) {
+	pageable := newFakePageable("a", "b", "c", "d")
+	pageable.beforeFetch = prependOnSecondPage(1)
+
+	items, err := pagination.Collect(context.Background(), pageable, pagination.PageSize(2))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "c", "d"}, identifiers(items))
+}
+
+func TestCollectDetectsChanges(t *testing.T) {
+	pageable := newFakePageable("a", "b", "c", "d")
+	pageable.beforeFetch = prependOnSecondPage(1)
+
+	_, err := pagination.Collect(context.Background(), pageable, pagination.PageSize(2), pagination.DetectChanges())
+	assert.True(t, errors.Is(err, pagination.ErrPaginationInconsistent), "expected ErrPaginationInconsistent but got %v", err)
+}
+
+func TestCollectRestartsOnChanges(t *testing.T) {
+	t.Run("ConsistentAfterRestart", func(t *testing.T) {
+		pageable := newFakePageable("a", "b", "c", "d")
+		pageable.beforeFetch = prependOnSecondPage(1)
+
+		items, err := pagination.Collect(context.Background(), pageable, pagination.PageSize(2), pagination.RestartOnChanges(1))
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"new", "a", "b", "c", "d"}, identifiers(items))
+	})
+
+	t.Run("RestartsExhausted", func(t *testing.T) {
+		pageable := newFakePageable("a", "b", "c", "d")
+		pageable.beforeFetch = prependOnSecondPage(3)
+
+		_, err := pagination.Collect(context.Background(), pageable, pagination.PageSize(2), pagination.RestartOnChanges(2))
+		assert.True(t, errors.Is(err, pagination.ErrPaginationInconsistent), "expected ErrPaginationInconsistent but got %v", err)
+	})
+}
+
+func TestCollectFrom(t *testing.T) {
+	t.Run("ResumeAfterFailure", func(t *testing.T) {
+		pageable := newFakePageable("a", "b", "c", "d", "e")
+		pageable.failOn = 3
+
+		var last pagination.ResumeToken
+		checkpoint := pagination.Checkpoint(func(token pagination.ResumeToken) {
+			last = token
+		})
+		items, err := pagination.Collect(context.Background(), pageable, pagination.PageSize(2), checkpoint)
+		assert.Error(t, err)
+		assert.Equal(t, []string{"a", "b", "c", "d"}, identifiers(items), "entries read before the failure must be returned")
+
+		pageable.failOn, pageable.fetches = 0, 0
+		items, err = pagination.CollectFrom(context.Background(), pageable, last, checkpoint)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"e"}, identifiers(items))
+		assert.Equal(t, 1, pageable.fetches, "pages read before the failure must not be fetched again")
+	})
+
+	t.Run("ResumeAfterCancel", func(t *testing.T) {
+		pageable := newFakePageable("a", "b", "c", "d", "e")
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var last pagination.ResumeToken
+		items, err := pagination.Collect(ctx, pageable, pagination.PageSize(2), pagination.Checkpoint(func(token pagination.ResumeToken) {
+			last = token
+			cancel()
+		}))
+		assert.True(t, errors.Is(err, context.Canceled), "expected Canceled but got %v", err)
+		assert.Equal(t, []string{"a", "b"}, identifiers(items), "entries read before canceling must be returned")
+
+		pageable.fetches = 0
+		items, err = pagination.CollectFrom(context.Background(), pageable, last)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"c", "d", "e"}, identifiers(items))
+		assert.Equal(t, 2, pageable.fetches, "pages read before canceling must not be fetched again")
+	})
+
+	t.Run("InvalidToken", func(t *testing.T) {
+		_, err := pagination.CollectFrom(context.Background(), newFakePageable("a"), "not-a-token")
+		assert.True(t, errors.Is(err, pagination.ErrInvalidResumeToken), "expected ErrInvalidResumeToken but got %v", err)
+	})
+}
+
+func TestLoopUntil(t *testing.T) {
+	t.Run("VisitsAllInOrder", func(t *testing.T) {
+		var visited []string
+		err := pagination.LoopUntil(context.Background(), newFakePageable("a", "b", "c"), func(item interface{}) (bool, error) {
+			visited = append(visited, item.(entry).Identifier)
+			return false, nil
+		})
+		assert.True(t, errors.Is(err, pagination.ErrConditionNeverMet), "expected ErrConditionNeverMet but got %v", err)
+		assert.Equal(t, []string{"a", "b", "c"}, visited)
+	})
+
+	t.Run("StopsAtMatch", func(t *testing.T) {
+		var visited []string
+		err := pagination.LoopUntil(context.Background(), newFakePageable("a", "b", "c"), func(item interface{}) (bool, error) {
+			visited = append(visited, item.(entry).Identifier)
+			return item.(entry).Identifier == "b", nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"a", "b"}, visited)
+	})
+
+	t.Run("ReturnsError", func(t *testing.T) {
+		failed := errors.New("failed")
+		err := pagination.LoopUntil(context.Background(), newFakePageable("a"), func(interface{}) (bool, error) {
+			return false, failed
+		})
+		assert.True(t, errors.Is(err, failed), "expected failed but got %v", err)
+	})
+}
+
+func TestAsChan(t *testing.T) {
+	t.Run("AllPages", func(t *testing.T) {
+		items, cancel := pagination.AsChan(context.Background(), newFakePageable("a", "b", "c", "d", "e"), pagination.PageSize(2))
+		defer cancel()
+
+		var received []interface{}
+		for item := range items {
+			received = append(received, item)
+		}
+		assert.Equal(t, []string{"a", "b", "c", "d", "e"}, identifiers(received))
+	})
+
+	t.Run("CancelMidIteration", func(t *testing.T) {
+		items, cancel := pagination.AsChan(context.Background(), newFakePageable("a", "b", "c", "d", "e"), pagination.PageSize(2))
+
+		assert.Equal(t, "a", (<-items).(entry).Identifier)
+		assert.NotPanics(t, func() {
+			cancel()
+			cancel()
+		})
+
+		select {
+		case _, open := <-items:
+			if open {
+				// An entry may already be on its way, the channel has to be closed right after.
+				_, open = <-items
+			}
+			assert.False(t, open)
+		case <-time.After(time.Second):
+			t.Fatal("channel was not closed after cancel")
+		}
+	})
+}
+
+func TestLoopUntilAllPages(t *testing.T) {
+	pageable := newFakePageable("a", "b", "c", "d", "e", "f")
+
+	var visited []string
+	err := pagination.LoopUntil(context.Background(), pageable, func(item interface{}) (bool, error) {
+		visited = append(visited, item.(entry).Identifier)
+		return item.(entry).Identifier == "f", nil
+	}, pagination.PageSize(2))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "c", "d", "e", "f"}, visited)
+	assert.Equal(t, 3, pageable.fetches)
+
+	pageable.fetches = 0
+	err = pagination.LoopUntil(context.Background(), pageable, func(interface{}) (bool, error) {
+		return false, nil
+	}, pagination.PageSize(2))
+	assert.True(t, errors.Is(err, pagination.ErrConditionNeverMet), "expected ErrConditionNeverMet but got %v", err)
+	assert.Equal(t, 3, pageable.fetches)
+}
+
+func TestIterate(t *testing.T) {
+	t.Run("Typed", func(t *testing.T) {
+		items, errs := pagination.Iterate[entry](context.Background(), newFakePageable("a", "b", "c"), pagination.PageSize(2))
+
+		var ids []string
+		for item := range items {
+			ids = append(ids, item.Identifier)
+		}
+		assert.NoError(t, <-errs)
+		assert.Equal(t, []string{"a", "b", "c"}, ids)
+	})
+
+	t.Run("WrongType", func(t *testing.T) {
+		items, errs := pagination.Iterate[string](context.Background(), newFakePageable("a"))
+		for range items {
+			t.Fatal("no item expected")
+		}
+		assert.Error(t, <-errs)
+	})
+
+	t.Run("Canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		items, errs := pagination.Iterate[entry](ctx, newFakePageable("a", "b", "c"))
+		<-items
+		cancel()
+		for range items {
+		}
+		assert.True(t, errors.Is(<-errs, context.Canceled))
+	})
+}
+
+func TestHasNext(t *testing.T) {
+	for name, test := range map[string]struct {
+		page    fakePage
+		hasNext bool
+	}{
+		"FirstPage":            {fakePage{num: 1, size: 10, total: 3, totalCount: 25}, true},
+		"MiddlePage":           {fakePage{num: 2, size: 10, total: 3, totalCount: 25}, true},
+		"LastPage":             {fakePage{num: 3, size: 10, total: 3, totalCount: 25}, false},
+		"SinglePage":           {fakePage{num: 1, size: 10, total: 1, totalCount: 5}, false},
+		"NoTotalFirstPage":     {fakePage{num: 1, size: 10, totalCount: 25}, true},
+		"NoTotalLastPage":      {fakePage{num: 3, size: 10, totalCount: 25}, false},
+		"NoTotalExactLastPage": {fakePage{num: 2, size: 10, totalCount: 20}, false},
+		"Empty":                {fakePage{num: 1, size: 10}, false},
+		"NoSize":               {fakePage{num: 1, totalCount: 25}, false},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.EqualValues(t, test.hasNext, pagination.HasNext(test.page))
+		})
+	}
+}
+
+// slowPageable serves count numbered entries in pages, taking delay for every page. It is safe for concurrent use.
+type slowPageable struct {
+	count int
+	delay time.Duration
+	fail  int
+}
+
+func (s slowPageable) GetPage(ctx context.Context, page, limit int) (pagination.Page, error) {
+	select {
+	case <-time.After(s.delay):
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if page == s.fail {
+		return nil, errFetch
+	}
+
+	content := []entry{}
+	for i := (page - 1) * limit; i < page*limit && i < s.count; i++ {
+		content = append(content, entry{fmt.Sprint(i)})
+	}
+
+	return fakePage{num: page, size: limit, totalCount: s.count, content: content}, nil
+}
+
+func (s slowPageable) NextPage(ctx context.Context, page pagination.Page) (pagination.Page, error) {
+	return s.GetPage(ctx, page.Num()+1, page.Size())
+}
+
+var errFetch = errors.New("fetch failed")
+
+func TestAsChanBuffered(t *testing.T) {
+	t.Run("Ordered", func(t *testing.T) {
+		items, errs, cancel := pagination.AsChanBuffered(context.Background(), slowPageable{count: 95, delay: time.Millisecond}, 4,
+			pagination.PageSize(10))
+		defer cancel()
+
+		received := 0
+		for item := range items {
+			assert.EqualValues(t, fmt.Sprint(received), item.(entry).Identifier)
+			received++
+		}
+		assert.EqualValues(t, 95, received)
+		assert.NoError(t, <-errs)
+	})
+
+	t.Run("FetchError", func(t *testing.T) {
+		items, errs, cancel := pagination.AsChanBuffered(context.Background(), slowPageable{count: 95, delay: time.Millisecond, fail: 3}, 4,
+			pagination.PageSize(10))
+		defer cancel()
+
+		received := 0
+		for range items {
+			received++
+		}
+		assert.EqualValues(t, 20, received)
+		err := <-errs
+		assert.True(t, errors.Is(err, errFetch), "expected errFetch but got %v", err)
+	})
+
+	t.Run("Cancel", func(t *testing.T) {
+		items, errs, cancel := pagination.AsChanBuffered(context.Background(), slowPageable{count: 95, delay: time.Millisecond}, 4,
+			pagination.PageSize(10))
+
+		assert.EqualValues(t, "0", (<-items).(entry).Identifier)
+		cancel()
+		for range items {
+		}
+		assert.NoError(t, <-errs)
+	})
+
+	t.Run("ContextDone", func(t *testing.T) {
+		ctx, cancelCtx := context.WithCancel(context.Background())
+		items, errs, cancel := pagination.AsChanBuffered(ctx, slowPageable{count: 95, delay: time.Millisecond}, 4,
+			pagination.PageSize(10))
+		defer cancel()
+
+		<-items
+		cancelCtx()
+		for range items {
+		}
+		err := <-errs
+		assert.True(t, errors.Is(err, context.Canceled), "expected context.Canceled but got %v", err)
+	})
+}
+
+func BenchmarkAsChan(b *testing.B) {
+	pageable := slowPageable{count: 200, delay: time.Millisecond}
+	for i := 0; i < b.N; i++ {
+		items, cancel := pagination.AsChan(context.Background(), pageable, pagination.PageSize(10))
+		for range items {
+		}
+		cancel()
+	}
+}
+
+func BenchmarkAsChanBuffered(b *testing.B) {
+	pageable := slowPageable{count: 200, delay: time.Millisecond}
+	for i := 0; i < b.N; i++ {
+		items, _, cancel := pagination.AsChanBuffered(context.Background(), pageable, 8, pagination.PageSize(10))
+		for range items {
+		}
+		cancel()
+	}
+}
+
+func TestCollectTyped(t *testing.T) {
+	t.Run("SinglePage", func(t *testing.T) {
+		pageable := newFakePageable("a", "b")
+		items, err := pagination.CollectTyped[entry](context.Background(), pageable)
+		assert.NoError(t, err)
+		assert.Equal(t, []entry{{"a"}, {"b"}}, items)
+		assert.Equal(t, 1, pageable.fetches)
+	})
+
+	t.Run("MultiplePages", func(t *testing.T) {
+		items, err := pagination.CollectTyped[entry](context.Background(), newFakePageable("a", "b", "c"), pagination.PageSize(2))
+		assert.NoError(t, err)
+		assert.Equal(t, []entry{{"a"}, {"b"}, {"c"}}, items)
+	})
+
+	t.Run("FetchError", func(t *testing.T) {
+		_, err := pagination.CollectTyped[entry](context.Background(), slowPageable{count: 3, fail: 2}, pagination.PageSize(2))
+		assert.True(t, errors.Is(err, errFetch), "expected errFetch but got %v", err)
+	})
+
+	t.Run("WrongType", func(t *testing.T) {
+		_, err := pagination.CollectTyped[string](context.Background(), newFakePageable("a"))
+		assert.Error(t, err)
+	})
+
+	t.Run("Canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := pagination.CollectTyped[entry](ctx, newFakePageable("a", "b", "c"), pagination.PageSize(2))
+		assert.True(t, errors.Is(err, context.Canceled), "expected context.Canceled but got %v", err)
+	})
+}
+
+func TestInvalidPageSize(t *testing.T) {
+	ctx := context.Background()
+	for _, size := range []int{0, -1} {
+		pageable := newFakePageable("a", "b")
+		invalid := func(err error) {
+			assert.True(t, errors.Is(err, pagination.ErrInvalidPage), "expected ErrInvalidPage for size %d but got %v", size, err)
+		}
+
+		_, err := pagination.Collect(ctx, pageable, pagination.PageSize(size))
+		invalid(err)
+		invalid(pagination.LoopUntil(ctx, pageable, func(interface{}) (bool, error) { return true, nil }, pagination.PageSize(size)))
+
+		items, errs := pagination.Iterate[entry](ctx, pageable, pagination.PageSize(size))
+		for range items {
+			t.Fatal("no item expected")
+		}
+		invalid(<-errs)
+
+		buffered, errs, cancel := pagination.AsChanBuffered(ctx, pageable, 2, pagination.PageSize(size))
+		for range buffered {
+			t.Fatal("no item expected")
+		}
+		invalid(<-errs)
+		cancel()
+
+		unbuffered, cancel := pagination.AsChan(ctx, pageable, pagination.PageSize(size))
+		for range unbuffered {
+			t.Fatal("no item expected")
+		}
+		cancel()
+
+		assert.Zero(t, pageable.fetches, "no page must be fetched with size %d", size)
+	}
+}
@@ -0,0 +1,147 @@
+package pagination
+
+import (
+	"context"
+	"fmt"
+)
+
+type pageResult struct {
+	page Page
+	err  error
+}
+
+// AsChanBuffered works like AsChan, but fetches up to concurrency pages at the same time.
+//
+// Entries are sent in the same order as with AsChan. The first page is fetched alone to learn the number
+// of pages, if the listing does not report it the remaining pages are walked one by one. Errors fetching
+// a page stop the walk and are sent on the error channel, which receives at most one error. Both channels
+// are closed once the walk ended, ctx is done or the returned CancelFunc was called.
+func AsChanBuffered(ctx context.Context, pageable Pageable, concurrency int, opts ...Option) (<-chan interface{}, <-chan error, CancelFunc) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	o, optErr := newOptions(opts)
+	items := make(chan interface{})
+	errs := make(chan error, 1)
+	walkCtx, cancelWalk := context.WithCancel(ctx)
+	cancel := CancelFunc(cancelWalk)
+
+	go func() {
+		defer close(errs)
+		defer close(items)
+		defer cancel()
+
+		if optErr != nil {
+			errs <- optErr
+			return
+		}
+		// Errors caused by calling the CancelFunc are not reported, the caller stopped the walk on purpose.
+		if err := prefetch(walkCtx, pageable, concurrency, o, items); err != nil && (walkCtx.Err() == nil || ctx.Err() != nil) {
+			errs <- err
+		}
+	}()
+
+	return items, errs, cancel
+}
+
+func prefetch(ctx context.Context, pageable Pageable, concurrency int, o options, items chan<- interface{}) error {
+	first, err := pageable.GetPage(ctx, 1, o.pageSize)
+	if err != nil {
+		return fmt.Errorf("could not fetch page 1: %w", err)
+	}
+	if err := sendContent(ctx, first, items); err != nil {
+		return err
+	}
+
+	total := pageCount(first)
+	if total == 0 {
+		return walkFrom(ctx, pageable, first, items)
+	}
+
+	// Every pending page holds one slot of the queue until its entries were sent,
+	// so at most concurrency pages are fetched or buffered at any time.
+	queue := make(chan chan pageResult, concurrency-1)
+	go func() {
+		defer close(queue)
+		for num := 2; num <= total; num++ {
+			result := make(chan pageResult, 1)
+			select {
+			case queue <- result:
+			case <-ctx.Done():
+				return
+			}
+			go func(num int) {
+				page, err := pageable.GetPage(ctx, num, first.Size())
+				if err != nil {
+					err = fmt.Errorf("could not fetch page %d: %w", num, err)
+				}
+				result <- pageResult{page, err}
+			}(num)
+		}
+	}()
+
+	for result := range queue {
+		var fetched pageResult
+		select {
+		case fetched = <-result:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		if fetched.err != nil {
+			return fetched.err
+		}
+		if err := sendContent(ctx, fetched.page, items); err != nil {
+			return err
+		}
+	}
+
+	return ctx.Err()
+}
+
+// walkFrom sends the entries of all pages following page, fetching them one by one.
+func walkFrom(ctx context.Context, pageable Pageable, page Page, items chan<- interface{}) error {
+	for HasNext(page) {
+		next := page.Num() + 1
+		var err error
+		if page, err = pageable.NextPage(ctx, page); err != nil {
+			return fmt.Errorf("could not fetch page %d: %w", next, err)
+		}
+		if err := sendContent(ctx, page, items); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// pageCount returns the total number of pages of the listing, 0 if it is unknown.
+func pageCount(page Page) int {
+	if total := page.Total(); total > 0 {
+		return total
+	}
+	if page.Size() > 0 {
+		return (page.TotalCount() + page.Size() - 1) / page.Size()
+	}
+
+	return 0
+}
+
+func sendContent(ctx context.Context, page Page, items chan<- interface{}) error {
+	content, err := sliceOf(page)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < content.Len(); i++ {
+		// Checked first, as select picks randomly if the consumer is ready as well.
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		select {
+		case items <- content.Index(i).Interface():
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	return nil
+}
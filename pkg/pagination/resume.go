@@ -0,0 +1,55 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+const resumeTokenVersion = 1
+
+// ErrInvalidResumeToken is raised if a ResumeToken could not be decoded.
+var ErrInvalidResumeToken = errors.New("invalid resume token")
+
+// ResumeToken is an opaque position within a paged listing.
+//
+// Tokens are versioned, tokens of an unknown version are rejected with ErrInvalidResumeToken.
+type ResumeToken string
+
+type position struct {
+	Version int `json:"v"`
+	Page    int `json:"p"`
+	Limit   int `json:"l"`
+}
+
+func newResumeToken(p position) ResumeToken {
+	p.Version = resumeTokenVersion
+	data, err := json.Marshal(p)
+	if err != nil {
+		panic(fmt.Sprintf("could not encode resume token: %v", err))
+	}
+
+	return ResumeToken(base64.RawURLEncoding.EncodeToString(data))
+}
+
+func (t ResumeToken) position() (position, error) {
+	data, err := base64.RawURLEncoding.DecodeString(string(t))
+	if err != nil {
+		return position{}, fmt.Errorf("%w: %v", ErrInvalidResumeToken, err)
+	}
+
+	var p position
+	if err := json.Unmarshal(data, &p); err != nil {
+		return position{}, fmt.Errorf("%w: %v", ErrInvalidResumeToken, err)
+	}
+
+	switch {
+	case p.Version != resumeTokenVersion:
+		return position{}, fmt.Errorf("%w: unsupported version %d", ErrInvalidResumeToken, p.Version)
+	case p.Page < 1 || p.Limit < 1:
+		return position{}, fmt.Errorf("%w: page %d with limit %d", ErrInvalidResumeToken, p.Page, p.Limit)
+	}
+
+	return p, nil
+}
@@ -0,0 +1,235 @@
+package snapshot
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/anexia-it/go-anxcloud/pkg/clouddns/zone"
+)
+
+// ChangeType describes how a resource changed between two snapshots.
+type ChangeType string
+
+const (
+	// Added resources are only contained in the newer snapshot.
+	Added ChangeType = "added"
+	// Removed resources are only contained in the older snapshot.
+	Removed ChangeType = "removed"
+	// Modified resources are contained in both snapshots with different fields.
+	Modified ChangeType = "modified"
+)
+
+// FieldChange is a single changed field of a modified resource.
+type FieldChange struct {
+	// Field is the path of the field in the JSON representation of the resource, e.g. "zone.ttl".
+	Field string `json:"field"`
+	Old   string `json:"old,omitempty"`
+	New   string `json:"new,omitempty"`
+}
+
+// Change is a resource that changed between two snapshots.
+type Change struct {
+	Resource Resource `json:"resource"`
+	// Kind is the kind of the changed resource, e.g. "record" or "backend".
+	Kind string `json:"kind"`
+	// Key identifies the changed resource within its kind.
+	Key  string     `json:"key"`
+	Type ChangeType `json:"type"`
+	// Fields lists the changed fields of a modified resource.
+	Fields []FieldChange `json:"fields,omitempty"`
+}
+
+func (c Change) String() string {
+	symbol := map[ChangeType]string{Added: "+", Removed: "-", Modified: "~"}[c.Type]
+	lines := []string{fmt.Sprintf("%s %s %s", symbol, c.Kind, c.Key)}
+	for _, field := range c.Fields {
+		lines = append(lines, fmt.Sprintf("    %s: %s -> %s", field.Field, field.Old, field.New))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// SnapshotDiff contains all changes between two snapshots, sorted by resource type, kind and key.
+type SnapshotDiff struct {
+	Changes []Change `json:"changes"`
+}
+
+// Empty returns true if both snapshots contain the same resources.
+func (d SnapshotDiff) Empty() bool {
+	return len(d.Changes) == 0
+}
+
+func (d SnapshotDiff) String() string {
+	if d.Empty() {
+		return "no changes"
+	}
+
+	lines := make([]string, 0, len(d.Changes))
+	for _, change := range d.Changes {
+		lines = append(lines, change.String())
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// Diff computes the changes from snapshot a to snapshot b.
+//
+// Resources are matched by name for zones and by identifier otherwise. The addresses of A and AAAA
+// records are compared in their canonical form. Resource types not
+// contained in one of the snapshots, e.g. because they were not included in its export, are
+// reported as added or removed.
+func Diff(a, b Snapshot) SnapshotDiff {
+	var changes []Change
+
+	changes = append(changes, diffEntries(Zones, "zone", a.zoneEntries(), b.zoneEntries())...)
+	changes = append(changes, diffEntries(Zones, "record", a.recordEntries(), b.recordEntries())...)
+
+	oldLBaaS, newLBaaS := lbaasOf(a), lbaasOf(b)
+	changes = append(changes, diffEntries(LBaaS, "load balancer", keyed(oldLBaaS.LoadBalancers), keyed(newLBaaS.LoadBalancers))...)
+	changes = append(changes, diffEntries(LBaaS, "frontend", keyed(oldLBaaS.Frontends), keyed(newLBaaS.Frontends))...)
+	changes = append(changes, diffEntries(LBaaS, "backend", keyed(oldLBaaS.Backends), keyed(newLBaaS.Backends))...)
+	changes = append(changes, diffEntries(LBaaS, "bind", keyed(oldLBaaS.Binds), keyed(newLBaaS.Binds))...)
+	changes = append(changes, diffEntries(LBaaS, "server", keyed(oldLBaaS.Servers), keyed(newLBaaS.Servers))...)
+
+	changes = append(changes, diffEntries(VMs, "vm", keyed(a.VMs), keyed(b.VMs))...)
+
+	sort.SliceStable(changes, func(i, j int) bool {
+		if changes[i].Resource != changes[j].Resource {
+			return changes[i].Resource < changes[j].Resource
+		}
+		if changes[i].Kind != changes[j].Kind {
+			return changes[i].Kind < changes[j].Kind
+		}
+
+		return changes[i].Key < changes[j].Key
+	})
+
+	return SnapshotDiff{Changes: changes}
+}
+
+func lbaasOf(s Snapshot) LBaaSState {
+	if s.LBaaS == nil {
+		return LBaaSState{}
+	}
+
+	return *s.LBaaS
+}
+
+func (s Snapshot) zoneEntries() map[string]interface{} {
+	entries := make(map[string]interface{}, len(s.Zones))
+	for _, z := range s.Zones {
+		entries[zoneName(z.Zone)] = z.Zone
+	}
+
+	return entries
+}
+
+func (s Snapshot) recordEntries() map[string]interface{} {
+	entries := make(map[string]interface{})
+	for _, z := range s.Zones {
+		for _, record := range z.Records {
+			// Equal addresses in different notations are no change.
+			if rdata, err := zone.NormalizeRData(zone.RecordType(record.Type), record.RData); err == nil {
+				record.RData = rdata
+			}
+			entries[fmt.Sprintf("%s/%s", zoneName(z.Zone), record.Identifier)] = record
+		}
+	}
+
+	return entries
+}
+
+// keyed maps the entries of the given slice by the value of their Identifier field.
+func keyed(slice interface{}) map[string]interface{} {
+	value := reflect.ValueOf(slice)
+	entries := make(map[string]interface{}, value.Len())
+	for i := 0; i < value.Len(); i++ {
+		entry := value.Index(i)
+		entries[fmt.Sprint(entry.FieldByName("Identifier").Interface())] = entry.Interface()
+	}
+
+	return entries
+}
+
+func diffEntries(resource Resource, kind string, old, new map[string]interface{}) []Change {
+	var changes []Change
+	for key, oldEntry := range old {
+		newEntry, ok := new[key]
+		if !ok {
+			changes = append(changes, Change{Resource: resource, Kind: kind, Key: key, Type: Removed})
+			continue
+		}
+
+		if fields := diffFields(oldEntry, newEntry); len(fields) != 0 {
+			changes = append(changes, Change{Resource: resource, Kind: kind, Key: key, Type: Modified, Fields: fields})
+		}
+	}
+
+	for key := range new {
+		if _, ok := old[key]; !ok {
+			changes = append(changes, Change{Resource: resource, Kind: kind, Key: key, Type: Added})
+		}
+	}
+
+	return changes
+}
+
+// diffFields compares the JSON representations of two resources field by field.
+func diffFields(old, new interface{}) []FieldChange {
+	oldFields, newFields := map[string]string{}, map[string]string{}
+	flatten("", toJSONValue(old), oldFields)
+	flatten("", toJSONValue(new), newFields)
+
+	var changes []FieldChange
+	for field, oldValue := range oldFields {
+		if newValue, ok := newFields[field]; !ok || newValue != oldValue {
+			changes = append(changes, FieldChange{Field: field, Old: oldValue, New: newValue})
+		}
+	}
+	for field, newValue := range newFields {
+		if _, ok := oldFields[field]; !ok {
+			changes = append(changes, FieldChange{Field: field, New: newValue})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Field < changes[j].Field
+	})
+
+	return changes
+}
+
+func toJSONValue(v interface{}) interface{} {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return string(data)
+	}
+
+	return value
+}
+
+// flatten stores the values of nested objects in fields, keyed by their dot-separated path.
+// Arrays and scalars are stored by their JSON encoding.
+func flatten(prefix string, value interface{}, fields map[string]string) {
+	if object, ok := value.(map[string]interface{}); ok {
+		for key, nested := range object {
+			path := key
+			if prefix != "" {
+				path = prefix + "." + key
+			}
+			flatten(path, nested, fields)
+		}
+
+		return
+	}
+
+	data, _ := json.Marshal(value)
+	fields[prefix] = string(data)
+}
@@ -0,0 +1,77 @@
+package snapshot_test
+
+import (
+	"testing"
+
+	"github.com/anexia-it/go-anxcloud/pkg/clouddns/zone"
+	"github.com/anexia-it/go-anxcloud/pkg/lbaas/backend"
+	"github.com/anexia-it/go-anxcloud/pkg/snapshot"
+	"github.com/anexia-it/go-anxcloud/pkg/vsphere/vmlist"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+var recordID = uuid.Must(uuid.FromString("6f7c3a4e-9a3d-4c1e-8d7b-2b1f0c9e8a71"))
+
+func newSnapshot() snapshot.Snapshot {
+	return snapshot.Snapshot{
+		Zones: []snapshot.Zone{{
+			Zone: zone.Zone{Definition: &zone.Definition{Name: "example.com", TTL: 3600}},
+			Records: []zone.Record{
+				{Identifier: recordID, Name: "www", Type: "A", RData: "192.0.2.1"},
+			},
+		}},
+		LBaaS: &snapshot.LBaaSState{
+			Backends: []backend.Backend{{Identifier: "backend-1", Name: "web"}},
+		},
+		VMs: []vmlist.VM{
+			{Identifier: "vm-1", Name: "web-01"},
+			{Identifier: "vm-2", Name: "web-02"},
+		},
+	}
+}
+
+func TestDiffEqual(t *testing.T) {
+	diff := snapshot.Diff(newSnapshot(), newSnapshot())
+	assert.True(t, diff.Empty())
+	assert.Equal(t, "no changes", diff.String())
+}
+
+func TestDiff(t *testing.T) {
+	old, new := newSnapshot(), newSnapshot()
+	new.Zones[0].Records[0].RData = "192.0.2.2"
+	new.Zones = append(new.Zones, snapshot.Zone{Zone: zone.Zone{Definition: &zone.Definition{Name: "example.org"}}})
+	new.LBaaS.Backends[0].Mode = "tcp"
+	new.VMs = new.VMs[:1]
+
+	diff := snapshot.Diff(old, new)
+	assert.Equal(t, []snapshot.Change{
+		{
+			Resource: snapshot.LBaaS, Kind: "backend", Key: "backend-1", Type: snapshot.Modified,
+			Fields: []snapshot.FieldChange{{Field: "mode", Old: `""`, New: `"tcp"`}},
+		},
+		{Resource: snapshot.VMs, Kind: "vm", Key: "vm-2", Type: snapshot.Removed},
+		{
+			Resource: snapshot.Zones, Kind: "record", Key: "example.com/" + recordID.String(), Type: snapshot.Modified,
+			Fields: []snapshot.FieldChange{{Field: "rdata", Old: `"192.0.2.1"`, New: `"192.0.2.2"`}},
+		},
+		{Resource: snapshot.Zones, Kind: "zone", Key: "example.org", Type: snapshot.Added},
+	}, diff.Changes)
+
+	assert.Equal(t, `~ backend backend-1
+    mode: "" -> "tcp"
+- vm vm-2
+~ record example.com/`+recordID.String()+`
+    rdata: "192.0.2.1" -> "192.0.2.2"
++ zone example.org`, diff.String())
+}
+
+func TestDiffMissingResourceType(t *testing.T) {
+	old, new := newSnapshot(), newSnapshot()
+	new.LBaaS = nil
+
+	diff := snapshot.Diff(old, new)
+	assert.Equal(t, []snapshot.Change{
+		{Resource: snapshot.LBaaS, Kind: "backend", Key: "backend-1", Type: snapshot.Removed},
+	}, diff.Changes)
+}
@@ -0,0 +1,232 @@
+package snapshot
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/anexia-it/go-anxcloud/pkg/client"
+	"github.com/anexia-it/go-anxcloud/pkg/clouddns/zone"
+	"github.com/anexia-it/go-anxcloud/pkg/vsphere/vmlist"
+)
+
+const (
+	// DefaultConcurrency is the default maximum number of concurrent requests of an export.
+	DefaultConcurrency = 4
+	listPageSize       = 100
+)
+
+type options struct {
+	resources   []Resource
+	concurrency int
+}
+
+// Option is an optional parameter for Export.
+type Option func(o *options)
+
+// Include limits the export to the given resource types. By default all resource types are exported.
+func Include(resources ...Resource) Option {
+	return func(o *options) {
+		o.resources = resources
+	}
+}
+
+// Concurrency sets the maximum number of concurrent requests.
+func Concurrency(n int) Option {
+	return func(o *options) {
+		if n > 0 {
+			o.concurrency = n
+		}
+	}
+}
+
+// Export reads all resources of the included types and returns them as Snapshot.
+//
+// ctx is attached to all requests and will cancel them on cancelation.
+//
+// Resources are fetched concurrently. If some of them can not be read, the export continues with the others
+// and an ExportError is returned along with the snapshot of everything that could be read.
+// Entries are sorted by name or identifier so snapshots of the same state are equal.
+func Export(ctx context.Context, c client.Client, opts ...Option) (Snapshot, error) {
+	o := options{resources: AllResources, concurrency: DefaultConcurrency}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	e := exporter{
+		ctx:    ctx,
+		client: c,
+		slots:  make(chan struct{}, o.concurrency),
+		failed: make(map[Resource][]error),
+	}
+
+	snapshot := Snapshot{CreatedAt: time.Now().UTC()}
+	wg := sync.WaitGroup{}
+	for _, resource := range o.resources {
+		wg.Add(1)
+		go func(resource Resource) {
+			defer wg.Done()
+			switch resource {
+			case Zones:
+				snapshot.Zones = e.zones()
+			case LBaaS:
+				snapshot.LBaaS = e.lbaas()
+			case VMs:
+				snapshot.VMs = e.vms()
+			default:
+				e.fail(resource, fmt.Errorf("unknown resource type"))
+			}
+		}(resource)
+	}
+	wg.Wait()
+
+	if len(e.failed) != 0 {
+		return snapshot, &ExportError{Failed: e.failed}
+	}
+
+	return snapshot, nil
+}
+
+type exporter struct {
+	ctx    context.Context
+	client client.Client
+	slots  chan struct{}
+
+	mu     sync.Mutex
+	failed map[Resource][]error
+}
+
+func (e *exporter) fail(resource Resource, err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.failed[resource] = append(e.failed[resource], err)
+}
+
+// call runs f once a request slot is free.
+func (e *exporter) call(f func() error) error {
+	select {
+	case e.slots <- struct{}{}:
+	case <-e.ctx.Done():
+		return e.ctx.Err()
+	}
+	defer func() { <-e.slots }()
+
+	return f()
+}
+
+// each calls fetch for all indices up to n concurrently and records failures for the given resource.
+// It returns whether fetch succeeded for each index.
+func (e *exporter) each(resource Resource, n int, fetch func(i int) error) []bool {
+	succeeded := make([]bool, n)
+	wg := sync.WaitGroup{}
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if err := e.call(func() error { return fetch(i) }); err != nil {
+				e.fail(resource, err)
+				return
+			}
+			succeeded[i] = true
+		}(i)
+	}
+	wg.Wait()
+
+	return succeeded
+}
+
+// listAll pages through a listing using get, which returns the identifiers of the entries on the given page.
+func (e *exporter) listAll(get func(page, limit int) ([]string, error)) ([]string, error) {
+	var identifiers []string
+	for page := 1; ; page++ {
+		var pageIdentifiers []string
+		err := e.call(func() (err error) {
+			pageIdentifiers, err = get(page, listPageSize)
+			return err
+		})
+		if err != nil {
+			return identifiers, err
+		}
+
+		identifiers = append(identifiers, pageIdentifiers...)
+		if len(pageIdentifiers) < listPageSize {
+			return identifiers, nil
+		}
+	}
+}
+
+func (e *exporter) zones() []Zone {
+	a := zone.NewAPI(e.client)
+
+	var zones []zone.Zone
+	err := e.call(func() (err error) {
+		zones, err = a.List(e.ctx)
+		return err
+	})
+	if err != nil {
+		e.fail(Zones, fmt.Errorf("could not list zones: %w", err))
+		return nil
+	}
+
+	result := make([]Zone, len(zones))
+	succeeded := e.each(Zones, len(zones), func(i int) error {
+		name := zoneName(zones[i])
+		records, err := a.ListRecords(e.ctx, name)
+		if err != nil {
+			return fmt.Errorf("could not list records of zone '%s': %w", name, err)
+		}
+		sort.Slice(records, func(i, j int) bool {
+			return records[i].Identifier.String() < records[j].Identifier.String()
+		})
+		result[i] = Zone{Zone: zones[i], Records: records}
+
+		return nil
+	})
+
+	exported := make([]Zone, 0, len(result))
+	for i, ok := range succeeded {
+		if ok {
+			exported = append(exported, result[i])
+		}
+	}
+	sort.Slice(exported, func(i, j int) bool {
+		return zoneName(exported[i].Zone) < zoneName(exported[j].Zone)
+	})
+
+	return exported
+}
+
+func zoneName(z zone.Zone) string {
+	if z.Definition == nil {
+		return ""
+	}
+
+	return z.Name
+}
+
+func (e *exporter) vms() []vmlist.VM {
+	a := vmlist.NewAPI(e.client)
+
+	var vms []vmlist.VM
+	_, err := e.listAll(func(page, limit int) ([]string, error) {
+		pageVMs, err := a.Get(e.ctx, page, limit)
+		identifiers := make([]string, 0, len(pageVMs))
+		for _, vm := range pageVMs {
+			vms = append(vms, vm)
+			identifiers = append(identifiers, vm.Identifier)
+		}
+
+		return identifiers, err
+	})
+	if err != nil {
+		e.fail(VMs, fmt.Errorf("could not list VMs: %w", err))
+	}
+
+	sort.Slice(vms, func(i, j int) bool {
+		return vms[i].Identifier < vms[j].Identifier
+	})
+
+	return vms
+}
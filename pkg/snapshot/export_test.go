@@ -0,0 +1,151 @@
+package snapshot_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/anexia-it/go-anxcloud/pkg/client"
+	"github.com/anexia-it/go-anxcloud/pkg/snapshot"
+	"github.com/stretchr/testify/assert"
+)
+
+// exportMock serves two resources of every LBaaS kind, two zones with records and two VMs.
+// Requests for the resources in failing are answered with an error.
+type exportMock struct {
+	failing map[string]bool
+
+	mu          sync.Mutex
+	paths       []string
+	inFlight    int32
+	maxInFlight int32
+}
+
+func (m *exportMock) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	current := atomic.AddInt32(&m.inFlight, 1)
+	defer atomic.AddInt32(&m.inFlight, -1)
+	m.mu.Lock()
+	m.paths = append(m.paths, r.URL.Path)
+	if current > m.maxInFlight {
+		m.maxInFlight = current
+	}
+	m.mu.Unlock()
+	// Keep requests in flight long enough to overlap.
+	time.Sleep(time.Millisecond)
+
+	path := r.URL.Path
+	if m.failing[path] {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(`{"error":{"code":500,"message":"failed"}}`))
+		return
+	}
+
+	switch {
+	case path == "/api/clouddns/v1/zone.json":
+		// Zones are listed out of order, the export sorts them.
+		_, _ = w.Write([]byte(`{"results":[{"name":"example.org"},{"name":"example.com"}]}`))
+	case strings.HasPrefix(path, "/api/clouddns/v1/zone.json/") && strings.HasSuffix(path, "/records"):
+		_, _ = w.Write([]byte(`[
+			{"identifier":"6ba7b811-9dad-11d1-80b4-00c04fd430c8","name":"www","type":"A","rdata":"192.0.2.2"},
+			{"identifier":"6ba7b810-9dad-11d1-80b4-00c04fd430c8","name":"@","type":"A","rdata":"192.0.2.1"}
+		]`))
+	case path == "/api/vsphere/v1/vmlist/list.json":
+		_, _ = w.Write([]byte(`{"data":[{"identifier":"vm-2","name":"web-02"},{"identifier":"vm-1","name":"web-01"}]}`))
+	case strings.HasPrefix(path, "/api/LBaaS/v1/"):
+		kind := strings.TrimSuffix(strings.TrimPrefix(path, "/api/LBaaS/v1/"), ".json")
+		if parts := strings.SplitN(kind, ".json/", 2); len(parts) == 2 {
+			fmt.Fprintf(w, `{"identifier":"%s","name":"%s"}`, parts[1], parts[1])
+			return
+		}
+		fmt.Fprintf(w, `{"data":{"page":1,"total_pages":1,"data":[{"identifier":"%[1]s-2"},{"identifier":"%[1]s-1"}]}}`, kind)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error":{"code":404,"message":"not found"}}`))
+	}
+}
+
+func TestExport(t *testing.T) {
+	mock := &exportMock{}
+	c, server := client.NewTestClient(nil, mock)
+	defer server.Close()
+
+	exported, err := snapshot.Export(context.Background(), c, snapshot.Concurrency(2))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	if assert.Len(t, exported.Zones, 2) {
+		assert.Equal(t, "example.com", exported.Zones[0].Zone.Name)
+		assert.Equal(t, "example.org", exported.Zones[1].Zone.Name)
+		if assert.Len(t, exported.Zones[0].Records, 2) {
+			assert.Equal(t, "@", exported.Zones[0].Records[0].Name, "records must be sorted by identifier")
+		}
+	}
+	if assert.Len(t, exported.VMs, 2) {
+		assert.Equal(t, "vm-1", exported.VMs[0].Identifier)
+	}
+	if assert.NotNil(t, exported.LBaaS) {
+		assert.Len(t, exported.LBaaS.LoadBalancers, 2)
+		assert.Len(t, exported.LBaaS.Frontends, 2)
+		assert.Len(t, exported.LBaaS.Binds, 2)
+		if assert.Len(t, exported.LBaaS.Backends, 2) {
+			assert.Equal(t, "backend-1", exported.LBaaS.Backends[0].Name, "resources must be fetched by identifier")
+		}
+		if assert.Len(t, exported.LBaaS.Servers, 2) {
+			assert.Equal(t, "server-1", exported.LBaaS.Servers[0].Identifier)
+		}
+	}
+	assert.False(t, exported.CreatedAt.IsZero())
+
+	assert.Greater(t, mock.maxInFlight, int32(1), "resources must be fetched concurrently")
+	assert.LessOrEqual(t, mock.maxInFlight, int32(2), "the concurrency limit must be kept")
+}
+
+func TestExportInclude(t *testing.T) {
+	mock := &exportMock{}
+	c, server := client.NewTestClient(nil, mock)
+	defer server.Close()
+
+	exported, err := snapshot.Export(context.Background(), c, snapshot.Include(snapshot.VMs))
+	assert.NoError(t, err)
+	assert.Len(t, exported.VMs, 2)
+	assert.Empty(t, exported.Zones)
+	assert.Nil(t, exported.LBaaS)
+	assert.Equal(t, []string{"/api/vsphere/v1/vmlist/list.json"}, mock.paths)
+}
+
+func TestExportPartialFailure(t *testing.T) {
+	mock := &exportMock{failing: map[string]bool{
+		"/api/clouddns/v1/zone.json/example.org/records": true,
+		"/api/LBaaS/v1/server.json/server-2":             true,
+		"/api/vsphere/v1/vmlist/list.json":               true,
+	}}
+	c, server := client.NewTestClient(nil, mock)
+	defer server.Close()
+
+	exported, err := snapshot.Export(context.Background(), c)
+	var exportErr *snapshot.ExportError
+	if !assert.True(t, errors.As(err, &exportErr), "expected ExportError but got %v", err) {
+		return
+	}
+	assert.Len(t, exportErr.Failed[snapshot.Zones], 1)
+	assert.Len(t, exportErr.Failed[snapshot.LBaaS], 1)
+	assert.Len(t, exportErr.Failed[snapshot.VMs], 1)
+	assert.Contains(t, err.Error(), "server-2")
+	assert.Contains(t, err.Error(), "example.org")
+
+	if assert.Len(t, exported.Zones, 1, "zones that could be read must be kept") {
+		assert.Equal(t, "example.com", exported.Zones[0].Zone.Name)
+	}
+	if assert.NotNil(t, exported.LBaaS) && assert.Len(t, exported.LBaaS.Servers, 1) {
+		assert.Equal(t, "server-1", exported.LBaaS.Servers[0].Identifier)
+	}
+	assert.Len(t, exported.LBaaS.Backends, 2)
+	assert.Empty(t, exported.VMs)
+}
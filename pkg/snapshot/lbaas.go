@@ -0,0 +1,119 @@
+package snapshot
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/anexia-it/go-anxcloud/pkg/lbaas/backend"
+	"github.com/anexia-it/go-anxcloud/pkg/lbaas/bind"
+	"github.com/anexia-it/go-anxcloud/pkg/lbaas/frontend"
+	"github.com/anexia-it/go-anxcloud/pkg/lbaas/loadbalancer"
+	"github.com/anexia-it/go-anxcloud/pkg/lbaas/server"
+)
+
+func (e *exporter) lbaas() *LBaaSState {
+	loadBalancerAPI := loadbalancer.NewAPI(e.client)
+	frontendAPI := frontend.NewAPI(e.client)
+	backendAPI := backend.NewAPI(e.client)
+	bindAPI := bind.NewAPI(e.client)
+	serverAPI := server.NewAPI(e.client)
+
+	state := LBaaSState{}
+	wg := sync.WaitGroup{}
+	wg.Add(5)
+	go func() {
+		defer wg.Done()
+		state.LoadBalancers = lbaasResources(e, "load balancer", func(page, limit int) ([]string, error) {
+			infos, err := loadBalancerAPI.Get(e.ctx, page, limit)
+			identifiers := make([]string, 0, len(infos))
+			for _, info := range infos {
+				identifiers = append(identifiers, info.Identifier)
+			}
+
+			return identifiers, err
+		}, loadBalancerAPI.GetByID)
+	}()
+	go func() {
+		defer wg.Done()
+		state.Frontends = lbaasResources(e, "frontend", func(page, limit int) ([]string, error) {
+			infos, err := frontendAPI.Get(e.ctx, page, limit)
+			identifiers := make([]string, 0, len(infos))
+			for _, info := range infos {
+				identifiers = append(identifiers, info.Identifier)
+			}
+
+			return identifiers, err
+		}, frontendAPI.GetByID)
+	}()
+	go func() {
+		defer wg.Done()
+		state.Backends = lbaasResources(e, "backend", func(page, limit int) ([]string, error) {
+			infos, err := backendAPI.Get(e.ctx, page, limit)
+			identifiers := make([]string, 0, len(infos))
+			for _, info := range infos {
+				identifiers = append(identifiers, info.Identifier)
+			}
+
+			return identifiers, err
+		}, backendAPI.GetByID)
+	}()
+	go func() {
+		defer wg.Done()
+		state.Binds = lbaasResources(e, "bind", func(page, limit int) ([]string, error) {
+			infos, err := bindAPI.Get(e.ctx, page, limit)
+			identifiers := make([]string, 0, len(infos))
+			for _, info := range infos {
+				identifiers = append(identifiers, info.Identifier)
+			}
+
+			return identifiers, err
+		}, bindAPI.GetByID)
+	}()
+	go func() {
+		defer wg.Done()
+		state.Servers = lbaasResources(e, "server", func(page, limit int) ([]string, error) {
+			infos, err := serverAPI.Get(e.ctx, page, limit)
+			identifiers := make([]string, 0, len(infos))
+			for _, info := range infos {
+				identifiers = append(identifiers, info.Identifier)
+			}
+
+			return identifiers, err
+		}, serverAPI.GetByID)
+	}()
+	wg.Wait()
+
+	return &state
+}
+
+// lbaasResources lists the identifiers of one kind of LBaaS resources using list and fetches each of them using get.
+// Resources that could not be fetched are recorded as failures and left out of the result, which is sorted by identifier.
+func lbaasResources[T any](e *exporter, kind string, list func(page, limit int) ([]string, error),
+	get func(ctx context.Context, identifier string) (T, error)) []T {
+	identifiers, err := e.listAll(list)
+	if err != nil {
+		e.fail(LBaaS, fmt.Errorf("could not list LBaaS %ss: %w", kind, err))
+	}
+	sort.Strings(identifiers)
+
+	fetched := make([]T, len(identifiers))
+	succeeded := e.each(LBaaS, len(identifiers), func(i int) (err error) {
+		fetched[i], err = get(e.ctx, identifiers[i])
+		if err != nil {
+			return fmt.Errorf("could not get LBaaS %s '%s': %w", kind, identifiers[i], err)
+		}
+
+		return nil
+	})
+
+	result := make([]T, 0, len(fetched))
+	for i, ok := range succeeded {
+		if ok {
+			result = append(result, fetched[i])
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,93 @@
+// Package snapshot exports the state of all resources readable by the API into a single serializable snapshot.
+package snapshot
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/anexia-it/go-anxcloud/pkg/clouddns/zone"
+	"github.com/anexia-it/go-anxcloud/pkg/lbaas/backend"
+	"github.com/anexia-it/go-anxcloud/pkg/lbaas/bind"
+	"github.com/anexia-it/go-anxcloud/pkg/lbaas/frontend"
+	"github.com/anexia-it/go-anxcloud/pkg/lbaas/loadbalancer"
+	"github.com/anexia-it/go-anxcloud/pkg/lbaas/server"
+	"github.com/anexia-it/go-anxcloud/pkg/vsphere/vmlist"
+)
+
+// Resource is a type of resource included in a snapshot.
+type Resource string
+
+const (
+	// Zones are CloudDNS zones including their records.
+	Zones Resource = "zones"
+	// LBaaS is the load balancer topology with load balancers, frontends, backends, binds and servers.
+	LBaaS Resource = "lbaas"
+	// VMs are the virtual machines of the account.
+	VMs Resource = "vms"
+)
+
+// AllResources contains all resource types that can be exported.
+var AllResources = []Resource{Zones, LBaaS, VMs}
+
+// Snapshot is the state of the exported resources at the time of the export.
+type Snapshot struct {
+	CreatedAt time.Time   `json:"created_at"`
+	Zones     []Zone      `json:"zones,omitempty"`
+	LBaaS     *LBaaSState `json:"lbaas,omitempty"`
+	VMs       []vmlist.VM `json:"vms,omitempty"`
+}
+
+// Zone is a CloudDNS zone with its records.
+type Zone struct {
+	Zone    zone.Zone     `json:"zone"`
+	Records []zone.Record `json:"records"`
+}
+
+// LBaaSState is the load balancer topology.
+type LBaaSState struct {
+	LoadBalancers []loadbalancer.Loadbalancer `json:"load_balancers"`
+	Frontends     []frontend.Frontend         `json:"frontends"`
+	Backends      []backend.Backend           `json:"backends"`
+	Binds         []bind.Bind                 `json:"binds"`
+	Servers       []server.Server             `json:"servers"`
+}
+
+// WriteJSON writes the snapshot as indented JSON to the given writer.
+func (s Snapshot) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(s); err != nil {
+		return fmt.Errorf("could not encode snapshot: %w", err)
+	}
+
+	return nil
+}
+
+// ExportError is returned by Export if some resources could not be exported.
+// The snapshot returned along with it contains everything else.
+type ExportError struct {
+	// Failed maps the resource types to the errors encountered while exporting them.
+	// Resources of these types that could be read are still included in the snapshot.
+	Failed map[Resource][]error
+}
+
+func (e *ExportError) Error() string {
+	resources := make([]string, 0, len(e.Failed))
+	for resource := range e.Failed {
+		resources = append(resources, string(resource))
+	}
+	sort.Strings(resources)
+
+	messages := make([]string, 0, len(resources))
+	for _, resource := range resources {
+		for _, err := range e.Failed[Resource(resource)] {
+			messages = append(messages, fmt.Sprintf("%s: %v", resource, err))
+		}
+	}
+
+	return fmt.Sprintf("could not export all resources: %s", strings.Join(messages, "; "))
+}
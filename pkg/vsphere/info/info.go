@@ -5,14 +5,20 @@ package info
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+
+	"github.com/anexia-it/go-anxcloud/pkg/client"
 )
 
 const (
 	pathPrefix = "/api/vsphere/v1/info.json"
 )
 
+// ErrNotFound is raised if the VM to query does not exist, e.g. because it was deleted.
+var ErrNotFound = errors.New("VM not found")
+
 // Info contains meta information of a VM.
 type Info struct {
 	Name             string     `json:"name"`
@@ -61,7 +67,7 @@ type Network struct {
 // Get returns additional information to a given VM identifier.
 //
 // ctx is attached to the request and will cancel it on cancelation.
-// identifier is the ID of the VM to query.
+// identifier is the ID of the VM to query. If no such VM exists, an error wrapping ErrNotFound is returned.
 func (a api) Get(ctx context.Context, identifier string) (Info, error) {
 	url := fmt.Sprintf(
 		"%s%s/%s/info",
@@ -76,10 +82,19 @@ func (a api) Get(ctx context.Context, identifier string) (Info, error) {
 	}
 
 	httpResponse, err := a.client.Do(req)
+	var responseError *client.ResponseError
+	if (errors.As(err, &responseError) && responseError.Response.StatusCode == http.StatusNotFound) ||
+		(err == nil && httpResponse.StatusCode == http.StatusNotFound) {
+		if err == nil {
+			_ = httpResponse.Body.Close()
+		}
+		return Info{}, fmt.Errorf("%w: '%s'", ErrNotFound, identifier)
+	}
 	if err != nil {
 		return Info{}, fmt.Errorf("could not execute VM info request: %w", err)
 	}
-	if httpResponse.StatusCode >= 500 && httpResponse.StatusCode < 600 {
+	if httpResponse.StatusCode < http.StatusOK || httpResponse.StatusCode >= http.StatusMultipleChoices {
+		_ = httpResponse.Body.Close()
 		return Info{}, fmt.Errorf("could not execute VM info request, got response %s", httpResponse.Status)
 	}
 
@@ -0,0 +1,39 @@
+package info_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/anexia-it/go-anxcloud/pkg/client"
+	"github.com/anexia-it/go-anxcloud/pkg/vsphere/info"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGet(t *testing.T) {
+	c, server := client.NewTestClient(nil, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/vsphere/v1/info.json/vm/info" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":{"code":404,"message":"not found"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"identifier":"vm","status":"poweredOn","cpu":2,"ram":2048,"location_identifier":"location",` +
+			`"disk_info":[{"disk_id":1,"disk_gb":10}],"network":[{"vlan":"vlan","ips_v4":["10.0.0.1"]}]}`))
+	}))
+	defer server.Close()
+
+	a := info.NewAPI(c)
+	vm, err := a.Get(context.Background(), "vm")
+	if assert.NoError(t, err) {
+		assert.EqualValues(t, "poweredOn", vm.Status)
+		assert.EqualValues(t, 2, vm.CPU)
+		assert.EqualValues(t, 2048, vm.RAM)
+		assert.EqualValues(t, "location", vm.LocationID)
+		assert.Len(t, vm.DiskInfo, 1)
+		assert.EqualValues(t, []string{"10.0.0.1"}, vm.Network[0].IPv4)
+	}
+
+	_, err = a.Get(context.Background(), "deleted")
+	assert.True(t, errors.Is(err, info.ErrNotFound), "expected ErrNotFound but got %v", err)
+}
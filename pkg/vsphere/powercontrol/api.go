@@ -12,6 +12,9 @@ type API interface {
 	// Do not use this, its broken.
 	Set(ctx context.Context, vmIdentifier string, request Request) (Task, error)
 	AwaitCompletion(ctx context.Context, vmID, taskID string) error
+	PowerOn(ctx context.Context, vmIdentifier string) error
+	PowerOff(ctx context.Context, vmIdentifier string, force bool) error
+	Restart(ctx context.Context, vmIdentifier string, force bool) error
 }
 
 type api struct {
@@ -0,0 +1,41 @@
+package powercontrol
+
+import (
+	"context"
+)
+
+// PowerOn requests the VM with the given identifier to be powered on.
+//
+// The operation runs asynchronously, Get reports the power state once it completed.
+func (a api) PowerOn(ctx context.Context, identifier string) error {
+	_, err := a.Set(ctx, identifier, OnRequest)
+	return err
+}
+
+// PowerOff requests the VM with the given identifier to be powered off.
+//
+// Without force, the guest OS is asked to shut down gracefully. With force, the VM is turned off
+// without involving the guest OS, which is needed for hung guests but may lose data.
+// The operation runs asynchronously, Get reports the power state once it completed.
+func (a api) PowerOff(ctx context.Context, identifier string, force bool) error {
+	request := ShutdownRequest
+	if force {
+		request = HardShutdownRequest
+	}
+
+	_, err := a.Set(ctx, identifier, request)
+	return err
+}
+
+// Restart requests the VM with the given identifier to be rebooted.
+//
+// Without force, the guest OS is asked to reboot. With force, the VM is reset without involving the guest OS.
+func (a api) Restart(ctx context.Context, identifier string, force bool) error {
+	request := RebootRequest
+	if force {
+		request = HardRebootRequest
+	}
+
+	_, err := a.Set(ctx, identifier, request)
+	return err
+}
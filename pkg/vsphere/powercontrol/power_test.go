@@ -0,0 +1,41 @@
+package powercontrol_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/anexia-it/go-anxcloud/pkg/client"
+	"github.com/anexia-it/go-anxcloud/pkg/vsphere/powercontrol"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPowerOperations(t *testing.T) {
+	var paths []string
+	c, server := client.NewTestClient(nil, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.EqualValues(t, http.MethodPut, r.Method)
+		paths = append(paths, r.URL.Path)
+		if r.URL.Path == "/api/vsphere/v1/powercontrol.json/missing/on" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":{"code":404,"message":"not found"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"progress":0,"identifier":"vm","task_id":"task"}`))
+	}))
+	defer server.Close()
+
+	a := powercontrol.NewAPI(c)
+	ctx := context.Background()
+	assert.NoError(t, a.PowerOn(ctx, "vm"))
+	assert.NoError(t, a.PowerOff(ctx, "vm", false))
+	assert.NoError(t, a.PowerOff(ctx, "vm", true))
+	assert.NoError(t, a.Restart(ctx, "vm", false))
+	assert.NoError(t, a.Restart(ctx, "vm", true))
+	assert.Error(t, a.PowerOn(ctx, "missing"))
+
+	prefix := "/api/vsphere/v1/powercontrol.json/vm/"
+	assert.EqualValues(t, []string{
+		prefix + "on", prefix + "shutdown", prefix + "hard_shutdown", prefix + "reboot", prefix + "hard_reboot",
+		"/api/vsphere/v1/powercontrol.json/missing/on",
+	}, paths)
+}
@@ -79,7 +79,8 @@ func (a api) Set(ctx context.Context, identifier string, request Request) (Task,
 	if err != nil {
 		return Task{}, fmt.Errorf("could not execute powercontrol set request: %w", err)
 	}
-	if httpResponse.StatusCode >= 500 && httpResponse.StatusCode < 600 {
+	if httpResponse.StatusCode < http.StatusOK || httpResponse.StatusCode >= http.StatusMultipleChoices {
+		_ = httpResponse.Body.Close()
 		return Task{}, fmt.Errorf("could not execute powercontrol set request, got response %s", httpResponse.Status)
 	}
 
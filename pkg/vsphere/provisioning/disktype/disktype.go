@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+
+	"github.com/anexia-it/go-anxcloud/pkg/client"
 )
 
 // DiskType represents a disk type that may be used for VMs.
@@ -21,6 +23,11 @@ const (
 )
 
 func (a api) List(ctx context.Context, locationID string, page, limit int) ([]DiskType, error) {
+	locationID, err := client.ResolveLocation(a.client, locationID)
+	if err != nil {
+		return nil, fmt.Errorf("could not list disk types: %w", err)
+	}
+
 	url := fmt.Sprintf(
 		"%s%s/%s?page=%v&limit=%v",
 		a.client.BaseURL(),
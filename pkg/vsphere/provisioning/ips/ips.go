@@ -7,6 +7,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+
+	"github.com/anexia-it/go-anxcloud/pkg/client"
 )
 
 const (
@@ -25,7 +27,16 @@ type response struct {
 }
 
 // GetFree returns information about the free IPs on a VLAN.
+//
+// The addresses are not reserved, so concurrent provisioners may pick the same one. Use ReserveIP of the
+// ipam/address package to claim an address.
+// If location is empty, the default location of the client is used.
 func (a api) GetFree(ctx context.Context, location, vlan string) ([]IP, error) {
+	location, err := client.ResolveLocation(a.client, location)
+	if err != nil {
+		return nil, fmt.Errorf("could not get free IPs: %w", err)
+	}
+
 	url := fmt.Sprintf(
 		"%s%s/%s/%s",
 		a.client.BaseURL(),
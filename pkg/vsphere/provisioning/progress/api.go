@@ -2,6 +2,7 @@ package progress
 
 import (
 	"context"
+	"time"
 
 	"github.com/anexia-it/go-anxcloud/pkg/client"
 )
@@ -9,7 +10,10 @@ import (
 // API contains methods for progress inquiries.
 type API interface {
 	AwaitCompletion(ctx context.Context, progressID string) (string, error)
+	AwaitCompletionEvery(ctx context.Context, progressID string, poll time.Duration) (string, error)
 	Get(ctx context.Context, identifier string) (Progress, error)
+	Track(ctx context.Context, identifier string) (<-chan Update, error)
+	TrackEvery(ctx context.Context, identifier string, poll time.Duration) (<-chan Update, error)
 }
 
 type api struct {
@@ -0,0 +1,61 @@
+package progress
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/anexia-it/go-anxcloud/pkg/client"
+)
+
+const logsPathSuffix = "logs"
+
+// ErrLogsUnavailable is raised if no logs are available for the given provisioning task.
+var ErrLogsUnavailable = errors.New("no logs available for provisioning task")
+
+// TaskLogs streams the logs of a provisioning task to the given writer.
+//
+// ctx is attached to the request and will cancel it on cancelation.
+// taskID is the identifier of the provisioning task, as returned when provisioning the VM.
+// w receives the log output as it is sent by the API, so logs of running tasks are written incrementally.
+//
+// If the API has no logs for the task, ErrLogsUnavailable is returned.
+func TaskLogs(ctx context.Context, c client.Client, taskID string, w io.Writer) error {
+	url := fmt.Sprintf(
+		"%s%s/%s/%s",
+		c.BaseURL(),
+		pathPrefix,
+		taskID,
+		logsPathSuffix,
+	)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("could not create task logs request: %w", err)
+	}
+
+	httpResponse, err := c.Do(req)
+	var responseError *client.ResponseError
+	if errors.As(err, &responseError) && responseError.Response.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("%w: task '%s'", ErrLogsUnavailable, taskID)
+	}
+	if err != nil {
+		return fmt.Errorf("could not execute task logs request: %w", err)
+	}
+	defer func() { _ = httpResponse.Body.Close() }()
+
+	switch {
+	case httpResponse.StatusCode == http.StatusNoContent:
+		return fmt.Errorf("%w: task '%s'", ErrLogsUnavailable, taskID)
+	case httpResponse.StatusCode >= 500 && httpResponse.StatusCode < 600:
+		return fmt.Errorf("could not execute task logs request, got response %s", httpResponse.Status)
+	}
+
+	if _, err := io.Copy(w, httpResponse.Body); err != nil {
+		return fmt.Errorf("could not stream task logs: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+package progress_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/anexia-it/go-anxcloud/pkg/client"
+	"github.com/anexia-it/go-anxcloud/pkg/vsphere/provisioning/progress"
+	"github.com/stretchr/testify/assert"
+)
+
+// chunkWriter sends every write on chunks.
+type chunkWriter struct {
+	chunks chan string
+}
+
+func (w chunkWriter) Write(p []byte) (int, error) {
+	w.chunks <- string(p)
+	return len(p), nil
+}
+
+func TestTaskLogs(t *testing.T) {
+	t.Run("Streaming", func(t *testing.T) {
+		release := make(chan struct{})
+		c, server := client.NewTestClient(nil, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.EqualValues(t, "/api/vsphere/v1/provisioning/progress.json/task-id/logs", r.URL.Path)
+			fmt.Fprint(w, "cloning template\n")
+			w.(http.Flusher).Flush()
+			<-release
+			fmt.Fprint(w, "powering on\n")
+		}))
+		defer server.Close()
+
+		writer := chunkWriter{make(chan string, 2)}
+		done := make(chan error, 1)
+		go func() { done <- progress.TaskLogs(context.Background(), c, "task-id", writer) }()
+
+		select {
+		case chunk := <-writer.chunks:
+			assert.Equal(t, "cloning template\n", chunk, "logs must be written before the response is complete")
+		case <-time.After(5 * time.Second):
+			t.Fatal("logs were not streamed")
+		}
+		close(release)
+		assert.NoError(t, <-done)
+		assert.Equal(t, "powering on\n", <-writer.chunks)
+	})
+
+	for name, status := range map[string]int{"NotFound": http.StatusNotFound, "NoContent": http.StatusNoContent} {
+		status := status
+		t.Run(name, func(t *testing.T) {
+			c, server := client.NewTestClient(nil, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(status)
+				if status == http.StatusNotFound {
+					_, _ = w.Write([]byte(`{"error":{"code":404,"message":"not found"}}`))
+				}
+			}))
+			defer server.Close()
+
+			logs := bytes.Buffer{}
+			err := progress.TaskLogs(context.Background(), c, "task-id", &logs)
+			assert.True(t, errors.Is(err, progress.ErrLogsUnavailable), "expected ErrLogsUnavailable but got %v", err)
+			assert.Empty(t, logs.String())
+		})
+	}
+
+	t.Run("ServerError", func(t *testing.T) {
+		c, server := client.NewTestClient(nil, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		err := progress.TaskLogs(context.Background(), c, "task-id", &bytes.Buffer{})
+		assert.Error(t, err)
+		assert.False(t, errors.Is(err, progress.ErrLogsUnavailable))
+	})
+}
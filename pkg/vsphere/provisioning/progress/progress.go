@@ -89,27 +89,46 @@ func (a api) Get(ctx context.Context, identifier string) (Progress, error) {
 // progressID identifies the running provisioning task and is contained within ProvisioningResponse.
 //
 // Returned will be the VM ID and an error if polling or ProvisioningError if provisioning failed.
+// The task is polled every 5 seconds, use AwaitCompletionEvery to poll at another interval.
 func (a api) AwaitCompletion(ctx context.Context, progressID string) (string, error) {
-	ticker := time.NewTicker(pollInterval)
-	defer ticker.Stop()
+	return a.AwaitCompletionEvery(ctx, progressID, pollInterval)
+}
+
+// AwaitCompletionEvery works like AwaitCompletion, but polls the task immediately and then every poll interval.
+//
+// A failed task is reported with an error wrapping ErrProgress. If ctx is done before the task completed,
+// the error wraps the error of ctx and contains the last progress seen, so an incomplete task can be told
+// apart from a failed one.
+func (a api) AwaitCompletionEvery(ctx context.Context, progressID string, poll time.Duration) (string, error) {
+	if poll <= 0 {
+		poll = pollInterval
+	}
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
 	var responseError *client.ResponseError
+	last := Progress{}
 	for {
 		select {
-		case <-ticker.C:
+		case <-timer.C:
 			progressResponse, err := a.Get(ctx, progressID)
-			isProvisioningError := errors.As(err, &responseError)
 			switch {
-			case isProvisioningError && responseError.Response.StatusCode == 404:
+			case errors.As(err, &responseError) && responseError.Response.StatusCode == http.StatusNotFound:
 				return "", fmt.Errorf("could not get progress. Endpoint returned 404: %w", err)
+			case errors.Is(err, ErrProgress):
+				return "", fmt.Errorf("provisioning task '%s' failed: %w", progressID, err)
+			case err != nil && ctx.Err() == nil:
+				return "", fmt.Errorf("could not query provision progress: %w", err)
 			case err == nil:
 				if progressResponse.Progress == progressCompleteValue {
 					return progressResponse.VMIdentifier, nil
 				}
-			default:
-				return "", fmt.Errorf("could not query provision progress: %w", err)
+				last = progressResponse
 			}
+			timer.Reset(poll)
 		case <-ctx.Done():
-			return "", fmt.Errorf("vm did not get ready in time: %w", ctx.Err())
+			return "", fmt.Errorf("vm did not get ready in time, provisioning task '%s' at %d%%: %w", progressID,
+				last.Progress, ctx.Err())
 		}
 	}
 }
@@ -0,0 +1,57 @@
+package progress_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/anexia-it/go-anxcloud/pkg/client"
+	"github.com/anexia-it/go-anxcloud/pkg/vsphere/provisioning/progress"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAwaitCompletionEvery(t *testing.T) {
+	t.Run("Completed", func(t *testing.T) {
+		c, server := client.NewTestClient(nil, newProgressMock(
+			`{"identifier":"task-id","queued":true,"progress":0}`,
+			`{"identifier":"task-id","progress":50}`,
+			`{"identifier":"task-id","progress":100,"vm_identifier":"vm-id"}`,
+		))
+		defer server.Close()
+
+		vmID, err := progress.NewAPI(c).AwaitCompletionEvery(context.Background(), "task-id", time.Millisecond)
+		assert.NoError(t, err)
+		assert.EqualValues(t, "vm-id", vmID)
+	})
+
+	t.Run("Failed", func(t *testing.T) {
+		c, server := client.NewTestClient(nil, newProgressMock(`{"identifier":"task-id","progress":20,"errors":["disk full"]}`))
+		defer server.Close()
+
+		_, err := progress.NewAPI(c).AwaitCompletionEvery(context.Background(), "task-id", time.Millisecond)
+		assert.True(t, errors.Is(err, progress.ErrProgress), "expected ErrProgress but got %v", err)
+	})
+
+	t.Run("Incomplete", func(t *testing.T) {
+		c, server := client.NewTestClient(nil, newProgressMock(`{"identifier":"task-id","progress":40}`))
+		defer server.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		_, err := progress.NewAPI(c).AwaitCompletionEvery(ctx, "task-id", time.Millisecond)
+		assert.True(t, errors.Is(err, context.DeadlineExceeded), "expected context.DeadlineExceeded but got %v", err)
+		assert.False(t, errors.Is(err, progress.ErrProgress))
+		if err != nil {
+			assert.Contains(t, err.Error(), "40%")
+		}
+	})
+
+	t.Run("UnknownTask", func(t *testing.T) {
+		c, server := client.NewTestClient(nil, newProgressMock(`{}`))
+		defer server.Close()
+
+		_, err := progress.NewAPI(c).AwaitCompletionEvery(context.Background(), "unknown", time.Millisecond)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,117 @@
+package progress
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// State is the state of a provisioning task.
+type State string
+
+const (
+	// StateQueued indicates that the task waits to be started.
+	StateQueued State = "queued"
+	// StateRunning indicates that the task is in progress.
+	StateRunning State = "running"
+	// StateCompleted indicates that the task finished successfully.
+	StateCompleted State = "completed"
+	// StateFailed indicates that the task finished with errors.
+	StateFailed State = "failed"
+)
+
+// Terminal returns true if the task will not change its state anymore.
+func (s State) Terminal() bool {
+	return s == StateCompleted || s == StateFailed
+}
+
+// Update is a state change of a tracked provisioning task.
+type Update struct {
+	// State of the task.
+	State State
+	// Progress is the last progress response received.
+	Progress Progress
+	// Err is set if the task failed or could not be tracked further. It is always the last update.
+	Err error
+}
+
+func stateOf(progress Progress, err error) State {
+	switch {
+	case errors.Is(err, ErrProgress):
+		return StateFailed
+	case progress.Progress == progressCompleteValue:
+		return StateCompleted
+	case progress.Queued:
+		return StateQueued
+	default:
+		return StateRunning
+	}
+}
+
+// Track polls the given provisioning task and sends an update on the returned channel whenever its state
+// or progress changes. The channel is closed after the task reached a terminal state, an error occurred
+// or ctx is done; errors are delivered as the last update. The channel has to be read until it is closed.
+//
+// The task is only identified by its identifier, so a task started by an earlier process can be tracked
+// again by persisting the identifier returned on provisioning.
+//
+// ctx will be checked for cancellation and tracking stops if so.
+// identifier is the ID of the provisioning task to track.
+//
+// An error is returned immediately if the task can not be queried.
+// The task is polled every 5 seconds, use TrackEvery to poll at another interval.
+func (a api) Track(ctx context.Context, identifier string) (<-chan Update, error) {
+	return a.TrackEvery(ctx, identifier, pollInterval)
+}
+
+// TrackEvery works like Track, but polls the task every poll interval.
+func (a api) TrackEvery(ctx context.Context, identifier string, poll time.Duration) (<-chan Update, error) {
+	if poll <= 0 {
+		poll = pollInterval
+	}
+
+	progress, err := a.Get(ctx, identifier)
+	if err != nil && !errors.Is(err, ErrProgress) {
+		return nil, err
+	}
+
+	updates := make(chan Update, 1)
+	go func() {
+		defer close(updates)
+
+		ticker := time.NewTicker(poll)
+		defer ticker.Stop()
+		last := Update{}
+		for {
+			update := Update{State: stateOf(progress, err), Progress: progress, Err: err}
+			if err != nil && !errors.Is(err, ErrProgress) {
+				update = Update{State: last.State, Progress: last.Progress, Err: fmt.Errorf("could not query provision progress: %w", err)}
+			}
+
+			if update.Err != nil || update.State.Terminal() {
+				updates <- update
+				return
+			}
+			if update.State != last.State || update.Progress.Progress != last.Progress.Progress {
+				select {
+				case updates <- update:
+				case <-ctx.Done():
+					updates <- Update{State: last.State, Progress: last.Progress, Err: ctx.Err()}
+					return
+				}
+				last = update
+			}
+
+			select {
+			case <-ticker.C:
+				progress, err = a.Get(ctx, identifier)
+			case <-ctx.Done():
+				updates <- Update{State: last.State, Progress: last.Progress, Err: ctx.Err()}
+				return
+			}
+		}
+	}()
+
+	return updates, nil
+}
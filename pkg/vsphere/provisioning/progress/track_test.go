@@ -0,0 +1,115 @@
+package progress_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/anexia-it/go-anxcloud/pkg/client"
+	"github.com/anexia-it/go-anxcloud/pkg/vsphere/provisioning/progress"
+	"github.com/stretchr/testify/assert"
+)
+
+// newProgressMock serves the given progress responses of task "task-id" in order, repeating the last one.
+func newProgressMock(responses ...string) http.Handler {
+	var mu sync.Mutex
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/vsphere/v1/provisioning/progress.json/task-id" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":{"code":404,"message":"not found"}}`))
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		fmt.Fprint(w, responses[0])
+		if len(responses) > 1 {
+			responses = responses[1:]
+		}
+	})
+}
+
+func collectUpdates(updates <-chan progress.Update) []progress.Update {
+	collected := make([]progress.Update, 0)
+	for update := range updates {
+		collected = append(collected, update)
+	}
+	return collected
+}
+
+func TestTrackEvery(t *testing.T) {
+	t.Run("Completed", func(t *testing.T) {
+		c, server := client.NewTestClient(nil, newProgressMock(
+			`{"identifier":"task-id","queued":true,"progress":0}`,
+			`{"identifier":"task-id","queued":true,"progress":0}`,
+			`{"identifier":"task-id","progress":50}`,
+			`{"identifier":"task-id","progress":100,"vm_identifier":"vm-id"}`,
+		))
+		defer server.Close()
+
+		updates, err := progress.NewAPI(c).TrackEvery(context.Background(), "task-id", time.Millisecond)
+		if !assert.NoError(t, err) {
+			return
+		}
+		collected := collectUpdates(updates)
+		if assert.Len(t, collected, 3, "unchanged progress must not be reported") {
+			assert.Equal(t, progress.StateQueued, collected[0].State)
+			assert.Equal(t, progress.StateRunning, collected[1].State)
+			assert.EqualValues(t, 50, collected[1].Progress.Progress)
+			assert.Equal(t, progress.StateCompleted, collected[2].State)
+			assert.EqualValues(t, "vm-id", collected[2].Progress.VMIdentifier)
+			assert.NoError(t, collected[2].Err)
+		}
+	})
+
+	t.Run("Failed", func(t *testing.T) {
+		c, server := client.NewTestClient(nil, newProgressMock(
+			`{"identifier":"task-id","progress":20}`,
+			`{"identifier":"task-id","progress":20,"errors":["disk full"]}`,
+		))
+		defer server.Close()
+
+		updates, err := progress.NewAPI(c).TrackEvery(context.Background(), "task-id", time.Millisecond)
+		if !assert.NoError(t, err) {
+			return
+		}
+		collected := collectUpdates(updates)
+		if assert.Len(t, collected, 2) {
+			assert.Equal(t, progress.StateRunning, collected[0].State)
+			assert.Equal(t, progress.StateFailed, collected[1].State)
+			assert.True(t, collected[1].State.Terminal())
+			assert.True(t, errors.Is(collected[1].Err, progress.ErrProgress), "expected ErrProgress but got %v", collected[1].Err)
+		}
+	})
+
+	t.Run("Cancelled", func(t *testing.T) {
+		c, server := client.NewTestClient(nil, newProgressMock(`{"identifier":"task-id","progress":40}`))
+		defer server.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		updates, err := progress.NewAPI(c).TrackEvery(ctx, "task-id", time.Millisecond)
+		if !assert.NoError(t, err) {
+			return
+		}
+		collected := collectUpdates(updates)
+		if assert.NotEmpty(t, collected) {
+			last := collected[len(collected)-1]
+			assert.False(t, last.State.Terminal())
+			assert.EqualValues(t, 40, last.Progress.Progress)
+			assert.True(t, errors.Is(last.Err, context.DeadlineExceeded), "expected DeadlineExceeded but got %v", last.Err)
+		}
+	})
+
+	t.Run("UnknownTask", func(t *testing.T) {
+		c, server := client.NewTestClient(nil, newProgressMock(`{}`))
+		defer server.Close()
+
+		_, err := progress.NewAPI(c).TrackEvery(context.Background(), "unknown", time.Millisecond)
+		var responseErr *client.ResponseError
+		assert.True(t, errors.As(err, &responseErr), "expected ResponseError but got %v", err)
+	})
+}
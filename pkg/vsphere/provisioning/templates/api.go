@@ -9,6 +9,7 @@ import (
 // API contains methods for template querying.
 type API interface {
 	List(ctx context.Context, locationID string, templateType string, page, limit int) ([]Template, error)
+	ListAll(ctx context.Context, locationID string, templateType string) ([]Template, error)
 }
 
 type api struct {
@@ -7,6 +7,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
+
+	"github.com/anexia-it/go-anxcloud/pkg/client"
 )
 
 // StringParameter is a string parameter for a template.
@@ -85,9 +88,40 @@ const (
 	// TemplateTypeFromScratch are templates that need to have distribution added to work.
 	TemplateTypeFromScratch string = "from_scratch"
 	pathPrefix              string = "/api/vsphere/v1/provisioning/templates.json"
+	listAllPageSize                = 100
 )
 
+// OSFamily returns the operating system family of the template, the first word of its name in lower case,
+// e.g. "debian" for "Debian 11".
+func (t Template) OSFamily() string {
+	family, _ := t.splitName()
+
+	return strings.ToLower(family)
+}
+
+// Version returns the operating system version of the template, the remainder of its name after the
+// family, e.g. "11" for "Debian 11". It is empty if the name has a single word.
+func (t Template) Version() string {
+	_, version := t.splitName()
+
+	return version
+}
+
+func (t Template) splitName() (string, string) {
+	fields := strings.Fields(t.Name)
+	if len(fields) == 0 {
+		return "", ""
+	}
+
+	return fields[0], strings.Join(fields[1:], " ")
+}
+
 func (a api) List(ctx context.Context, locationID string, templateType string, page, limit int) ([]Template, error) {
+	locationID, err := client.ResolveLocation(a.client, locationID)
+	if err != nil {
+		return nil, fmt.Errorf("could not list templates: %w", err)
+	}
+
 	url := fmt.Sprintf(
 		"%s%s/%s/%s?page=%v&limit=%v",
 		a.client.BaseURL(),
@@ -117,3 +151,21 @@ func (a api) List(ctx context.Context, locationID string, templateType string, p
 
 	return responsePayload, err
 }
+
+// ListAll returns all templates of the given type available at the location, fetching all pages.
+//
+// templateType is TemplateTypeTemplates or TemplateTypeFromScratch. If locationID is empty, the default
+// location of the client is used.
+func (a api) ListAll(ctx context.Context, locationID string, templateType string) ([]Template, error) {
+	var all []Template
+	for page := 1; ; page++ {
+		templates, err := a.List(ctx, locationID, templateType, page, listAllPageSize)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, templates...)
+		if len(templates) < listAllPageSize {
+			return all, nil
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package templates_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/anexia-it/go-anxcloud/pkg/client"
+	"github.com/anexia-it/go-anxcloud/pkg/vsphere/provisioning/templates"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListAll(t *testing.T) {
+	const total = 150
+	c, server := client.NewTestClient(nil, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.EqualValues(t, "/api/vsphere/v1/provisioning/templates.json/location-id/templates", r.URL.Path)
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+		list := []templates.Template{}
+		for i := (page - 1) * limit; i < page*limit && i < total; i++ {
+			list = append(list, templates.Template{ID: fmt.Sprintf("template-%d", i), Name: "Debian 11"})
+		}
+		_ = json.NewEncoder(w).Encode(list)
+	}))
+	defer server.Close()
+
+	list, err := templates.NewAPI(c).ListAll(context.Background(), "location-id", templates.TemplateTypeTemplates)
+	assert.NoError(t, err)
+	assert.Len(t, list, total)
+	assert.EqualValues(t, "template-149", list[total-1].ID)
+}
+
+func TestTemplateOS(t *testing.T) {
+	template := templates.Template{Name: "Windows Server 2019"}
+	assert.EqualValues(t, "windows", template.OSFamily())
+	assert.EqualValues(t, "Server 2019", template.Version())
+
+	template = templates.Template{Name: "Flatcar"}
+	assert.EqualValues(t, "flatcar", template.OSFamily())
+	assert.EqualValues(t, "", template.Version())
+}
+
+func TestListDefaultLocation(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.EqualValues(t, "/api/vsphere/v1/provisioning/templates.json/default-location/templates", r.URL.Path)
+		_ = json.NewEncoder(w).Encode([]templates.Template{{ID: "template-id"}})
+	})
+	c, server := client.NewTestClient(nil, handler)
+	defer server.Close()
+	withDefault, err := client.New(client.TokenFromString("token"), client.WithDefaultLocation("default-location"))
+	if !assert.NoError(t, err) {
+		return
+	}
+	defaulted, defaultedServer := client.NewTestClient(withDefault, handler)
+	defer defaultedServer.Close()
+
+	list, err := templates.NewAPI(defaulted).List(context.Background(), "", templates.TemplateTypeTemplates, 1, 10)
+	if assert.NoError(t, err) && assert.Len(t, list, 1) {
+		assert.EqualValues(t, "template-id", list[0].ID)
+	}
+
+	_, err = templates.NewAPI(c).List(context.Background(), "", templates.TemplateTypeTemplates, 1, 10)
+	assert.True(t, errors.Is(err, client.ErrNoLocation), "expected ErrNoLocation but got %v", err)
+}
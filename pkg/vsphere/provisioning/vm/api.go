@@ -9,9 +9,13 @@ import (
 // API contains methods for VM provisioning.
 type API interface {
 	NewDefinition(location, templateType, templateID, hostname string, cpus, memory, disk int, network []Network) Definition
-	Deprovision(ctx context.Context, identifier string, delayed bool) error
+	Deprovision(ctx context.Context, identifier string, delayed bool, opts ...DeprovisionOption) (DeprovisionResponse, error)
 	Provision(ctx context.Context, definition Definition, base64Encoding bool) (ProvisioningResponse, error)
 	Update(ctx context.Context, vmID string, change Change) (ProvisioningResponse, error)
+	AddDisk(ctx context.Context, vmID string, sizeGBs int, diskType string) (ProvisioningResponse, error)
+	ResizeDisk(ctx context.Context, vmID string, diskID int, newSizeGBs int) (ProvisioningResponse, error)
+	AddNetworkInterface(ctx context.Context, vmID, vlan, ip string) (ProvisioningResponse, error)
+	RemoveNetworkInterface(ctx context.Context, vmID string, nicID int) (ProvisioningResponse, error)
 }
 
 type api struct {
@@ -1,5 +1,29 @@
 package vm
 
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidDefinition is raised if a VM definition lacks required values.
+var ErrInvalidDefinition = errors.New("invalid VM definition")
+
+// DefinitionError is returned by Definition.Validate, listing the fields that are missing or invalid.
+type DefinitionError struct {
+	// Fields names the invalid fields, e.g. "Hostname" or "Network[0].VLAN".
+	Fields []string
+}
+
+func (e *DefinitionError) Error() string {
+	return fmt.Sprintf("%v: missing or invalid %s", ErrInvalidDefinition, strings.Join(e.Fields, ", "))
+}
+
+// Is reports whether target is ErrInvalidDefinition.
+func (e *DefinitionError) Is(target error) bool {
+	return target == ErrInvalidDefinition
+}
+
 // Definition states the configuration of a VM within the anxcloud.
 type Definition struct {
 	Location     string `json:"-"`
@@ -103,6 +127,41 @@ type Network struct {
 	IPs []string `json:"ips,omitempty"`
 }
 
+// Validate checks that all required values of the definition are set, returning a *DefinitionError if not.
+// The location is not checked, as Provision falls back to the default location of the client.
+func (d Definition) Validate() error {
+	var fields []string
+	if d.TemplateType == "" {
+		fields = append(fields, "TemplateType")
+	}
+	if d.TemplateID == "" {
+		fields = append(fields, "TemplateID")
+	}
+	if d.Hostname == "" {
+		fields = append(fields, "Hostname")
+	}
+	if d.CPUs < 1 {
+		fields = append(fields, "CPUs")
+	}
+	if d.Memory < 1 {
+		fields = append(fields, "Memory")
+	}
+	if d.Disk < 1 {
+		fields = append(fields, "Disk")
+	}
+	for i, network := range d.Network {
+		if network.VLAN == "" {
+			fields = append(fields, fmt.Sprintf("Network[%d].VLAN", i))
+		}
+	}
+
+	if len(fields) > 0 {
+		return &DefinitionError{Fields: fields}
+	}
+
+	return nil
+}
+
 // NewDefinition create a VM definition with the mandatory values set.
 func (a api) NewDefinition(location, templateType, templateID, hostname string, cpus, memory, disk int, network []Network) Definition {
 	return Definition{
@@ -136,6 +195,7 @@ type Change struct {
 	AddDisks           []Disk    `json:"disk_to_add,omitempty"`
 	ChangeDisks        []Disk    `json:"disk_to_change,omitempty"`
 	AddNICs            []Network `json:"network_to_add,omitempty"`
+	DeleteNICIDs       []int     `json:"network_to_delete,omitempty"`
 	BootDelaySecs      int       `json:"boot_delay,omitempty"`
 	EnterBIOSSetup     bool      `json:"enter_bios_setup,omitempty"`
 	Reboot             bool      `json:"force_restart_if_needed,omitempty"`
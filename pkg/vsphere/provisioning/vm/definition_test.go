@@ -0,0 +1,34 @@
+package vm_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/anexia-it/go-anxcloud/pkg/client"
+	"github.com/anexia-it/go-anxcloud/pkg/vsphere/provisioning/vm"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProvisionValidatesDefinition(t *testing.T) {
+	requests := 0
+	c, server := client.NewTestClient(nil, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	}))
+	defer server.Close()
+
+	a := vm.NewAPI(c)
+	definition := a.NewDefinition("location", "templates", "template", "", 2, 0, 10, []vm.Network{{IPs: []string{"10.0.0.1"}}})
+	_, err := a.Provision(context.Background(), definition, false)
+
+	var definitionErr *vm.DefinitionError
+	if assert.True(t, errors.As(err, &definitionErr), "expected DefinitionError but got %v", err) {
+		assert.EqualValues(t, []string{"Hostname", "Memory", "Network[0].VLAN"}, definitionErr.Fields)
+	}
+	assert.True(t, errors.Is(err, vm.ErrInvalidDefinition), "expected ErrInvalidDefinition but got %v", err)
+	assert.EqualValues(t, 0, requests)
+
+	definition = a.NewDefinition("location", "templates", "template", "web-01", 2, 2048, 10, []vm.Network{{VLAN: "vlan"}})
+	assert.NoError(t, definition.Validate())
+}
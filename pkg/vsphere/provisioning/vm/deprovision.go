@@ -2,10 +2,35 @@ package vm
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/anexia-it/go-anxcloud/pkg/client"
 )
 
+// ErrAlreadyDeleted is raised if the VM to deprovision does not exist, e.g. because it was deleted before.
+var ErrAlreadyDeleted = errors.New("VM does not exist or was already deleted")
+
+// DeprovisionResponse contains information returned by the API regarding a VM being deleted.
+type DeprovisionResponse struct {
+	// Identifier of the deprovisioning task, to be passed to the progress API.
+	Identifier string `json:"identifier"`
+}
+
+// DeprovisionOption is an optional parameter for Deprovision.
+type DeprovisionOption func(query url.Values)
+
+// Force lets Deprovision delete the VM even if it is powered on.
+func Force() DeprovisionOption {
+	return func(query url.Values) {
+		query.Set("force", "true")
+	}
+}
+
 // Deprovision issues a request to deprovision an existing VM using.
 //
 // ctx is attached to the request and will cancel it on cancelation.
@@ -13,35 +38,54 @@ import (
 // identifier is the VM identifier string returned when querying the
 // provisioning task which ID was returned on VM provisioning.
 // delayed indicated that the VM shall be removed with a delay of 24h.
+// Pass Force to delete VMs that are still powered on.
 //
-// If the API returns errors, they are raised as ResponseError error.
-func (a api) Deprovision(ctx context.Context, identifier string, delayed bool) error {
-	url := fmt.Sprintf(
-		"%s%s/%s?delayed=%t",
+// If the VM does not exist, an error wrapping ErrAlreadyDeleted is returned, which cleanup loops can ignore.
+// Other errors returned by the API are raised as ResponseError error.
+func (a api) Deprovision(ctx context.Context, identifier string, delayed bool, opts ...DeprovisionOption) (DeprovisionResponse, error) {
+	query := url.Values{}
+	query.Set("delayed", strconv.FormatBool(delayed))
+	for _, opt := range opts {
+		opt(query)
+	}
+	endpoint := fmt.Sprintf(
+		"%s%s/%s?%s",
 		a.client.BaseURL(),
 		pathPrefix,
 		identifier,
-		delayed,
+		query.Encode(),
 	)
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, url, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, endpoint, nil)
 	if err != nil {
-		return fmt.Errorf("could not create VM deprovisioning request: %w", err)
+		return DeprovisionResponse{}, fmt.Errorf("could not create VM deprovisioning request: %w", err)
 	}
 
 	httpResponse, err := a.client.Do(req)
+	var responseError *client.ResponseError
+	if (errors.As(err, &responseError) && responseError.Response.StatusCode == http.StatusNotFound) ||
+		(err == nil && httpResponse.StatusCode == http.StatusNotFound) {
+		if err == nil {
+			_ = httpResponse.Body.Close()
+		}
+		return DeprovisionResponse{}, fmt.Errorf("%w: '%s'", ErrAlreadyDeleted, identifier)
+	}
 	if err != nil {
-		return fmt.Errorf("could not execute VM deprovisioning request: %w", err)
+		return DeprovisionResponse{}, fmt.Errorf("could not execute VM deprovisioning request: %w", err)
 	}
-	if httpResponse.StatusCode >= 500 && httpResponse.StatusCode < 600 {
-		return fmt.Errorf("could not execute VM deprovisioning request, got response %s", httpResponse.Status)
+	defer func() { _ = httpResponse.Body.Close() }()
+	if httpResponse.StatusCode < http.StatusOK || httpResponse.StatusCode >= http.StatusMultipleChoices {
+		return DeprovisionResponse{}, fmt.Errorf("could not execute VM deprovisioning request, got response %s",
+			httpResponse.Status)
 	}
 
-	_ = httpResponse.Body.Close()
-
-	if err != nil {
-		return fmt.Errorf("could not decode VM deprovisioning response: %w", err)
+	var response DeprovisionResponse
+	if httpResponse.StatusCode == http.StatusNoContent {
+		return response, nil
+	}
+	if err := json.NewDecoder(httpResponse.Body).Decode(&response); err != nil {
+		return DeprovisionResponse{}, fmt.Errorf("could not decode VM deprovisioning response: %w", err)
 	}
 
-	return err
+	return response, nil
 }
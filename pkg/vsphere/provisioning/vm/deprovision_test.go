@@ -0,0 +1,38 @@
+package vm_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/anexia-it/go-anxcloud/pkg/client"
+	"github.com/anexia-it/go-anxcloud/pkg/vsphere/provisioning/vm"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeprovision(t *testing.T) {
+	c, server := client.NewTestClient(nil, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.EqualValues(t, http.MethodDelete, r.Method)
+		switch r.URL.Path {
+		case "/api/vsphere/v1/provisioning/vm.json/vm-id":
+			_, _ = w.Write([]byte(`{"identifier":"task-` + r.URL.Query().Get("delayed") + `-` + r.URL.Query().Get("force") + `"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":{"code":404,"message":"not found"}}`))
+		}
+	}))
+	defer server.Close()
+	a := vm.NewAPI(c)
+
+	response, err := a.Deprovision(context.Background(), "vm-id", false)
+	assert.NoError(t, err)
+	assert.EqualValues(t, "task-false-", response.Identifier)
+
+	response, err = a.Deprovision(context.Background(), "vm-id", true, vm.Force())
+	assert.NoError(t, err)
+	assert.EqualValues(t, "task-true-true", response.Identifier)
+
+	_, err = a.Deprovision(context.Background(), "deleted", false)
+	assert.True(t, errors.Is(err, vm.ErrAlreadyDeleted), "expected ErrAlreadyDeleted but got %v", err)
+}
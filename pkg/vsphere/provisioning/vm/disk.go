@@ -0,0 +1,63 @@
+package vm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/anexia-it/go-anxcloud/pkg/vsphere/info"
+)
+
+var (
+	// ErrDiskShrink is raised if a disk is to be resized to less than its current size, which is not supported.
+	ErrDiskShrink = errors.New("disks cannot be shrunk")
+	// ErrDiskNotFound is raised if the disk to resize is not attached to the VM.
+	ErrDiskNotFound = errors.New("disk not found")
+)
+
+// AddDisk adds a new disk of sizeGBs and the given disk type to the VM.
+//
+// If diskType is empty, DefaultDiskType is used. Disk changes are asynchronous, the Identifier of the
+// returned response can be passed to the progress API to await them.
+func (a api) AddDisk(ctx context.Context, vmID string, sizeGBs int, diskType string) (ProvisioningResponse, error) {
+	if sizeGBs < 1 {
+		return ProvisioningResponse{}, fmt.Errorf("disk size must be at least 1 GB, got %d", sizeGBs)
+	}
+	if diskType == "" {
+		diskType = DefaultDiskType
+	}
+
+	change := NewChange()
+	change.AddDisks = []Disk{{Type: diskType, SizeGBs: sizeGBs}}
+
+	return a.Update(ctx, vmID, change)
+}
+
+// ResizeDisk grows the disk diskID of the VM to newSizeGBs, keeping its disk type.
+//
+// The current size of the disk is queried first, an error wrapping ErrDiskShrink is returned if newSizeGBs is
+// smaller and one wrapping ErrDiskNotFound if the VM has no such disk. Disk changes are asynchronous, the
+// Identifier of the returned response can be passed to the progress API to await them.
+func (a api) ResizeDisk(ctx context.Context, vmID string, diskID int, newSizeGBs int) (ProvisioningResponse, error) {
+	vmInfo, err := info.NewAPI(a.client).Get(ctx, vmID)
+	if err != nil {
+		return ProvisioningResponse{}, fmt.Errorf("could not query disks of VM '%s': %w", vmID, err)
+	}
+
+	for _, disk := range vmInfo.DiskInfo {
+		if disk.DiskID != diskID {
+			continue
+		}
+		if float64(newSizeGBs) < disk.DiskGB {
+			return ProvisioningResponse{}, fmt.Errorf("%w: disk %d of VM '%s' has %v GB, requested %d GB",
+				ErrDiskShrink, diskID, vmID, disk.DiskGB, newSizeGBs)
+		}
+
+		change := NewChange()
+		change.ChangeDisks = []Disk{{ID: diskID, Type: disk.DiskType, SizeGBs: newSizeGBs}}
+
+		return a.Update(ctx, vmID, change)
+	}
+
+	return ProvisioningResponse{}, fmt.Errorf("%w: VM '%s' has no disk %d", ErrDiskNotFound, vmID, diskID)
+}
@@ -0,0 +1,53 @@
+package vm_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/anexia-it/go-anxcloud/pkg/client"
+	"github.com/anexia-it/go-anxcloud/pkg/vsphere/provisioning/vm"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDisks(t *testing.T) {
+	var changes []vm.Change
+	c, server := client.NewTestClient(nil, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/vsphere/v1/info.json/vm-id/info":
+			_, _ = w.Write([]byte(`{"identifier":"vm-id","disk_info":[{"disk_id":2000,"disk_gb":20,"disk_type":"STD4"}]}`))
+		case r.Method == http.MethodPut && r.URL.Path == "/api/vsphere/v1/provisioning/vm.json/vm-id":
+			var change vm.Change
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&change))
+			changes = append(changes, change)
+			_, _ = w.Write([]byte(`{"identifier":"task-id","queued":true}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":{"code":404,"message":"not found"}}`))
+		}
+	}))
+	defer server.Close()
+	a := vm.NewAPI(c)
+
+	response, err := a.AddDisk(context.Background(), "vm-id", 50, "")
+	assert.NoError(t, err)
+	assert.EqualValues(t, "task-id", response.Identifier)
+
+	response, err = a.ResizeDisk(context.Background(), "vm-id", 2000, 30)
+	assert.NoError(t, err)
+	assert.EqualValues(t, "task-id", response.Identifier)
+
+	if assert.Len(t, changes, 2) {
+		assert.EqualValues(t, []vm.Disk{{Type: vm.DefaultDiskType, SizeGBs: 50}}, changes[0].AddDisks)
+		assert.EqualValues(t, []vm.Disk{{ID: 2000, Type: "STD4", SizeGBs: 30}}, changes[1].ChangeDisks)
+	}
+
+	_, err = a.ResizeDisk(context.Background(), "vm-id", 2000, 10)
+	assert.True(t, errors.Is(err, vm.ErrDiskShrink), "expected ErrDiskShrink but got %v", err)
+
+	_, err = a.ResizeDisk(context.Background(), "vm-id", 2001, 30)
+	assert.True(t, errors.Is(err, vm.ErrDiskNotFound), "expected ErrDiskNotFound but got %v", err)
+	assert.Len(t, changes, 2)
+}
@@ -0,0 +1,42 @@
+package vm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidNetwork is raised if a network interface to add lacks required values.
+var ErrInvalidNetwork = errors.New("invalid network interface")
+
+// AddNetworkInterface attaches a new network interface of DefaultNICType in the given VLAN to the VM.
+//
+// ip is the identifier or address of a reserved IP to assign to the interface. If it is empty, the engine
+// assigns a free IP of the VLAN. Network changes are asynchronous, the Identifier of the returned response
+// can be passed to the progress API to await them.
+func (a api) AddNetworkInterface(ctx context.Context, vmID, vlan, ip string) (ProvisioningResponse, error) {
+	if vlan == "" {
+		return ProvisioningResponse{}, fmt.Errorf("%w: missing VLAN", ErrInvalidNetwork)
+	}
+
+	network := Network{NICType: DefaultNICType, VLAN: vlan}
+	if ip != "" {
+		network.IPs = []string{ip}
+	}
+
+	change := NewChange()
+	change.AddNICs = []Network{network}
+
+	return a.Update(ctx, vmID, change)
+}
+
+// RemoveNetworkInterface detaches the network interface nicID, as listed by the info API, from the VM.
+//
+// Network changes are asynchronous, the Identifier of the returned response can be passed to the progress
+// API to await them.
+func (a api) RemoveNetworkInterface(ctx context.Context, vmID string, nicID int) (ProvisioningResponse, error) {
+	change := NewChange()
+	change.DeleteNICIDs = []int{nicID}
+
+	return a.Update(ctx, vmID, change)
+}
@@ -0,0 +1,46 @@
+package vm_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/anexia-it/go-anxcloud/pkg/client"
+	"github.com/anexia-it/go-anxcloud/pkg/vsphere/provisioning/vm"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNetworkInterfaces(t *testing.T) {
+	var changes []vm.Change
+	c, server := client.NewTestClient(nil, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.EqualValues(t, http.MethodPut, r.Method)
+		assert.EqualValues(t, "/api/vsphere/v1/provisioning/vm.json/vm-id", r.URL.Path)
+		var change vm.Change
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&change))
+		changes = append(changes, change)
+		_, _ = w.Write([]byte(`{"identifier":"task-id","queued":true}`))
+	}))
+	defer server.Close()
+	a := vm.NewAPI(c)
+
+	response, err := a.AddNetworkInterface(context.Background(), "vm-id", "vlan-id", "")
+	assert.NoError(t, err)
+	assert.EqualValues(t, "task-id", response.Identifier)
+
+	_, err = a.AddNetworkInterface(context.Background(), "vm-id", "vlan-id", "10.0.0.1")
+	assert.NoError(t, err)
+
+	_, err = a.RemoveNetworkInterface(context.Background(), "vm-id", 4000)
+	assert.NoError(t, err)
+
+	_, err = a.AddNetworkInterface(context.Background(), "vm-id", "", "10.0.0.1")
+	assert.True(t, errors.Is(err, vm.ErrInvalidNetwork), "expected ErrInvalidNetwork but got %v", err)
+
+	if assert.Len(t, changes, 3) {
+		assert.EqualValues(t, []vm.Network{{NICType: vm.DefaultNICType, VLAN: "vlan-id"}}, changes[0].AddNICs)
+		assert.EqualValues(t, []vm.Network{{NICType: vm.DefaultNICType, VLAN: "vlan-id", IPs: []string{"10.0.0.1"}}}, changes[1].AddNICs)
+		assert.EqualValues(t, []int{4000}, changes[2].DeleteNICIDs)
+	}
+}
@@ -8,6 +8,8 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+
+	"github.com/anexia-it/go-anxcloud/pkg/client"
 )
 
 // ProvisioningResponse contains information returned by the API regarding a newly created VM.
@@ -25,11 +27,23 @@ var ErrProvisioning = errors.New("ProvisioningResponse contains errors")
 //
 // ctx is attached to the request and will cancel it on cancelation.
 // It does not affect the provisioning request after it was issued.
-// definition contains the definition of the VM to be created.
+// definition contains the definition of the VM to be created. If its location is empty,
+// the default location of the client is used.
 //
+// definition is validated before any request is sent, a *DefinitionError is returned if values are missing.
 // If the API call returns errors, they are raised as ErrProvisioning.
 // The returned ProvisioningResponse is still valid in this case.
 func (a api) Provision(ctx context.Context, definition Definition, scriptBase64Encoded bool) (ProvisioningResponse, error) {
+	if err := definition.Validate(); err != nil {
+		return ProvisioningResponse{}, err
+	}
+
+	location, err := client.ResolveLocation(a.client, definition.Location)
+	if err != nil {
+		return ProvisioningResponse{}, fmt.Errorf("could not provision VM: %w", err)
+	}
+	definition.Location = location
+
 	buf := bytes.Buffer{}
 
 	if definition.Script != "" && scriptBase64Encoded {
@@ -0,0 +1,47 @@
+package vm_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/anexia-it/go-anxcloud/pkg/client"
+	"github.com/anexia-it/go-anxcloud/pkg/vsphere/provisioning/vm"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProvisionDefaultLocation(t *testing.T) {
+	var path string
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.EqualValues(t, http.MethodPost, r.Method)
+		path = r.URL.Path
+		_, _ = w.Write([]byte(`{"identifier":"task-id","queued":true}`))
+	})
+	c, server := client.NewTestClient(nil, handler)
+	defer server.Close()
+	withDefault, err := client.New(client.TokenFromString("token"), client.WithDefaultLocation("default-location"))
+	if !assert.NoError(t, err) {
+		return
+	}
+	defaulted, defaultedServer := client.NewTestClient(withDefault, handler)
+	defer defaultedServer.Close()
+	definition := vm.NewAPI(c).NewDefinition("", "templates", "template-id", "web-001", 2, 2048, 20, nil)
+
+	response, err := vm.NewAPI(defaulted).Provision(context.Background(), definition, false)
+	if assert.NoError(t, err) {
+		assert.EqualValues(t, "task-id", response.Identifier)
+		assert.EqualValues(t, "/api/vsphere/v1/provisioning/vm.json/default-location/templates/template-id", path)
+	}
+
+	definition.Location = "given-location"
+	_, err = vm.NewAPI(defaulted).Provision(context.Background(), definition, false)
+	assert.NoError(t, err)
+	assert.EqualValues(t, "/api/vsphere/v1/provisioning/vm.json/given-location/templates/template-id", path)
+
+	path = ""
+	definition.Location = ""
+	_, err = vm.NewAPI(c).Provision(context.Background(), definition, false)
+	assert.True(t, errors.Is(err, client.ErrNoLocation), "expected ErrNoLocation but got %v", err)
+	assert.Empty(t, path, "requests without location must not be sent")
+}
@@ -10,4 +10,6 @@ const (
 	// DefaultDiskType to be used if a VM definition is created
 	// by NewDefinition.
 	DefaultDiskType = "ENT2"
+	// DefaultNICType to be used if a network interface is added by AddNetworkInterface.
+	DefaultNICType = "vmxnet3"
 )
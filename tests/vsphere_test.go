@@ -106,7 +106,7 @@ var _ = Describe("Vsphere API endpoint tests", func() {
 				}
 
 				By("Deleting the VM")
-				err = vm.NewAPI(cli).Deprovision(ctx, vmID, false)
+				_, err = vm.NewAPI(cli).Deprovision(ctx, vmID, false)
 				Expect(err).NotTo(HaveOccurred())
 			})
 		})